@@ -0,0 +1,93 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"runtime"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/biome"
+	"github.com/df-mc/dragonfly/server/world/generator"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+)
+
+// pregen pre-generates the chunks around a centre chunk of a world with the
+// default flat generators of the server, or trims all chunks outside of the
+// area. Pre-generation may be interrupted with Ctrl+C and resumed by running
+// the same command again. Servers using a custom world.Generator should call
+// world.World.Pregenerate instead.
+func main() {
+	dir := flag.String("world", "world", "folder of the world to pre-generate or trim")
+	dimName := flag.String("dim", "overworld", "dimension to pre-generate or trim: overworld, nether or end")
+	x := flag.Int("x", 0, "x coordinate of the centre chunk")
+	z := flag.Int("z", 0, "z coordinate of the centre chunk")
+	radius := flag.Int("radius", 32, "radius in chunks around the centre chunk")
+	circle := flag.Bool("circle", false, "use a circular instead of a square area")
+	workers := flag.Int("workers", runtime.NumCPU(), "number of chunks generated concurrently")
+	trim := flag.Bool("trim", false, "remove all chunks outside the area instead of generating the chunks inside it")
+	flag.Parse()
+
+	dim, ok := map[string]world.Dimension{"overworld": world.Overworld, "nether": world.Nether, "end": world.End}[*dimName]
+	if !ok {
+		log.Fatalln("Unknown dimension", *dimName)
+	}
+	area := world.ChunkArea{Centre: world.ChunkPos{int32(*x), int32(*z)}, Radius: int32(*radius), Circle: *circle}
+
+	db, err := mcdb.Open(*dir)
+	if err != nil {
+		log.Fatalln(err)
+	}
+	if *trim {
+		n, err := db.Trim(area, dim)
+		if err != nil {
+			log.Println(err)
+		}
+		log.Printf("Removed %v chunks.\n", n)
+		if err := db.Close(); err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+
+	w := world.Config{
+		Dim:              dim,
+		Provider:         db,
+		Generator:        flatGenerator(dim),
+		ChunkLoadWorkers: *workers,
+	}.New()
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+
+	start, last := time.Now(), time.Now()
+	prog, err := w.Pregenerate(ctx, area, func(p world.PregenProgress) {
+		if time.Since(last) >= time.Second {
+			last = time.Now()
+			log.Printf("%v/%v chunks (%.1f%%), %v generated, %v skipped\n", p.Done(), p.Total, float64(p.Done())/float64(p.Total)*100, p.Generated, p.Skipped)
+		}
+	})
+	if err != nil {
+		log.Println(err)
+	}
+	log.Printf("Finished %v/%v chunks in %v: %v generated, %v skipped.\n", prog.Done(), prog.Total, time.Since(start).Round(time.Millisecond), prog.Generated, prog.Skipped)
+	if err := w.Close(); err != nil {
+		log.Fatalln(err)
+	}
+}
+
+// flatGenerator returns the default flat generator of the server for the
+// world.Dimension passed.
+func flatGenerator(dim world.Dimension) world.Generator {
+	switch dim {
+	case world.Nether:
+		return generator.NewFlat(biome.NetherWastes{}, []world.Block{block.Netherrack{}, block.Netherrack{}, block.Netherrack{}, block.Bedrock{}})
+	case world.End:
+		return generator.NewFlat(biome.End{}, []world.Block{block.EndStone{}, block.EndStone{}, block.EndStone{}, block.Bedrock{}})
+	}
+	return generator.NewFlat(biome.Plains{}, []world.Block{block.Grass{}, block.Dirt{}, block.Dirt{}, block.Bedrock{}})
+}
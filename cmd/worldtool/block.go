@@ -0,0 +1,193 @@
+package main
+
+import (
+	"fmt"
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// blockSpec is a block state passed on the command line in the format
+// name[property=value,...]. Properties that are not set match any value.
+type blockSpec struct {
+	name  string
+	props map[string]string
+}
+
+// parseBlockSpec parses a blockSpec from s. If the name has no namespace, the
+// minecraft namespace is used.
+func parseBlockSpec(s string) (blockSpec, error) {
+	spec := blockSpec{name: s, props: map[string]string{}}
+	if i := strings.IndexByte(s, '['); i != -1 {
+		if !strings.HasSuffix(s, "]") {
+			return blockSpec{}, fmt.Errorf("invalid block %q: missing ]", s)
+		}
+		spec.name = s[:i]
+		for _, prop := range strings.Split(s[i+1:len(s)-1], ",") {
+			if prop = strings.TrimSpace(prop); prop == "" {
+				continue
+			}
+			k, v, ok := strings.Cut(prop, "=")
+			if !ok {
+				return blockSpec{}, fmt.Errorf("invalid block %q: property %q has no value", s, prop)
+			}
+			spec.props[strings.TrimSpace(k)] = strings.TrimSpace(v)
+		}
+	}
+	if !strings.Contains(spec.name, ":") {
+		spec.name = "minecraft:" + spec.name
+	}
+	return spec, nil
+}
+
+// matches checks if the block state with the name and properties passed
+// matches the blockSpec.
+func (spec blockSpec) matches(name string, props map[string]any) bool {
+	if name != spec.name {
+		return false
+	}
+	for k, s := range spec.props {
+		v, ok := props[k]
+		if !ok {
+			return false
+		}
+		if parsed, err := parseProperty(v, s); err != nil || parsed != v {
+			return false
+		}
+	}
+	return true
+}
+
+// runtimeIDs returns the set of runtime IDs of all block states that match
+// the blockSpec.
+func (spec blockSpec) runtimeIDs() map[uint32]struct{} {
+	br := world.DefaultBlockRegistry
+	m := make(map[uint32]struct{})
+	for rid := range uint32(br.BlockCount()) {
+		if name, props, ok := br.RuntimeIDToState(rid); ok && spec.matches(name, props) {
+			m[rid] = struct{}{}
+		}
+	}
+	return m
+}
+
+// runtimeID returns the runtime ID of the block state described by the
+// blockSpec, using the default value of every property that is not set.
+func (spec blockSpec) runtimeID() (uint32, error) {
+	br := world.DefaultBlockRegistry
+	rid, ok := br.StateToRuntimeID(spec.name, nil)
+	if !ok {
+		return 0, fmt.Errorf("unknown block %v", spec.name)
+	}
+	_, def, _ := br.RuntimeIDToState(rid)
+	props := maps.Clone(def)
+	for k, s := range spec.props {
+		v, ok := def[k]
+		if !ok {
+			return 0, fmt.Errorf("block %v has no property %v", spec.name, k)
+		}
+		parsed, err := parseProperty(v, s)
+		if err != nil {
+			return 0, fmt.Errorf("block %v: property %v: %w", spec.name, k, err)
+		}
+		props[k] = parsed
+	}
+	if rid, ok = br.StateToRuntimeID(spec.name, props); !ok {
+		return 0, fmt.Errorf("unknown block state %v", formatState(spec.name, props))
+	}
+	if name, found, _ := br.RuntimeIDToState(rid); !spec.matches(name, found) {
+		return 0, fmt.Errorf("unknown block state %v", formatState(spec.name, props))
+	}
+	return rid, nil
+}
+
+// parseProperty parses the property value s to the type of the existing value
+// v of the property.
+func parseProperty(v any, s string) (any, error) {
+	switch v.(type) {
+	case string:
+		return s, nil
+	case int32:
+		n, err := strconv.ParseInt(s, 10, 32)
+		return int32(n), err
+	case uint8:
+		switch s {
+		case "true", "1":
+			return uint8(1), nil
+		case "false", "0":
+			return uint8(0), nil
+		}
+		return nil, fmt.Errorf("invalid boolean %q", s)
+	case bool:
+		return strconv.ParseBool(s)
+	}
+	return nil, fmt.Errorf("unsupported property type %T", v)
+}
+
+// formatState formats a block state with a name and properties in the format
+// accepted by parseBlockSpec.
+func formatState(name string, props map[string]any) string {
+	if len(props) == 0 {
+		return name
+	}
+	parts := make([]string, 0, len(props))
+	for _, k := range slices.Sorted(maps.Keys(props)) {
+		parts = append(parts, fmt.Sprintf("%v=%v", k, props[k]))
+	}
+	return name + "[" + strings.Join(parts, ",") + "]"
+}
+
+// region is a cuboid of blocks between two corners, both included.
+type region struct {
+	min, max cube.Pos
+}
+
+// newRegion returns the region between the corners a and b, clamped to the
+// range of the world.Dimension passed.
+func newRegion(a, b cube.Pos, dim world.Dimension) region {
+	r := region{
+		min: cube.Pos{min(a[0], b[0]), min(a[1], b[1]), min(a[2], b[2])},
+		max: cube.Pos{max(a[0], b[0]), max(a[1], b[1]), max(a[2], b[2])},
+	}
+	r.min[1], r.max[1] = max(r.min[1], dim.Range()[0]), min(r.max[1], dim.Range()[1])
+	return r
+}
+
+// size returns the size of the region on every axis.
+func (r region) size() [3]int {
+	return [3]int{r.max[0] - r.min[0] + 1, r.max[1] - r.min[1] + 1, r.max[2] - r.min[2] + 1}
+}
+
+// contains checks if the position passed is within the region.
+func (r region) contains(pos cube.Pos) bool {
+	return pos[0] >= r.min[0] && pos[0] <= r.max[0] && pos[1] >= r.min[1] && pos[1] <= r.max[1] &&
+		pos[2] >= r.min[2] && pos[2] <= r.max[2]
+}
+
+// columns returns the positions of all chunks that the region intersects.
+func (r region) columns() []world.ChunkPos {
+	var positions []world.ChunkPos
+	for x := r.min[0] >> 4; x <= r.max[0]>>4; x++ {
+		for z := r.min[2] >> 4; z <= r.max[2]>>4; z++ {
+			positions = append(positions, world.ChunkPos{int32(x), int32(z)})
+		}
+	}
+	return positions
+}
+
+// positions calls f for every position in the region that is within the
+// chunk at the position passed.
+func (r region) positions(c world.ChunkPos, f func(pos cube.Pos)) {
+	cx, cz := int(c[0])<<4, int(c[1])<<4
+	for x := max(r.min[0], cx); x <= min(r.max[0], cx+15); x++ {
+		for z := max(r.min[2], cz); z <= min(r.max[2], cz+15); z++ {
+			for y := r.min[1]; y <= r.max[1]; y++ {
+				f(cube.Pos{x, y, z})
+			}
+		}
+	}
+}
@@ -0,0 +1,249 @@
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"github.com/df-mc/goleveldb/leveldb"
+)
+
+// listCommand lists the number of chunks in every dimension of the world, or
+// the positions of all chunks in a dimension.
+func listCommand(fs *flag.FlagSet) func(db *mcdb.DB, dim world.Dimension) error {
+	chunks := fs.Bool("chunks", false, "list the positions of the chunks in the dimension passed with -dim")
+	return func(db *mcdb.DB, dim world.Dimension) error {
+		if *chunks {
+			positions, err := db.ColumnPositions(&mcdb.IteratorRange{Dimension: dim})
+			for _, p := range positions {
+				fmt.Printf("%v %v\n", p.Pos[0], p.Pos[1])
+			}
+			return err
+		}
+		positions, err := db.ColumnPositions(nil)
+		if err != nil {
+			return err
+		}
+		counts := map[world.Dimension]int{}
+		for _, p := range positions {
+			counts[p.Dim]++
+		}
+		for _, d := range []world.Dimension{world.Overworld, world.Nether, world.End} {
+			if counts[d] > 0 {
+				fmt.Printf("%v: %v chunks\n", d, counts[d])
+			}
+		}
+		return nil
+	}
+}
+
+// blockCommand prints the blocks on both layers and the block entity at a
+// position.
+func blockCommand(fs *flag.FlagSet) func(db *mcdb.DB, dim world.Dimension) error {
+	at := fs.String("pos", "0,0,0", "position of the block in the format x,y,z")
+	return func(db *mcdb.DB, dim world.Dimension) error {
+		pos, err := parsePos(*at)
+		if err != nil {
+			return err
+		}
+		if pos.OutOfBounds(dim.Range()) {
+			return fmt.Errorf("position %v is outside the range of %v", pos, dim)
+		}
+		col, err := db.LoadColumn(world.ChunkPos{int32(pos[0] >> 4), int32(pos[2] >> 4)}, dim)
+		if err != nil {
+			return err
+		}
+		br := world.DefaultBlockRegistry
+		for layer := range uint8(2) {
+			name, props, _ := br.RuntimeIDToState(col.Chunk.Block(uint8(pos[0]&15), int16(pos[1]), uint8(pos[2]&15), layer))
+			fmt.Printf("layer %v: %v\n", layer, formatState(name, props))
+		}
+		for _, be := range col.BlockEntities {
+			if be.Pos == pos {
+				data, err := json.MarshalIndent(be.Data, "", "  ")
+				if err != nil {
+					return fmt.Errorf("encode block entity: %w", err)
+				}
+				fmt.Printf("block entity: %s\n", data)
+			}
+		}
+		return nil
+	}
+}
+
+// replaceCommand replaces all blocks matching a block state in a region with
+// another block state.
+func replaceCommand(fs *flag.FlagSet) func(db *mcdb.DB, dim world.Dimension) error {
+	from := fs.String("from", "", "block to replace, in the format name[property=value,...]; unset properties match any value")
+	to := fs.String("to", "", "block to replace with, in the format name[property=value,...]")
+	a := fs.String("min", "0,0,0", "first corner of the region in the format x,y,z")
+	b := fs.String("max", "0,0,0", "second corner of the region in the format x,y,z")
+	return func(db *mcdb.DB, dim world.Dimension) error {
+		fromSpec, err := parseBlockSpec(*from)
+		if err != nil {
+			return err
+		}
+		toSpec, err := parseBlockSpec(*to)
+		if err != nil {
+			return err
+		}
+		rid, err := toSpec.runtimeID()
+		if err != nil {
+			return err
+		}
+		toBlock, _ := world.BlockByRuntimeID(rid)
+		matches := fromSpec.runtimeIDs()
+		if len(matches) == 0 {
+			return fmt.Errorf("no block states match %v", *from)
+		}
+		r, err := parseRegion(*a, *b, dim)
+		if err != nil {
+			return err
+		}
+
+		n := 0
+		for _, pos := range r.columns() {
+			col, err := db.LoadColumn(pos, dim)
+			if errors.Is(err, leveldb.ErrNotFound) {
+				continue
+			} else if err != nil {
+				return err
+			}
+			replaced := map[cube.Pos]struct{}{}
+			r.positions(pos, func(p cube.Pos) {
+				x, y, z := uint8(p[0]&15), int16(p[1]), uint8(p[2]&15)
+				if _, ok := matches[col.Chunk.Block(x, y, z, 0)]; ok {
+					col.Chunk.SetBlock(x, y, z, 0, rid)
+					replaced[p] = struct{}{}
+				}
+			})
+			if len(replaced) == 0 {
+				continue
+			}
+			col.BlockEntities = slices.DeleteFunc(col.BlockEntities, func(be chunk.BlockEntity) bool {
+				_, ok := replaced[be.Pos]
+				return ok
+			})
+			if nbter, ok := toBlock.(world.NBTer); ok {
+				// Blocks such as chests need a block entity, so a new, empty
+				// block entity is created for every block replaced.
+				for p := range replaced {
+					col.BlockEntities = append(col.BlockEntities, chunk.BlockEntity{Pos: p, Data: nbter.EncodeNBT()})
+				}
+			}
+			if err := db.StoreColumn(pos, dim, col); err != nil {
+				return err
+			}
+			n += len(replaced)
+		}
+		fmt.Printf("Replaced %v blocks.\n", n)
+		return nil
+	}
+}
+
+// removeEntitiesCommand removes all entities with one of the types passed
+// from the dimension.
+func removeEntitiesCommand(fs *flag.FlagSet) func(db *mcdb.DB, dim world.Dimension) error {
+	types := fs.String("type", "", "comma separated entity types to remove, such as minecraft:zombie")
+	return func(db *mcdb.DB, dim world.Dimension) error {
+		remove := map[string]struct{}{}
+		for _, t := range strings.Split(*types, ",") {
+			if t = strings.TrimSpace(t); t == "" {
+				continue
+			}
+			if !strings.Contains(t, ":") {
+				t = "minecraft:" + t
+			}
+			remove[t] = struct{}{}
+		}
+		if len(remove) == 0 {
+			return errors.New("no entity types passed with -type")
+		}
+
+		n := 0
+		iter := db.NewColumnIterator(&mcdb.IteratorRange{Dimension: dim})
+		defer iter.Release()
+		for iter.Next() {
+			col := iter.Column()
+			before := len(col.Entities)
+			col.Entities = slices.DeleteFunc(col.Entities, func(e chunk.Entity) bool {
+				id, _ := e.Data["identifier"].(string)
+				_, ok := remove[id]
+				return ok
+			})
+			if removed := before - len(col.Entities); removed > 0 {
+				if err := db.StoreColumn(iter.Position(), dim, col); err != nil {
+					return err
+				}
+				n += removed
+			}
+		}
+		if err := iter.Error(); err != nil {
+			return err
+		}
+		fmt.Printf("Removed %v entities of type %v.\n", n, strings.Join(slices.Sorted(maps.Keys(remove)), ", "))
+		return nil
+	}
+}
+
+// repairCommand removes the corrupt sub chunks and biomes of all chunks in
+// the dimension that cannot be loaded. Chunks that still cannot be loaded
+// afterwards are optionally deleted.
+func repairCommand(fs *flag.FlagSet) func(db *mcdb.DB, dim world.Dimension) error {
+	del := fs.Bool("delete", false, "delete chunks that cannot be repaired, so that they are generated again")
+	return func(db *mcdb.DB, dim world.Dimension) error {
+		positions, err := db.ColumnPositions(&mcdb.IteratorRange{Dimension: dim})
+		if err != nil {
+			return err
+		}
+		repaired, deleted, failed := 0, 0, 0
+		for _, p := range positions {
+			if _, err := db.LoadColumn(p.Pos, dim); err == nil {
+				continue
+			}
+			subChunks, biomes, err := db.RepairColumn(p.Pos, dim)
+			if err != nil {
+				return err
+			}
+			if len(subChunks) > 0 || biomes {
+				fmt.Printf("Chunk %v: removed sub chunks %v, removed biomes: %v\n", p.Pos, subChunks, biomes)
+			}
+			if _, err := db.LoadColumn(p.Pos, dim); err == nil {
+				repaired++
+				continue
+			} else if !*del {
+				fmt.Printf("Chunk %v could not be repaired: %v\n", p.Pos, err)
+				failed++
+				continue
+			}
+			if err := db.DeleteColumn(p.Pos, dim); err != nil {
+				return err
+			}
+			fmt.Printf("Chunk %v could not be repaired and was deleted.\n", p.Pos)
+			deleted++
+		}
+		fmt.Printf("Checked %v chunks: %v repaired, %v deleted, %v failed.\n", len(positions), repaired, deleted, failed)
+		return nil
+	}
+}
+
+// parseRegion parses the corners a and b of a region in the format x,y,z.
+func parseRegion(a, b string, dim world.Dimension) (region, error) {
+	minPos, err := parsePos(a)
+	if err != nil {
+		return region{}, err
+	}
+	maxPos, err := parsePos(b)
+	if err != nil {
+		return region{}, err
+	}
+	return newRegion(minPos, maxPos, dim), nil
+}
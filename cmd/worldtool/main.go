@@ -0,0 +1,119 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	// Blocks are registered so that block entities can be created for them.
+	_ "github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+)
+
+// command is a sub command of worldtool. setup registers the flags of the
+// command and returns the function that runs the command once the flags are
+// parsed.
+type command struct {
+	name, usage string
+	setup       func(fs *flag.FlagSet) func(db *mcdb.DB, dim world.Dimension) error
+}
+
+var commands = []command{
+	{name: "list", usage: "list the dimensions and chunks of the world", setup: listCommand},
+	{name: "block", usage: "print the blocks and block entity at a position", setup: blockCommand},
+	{name: "replace", usage: "replace blocks in a region", setup: replaceCommand},
+	{name: "export", usage: "export a region to an .mcstructure file", setup: exportCommand},
+	{name: "import", usage: "import an .mcstructure file into the world", setup: importCommand},
+	{name: "remove-entities", usage: "remove all entities of one or more types", setup: removeEntitiesCommand},
+	{name: "repair", usage: "remove corrupt sub chunks so that chunks can be loaded again", setup: repairCommand},
+}
+
+// worldtool inspects and edits worlds stored in the mcdb format while no
+// server is running.
+func main() {
+	if len(os.Args) < 2 {
+		printUsage()
+		os.Exit(2)
+	}
+	for _, cmd := range commands {
+		if cmd.name != os.Args[1] {
+			continue
+		}
+		fs := flag.NewFlagSet(cmd.name, flag.ExitOnError)
+		dir := fs.String("world", "world", "folder of the world")
+		dimName := fs.String("dim", "overworld", "dimension: overworld, nether or end")
+		run := cmd.setup(fs)
+		_ = fs.Parse(os.Args[2:])
+
+		dim, ok := map[string]world.Dimension{"overworld": world.Overworld, "nether": world.Nether, "end": world.End}[*dimName]
+		if !ok {
+			log.Fatalln("Unknown dimension", *dimName)
+		}
+		if err := checkWorld(*dir); err != nil {
+			log.Fatalln(err)
+		}
+		world.DefaultBlockRegistry.Finalize()
+		db, err := mcdb.Open(*dir)
+		if err != nil {
+			log.Fatalln(err)
+		}
+		err = run(db, dim)
+		if cerr := db.Close(); cerr != nil {
+			log.Println(cerr)
+		}
+		if err != nil {
+			log.Fatalln(err)
+		}
+		return
+	}
+	printUsage()
+	os.Exit(2)
+}
+
+// checkWorld checks if dir is the folder of an existing world. mcdb.Open
+// creates a new world if none exists, which is never what is intended when
+// inspecting or editing a world.
+func checkWorld(dir string) error {
+	for _, path := range []string{dir, filepath.Join(dir, "db")} {
+		info, err := os.Stat(path)
+		if err != nil {
+			return fmt.Errorf("open world: %w", err)
+		}
+		if !info.IsDir() {
+			return fmt.Errorf("open world: %v is not a folder", path)
+		}
+	}
+	return nil
+}
+
+// printUsage prints the commands of worldtool.
+func printUsage() {
+	fmt.Fprint(os.Stderr, "Usage: worldtool <command> [flags]\n\nCommands:\n")
+	for _, cmd := range commands {
+		fmt.Fprintf(os.Stderr, "  %-16v %v\n", cmd.name, cmd.usage)
+	}
+	fmt.Fprintln(os.Stderr, "\nRun worldtool <command> -h for the flags of a command.")
+}
+
+// parsePos parses a block position in the format x,y,z.
+func parsePos(s string) (cube.Pos, error) {
+	parts := strings.Split(s, ",")
+	if len(parts) != 3 {
+		return cube.Pos{}, fmt.Errorf("invalid position %q: expected x,y,z", s)
+	}
+	var pos cube.Pos
+	for i, p := range parts {
+		v, err := strconv.Atoi(strings.TrimSpace(p))
+		if err != nil {
+			return cube.Pos{}, fmt.Errorf("invalid position %q: %w", s, err)
+		}
+		pos[i] = v
+	}
+	return pos, nil
+}
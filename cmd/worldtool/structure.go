@@ -0,0 +1,227 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"maps"
+	"os"
+	"slices"
+	"strconv"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/dragonfly/server/world/mcdb"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// structureFile is the NBT structure of an .mcstructure file, as written by
+// structure blocks in vanilla.
+type structureFile struct {
+	FormatVersion int32   `nbt:"format_version"`
+	Size          []int32 `nbt:"size"`
+	Structure     struct {
+		// BlockIndices holds an index into the palette for every block in the
+		// structure, for both layers, in ZYX order. -1 means no block.
+		BlockIndices [][]int32        `nbt:"block_indices"`
+		Entities     []map[string]any `nbt:"entities"`
+		Palette      struct {
+			Default struct {
+				BlockPalette      []structureBlock          `nbt:"block_palette"`
+				BlockPositionData map[string]map[string]any `nbt:"block_position_data"`
+			} `nbt:"default"`
+		} `nbt:"palette"`
+	} `nbt:"structure"`
+	Origin []int32 `nbt:"structure_world_origin"`
+}
+
+// structureBlock is a block state in the palette of a structureFile.
+type structureBlock struct {
+	Name    string         `nbt:"name"`
+	States  map[string]any `nbt:"states"`
+	Version int32          `nbt:"version"`
+}
+
+// exportCommand exports the blocks and block entities in a region to an
+// .mcstructure file. Entities are not exported.
+func exportCommand(fs *flag.FlagSet) func(db *mcdb.DB, dim world.Dimension) error {
+	a := fs.String("min", "0,0,0", "first corner of the region in the format x,y,z")
+	b := fs.String("max", "0,0,0", "second corner of the region in the format x,y,z")
+	out := fs.String("o", "region.mcstructure", "file to export the region to")
+	return func(db *mcdb.DB, dim world.Dimension) error {
+		r, err := parseRegion(*a, *b, dim)
+		if err != nil {
+			return err
+		}
+		size := r.size()
+		var s structureFile
+		s.FormatVersion = 1
+		s.Size = []int32{int32(size[0]), int32(size[1]), int32(size[2])}
+		s.Origin = []int32{int32(r.min[0]), int32(r.min[1]), int32(r.min[2])}
+		s.Structure.Entities = []map[string]any{}
+		s.Structure.Palette.Default.BlockPositionData = map[string]map[string]any{}
+		layers := [][]int32{make([]int32, size[0]*size[1]*size[2]), make([]int32, size[0]*size[1]*size[2])}
+
+		br := world.DefaultBlockRegistry
+		palette := map[uint32]int32{}
+		paletteIndex := func(rid uint32) int32 {
+			if i, ok := palette[rid]; ok {
+				return i
+			}
+			name, props, _ := br.RuntimeIDToState(rid)
+			i := int32(len(s.Structure.Palette.Default.BlockPalette))
+			s.Structure.Palette.Default.BlockPalette = append(s.Structure.Palette.Default.BlockPalette, structureBlock{Name: name, States: props, Version: chunk.CurrentBlockVersion})
+			palette[rid] = i
+			return i
+		}
+		for _, pos := range r.columns() {
+			col, err := db.LoadColumn(pos, dim)
+			notFound := errors.Is(err, leveldb.ErrNotFound)
+			if err != nil && !notFound {
+				return err
+			}
+			r.positions(pos, func(p cube.Pos) {
+				i := structureIndex(p, r)
+				if notFound {
+					layers[0][i], layers[1][i] = -1, -1
+					return
+				}
+				x, y, z := uint8(p[0]&15), int16(p[1]), uint8(p[2]&15)
+				layers[0][i] = paletteIndex(col.Chunk.Block(x, y, z, 0))
+				if rid := col.Chunk.Block(x, y, z, 1); rid != br.AirRuntimeID() {
+					layers[1][i] = paletteIndex(rid)
+				} else {
+					layers[1][i] = -1
+				}
+			})
+			if notFound {
+				continue
+			}
+			for _, be := range col.BlockEntities {
+				if r.contains(be.Pos) {
+					s.Structure.Palette.Default.BlockPositionData[strconv.Itoa(structureIndex(be.Pos, r))] = map[string]any{"block_entity_data": be.Data}
+				}
+			}
+		}
+		s.Structure.BlockIndices = layers
+
+		data, err := nbt.MarshalEncoding(s, nbt.LittleEndian)
+		if err != nil {
+			return fmt.Errorf("encode structure: %w", err)
+		}
+		if err := os.WriteFile(*out, data, 0644); err != nil {
+			return err
+		}
+		fmt.Printf("Exported %vx%vx%v blocks to %v.\n", size[0], size[1], size[2], *out)
+		return nil
+	}
+}
+
+// importCommand places the blocks and block entities of an .mcstructure file
+// in the world. Chunks that do not exist yet are created.
+func importCommand(fs *flag.FlagSet) func(db *mcdb.DB, dim world.Dimension) error {
+	in := fs.String("i", "region.mcstructure", "file to import")
+	at := fs.String("pos", "", "position of the lowest corner of the structure in the format x,y,z; defaults to the origin it was exported from")
+	return func(db *mcdb.DB, dim world.Dimension) error {
+		data, err := os.ReadFile(*in)
+		if err != nil {
+			return err
+		}
+		var s structureFile
+		if err := nbt.UnmarshalEncoding(data, &s, nbt.LittleEndian); err != nil {
+			return fmt.Errorf("decode structure: %w", err)
+		}
+		if len(s.Size) != 3 || len(s.Structure.BlockIndices) == 0 {
+			return errors.New("decode structure: missing size or block indices")
+		}
+		origin := cube.Pos{}
+		if len(s.Origin) == 3 {
+			origin = cube.Pos{int(s.Origin[0]), int(s.Origin[1]), int(s.Origin[2])}
+		}
+		if *at != "" {
+			if origin, err = parsePos(*at); err != nil {
+				return err
+			}
+		}
+		// The region is not clamped to the range of the dimension here, so
+		// that indices into the structure remain valid.
+		r := region{min: origin, max: origin.Add(cube.Pos{int(s.Size[0]) - 1, int(s.Size[1]) - 1, int(s.Size[2]) - 1})}
+		if n := s.Size[0] * s.Size[1] * s.Size[2]; int(n) != len(s.Structure.BlockIndices[0]) {
+			return fmt.Errorf("decode structure: expected %v block indices, got %v", n, len(s.Structure.BlockIndices[0]))
+		}
+
+		bpe := chunk.BlockPaletteEncoding{Blocks: world.DefaultBlockRegistry}
+		palette := make([]uint32, len(s.Structure.Palette.Default.BlockPalette))
+		for i, b := range s.Structure.Palette.Default.BlockPalette {
+			rid, err := bpe.DecodeBlockState(map[string]any{"name": b.Name, "states": b.States, "version": b.Version})
+			if err != nil {
+				return fmt.Errorf("decode structure palette: %w", err)
+			}
+			palette[i] = rid
+		}
+		blockEntities := map[cube.Pos]map[string]any{}
+		for k, v := range s.Structure.Palette.Default.BlockPositionData {
+			i, err := strconv.Atoi(k)
+			data, ok := v["block_entity_data"].(map[string]any)
+			if err != nil || !ok {
+				continue
+			}
+			pos := structurePos(i, r)
+			data = maps.Clone(data)
+			data["x"], data["y"], data["z"] = int32(pos[0]), int32(pos[1]), int32(pos[2])
+			blockEntities[pos] = data
+		}
+
+		n := 0
+		for _, pos := range r.columns() {
+			col, err := db.LoadColumn(pos, dim)
+			if errors.Is(err, leveldb.ErrNotFound) {
+				col = &chunk.Column{Chunk: chunk.New(world.DefaultBlockRegistry, dim.Range())}
+			} else if err != nil {
+				return err
+			}
+			r.positions(pos, func(p cube.Pos) {
+				if p.OutOfBounds(dim.Range()) {
+					return
+				}
+				i := structureIndex(p, r)
+				x, y, z := uint8(p[0]&15), int16(p[1]), uint8(p[2]&15)
+				for layer, indices := range s.Structure.BlockIndices[:min(2, len(s.Structure.BlockIndices))] {
+					if idx := indices[i]; idx >= 0 && int(idx) < len(palette) {
+						col.Chunk.SetBlock(x, y, z, uint8(layer), palette[idx])
+						n += 1 - layer
+					}
+				}
+			})
+			col.BlockEntities = slices.DeleteFunc(col.BlockEntities, func(be chunk.BlockEntity) bool {
+				return r.contains(be.Pos)
+			})
+			for p, data := range blockEntities {
+				if (world.ChunkPos{int32(p[0] >> 4), int32(p[2] >> 4)}) == pos && !p.OutOfBounds(dim.Range()) {
+					col.BlockEntities = append(col.BlockEntities, chunk.BlockEntity{Pos: p, Data: data})
+				}
+			}
+			if err := db.StoreColumn(pos, dim, col); err != nil {
+				return err
+			}
+		}
+		fmt.Printf("Imported %v blocks at %v.\n", n, origin)
+		return nil
+	}
+}
+
+// structureIndex returns the index of the position passed in the block
+// indices of a structure covering the region r.
+func structureIndex(pos cube.Pos, r region) int {
+	size, rel := r.size(), pos.Sub(r.min)
+	return (rel[0]*size[1]+rel[1])*size[2] + rel[2]
+}
+
+// structurePos returns the position of the block at index i in the block
+// indices of a structure covering the region r.
+func structurePos(i int, r region) cube.Pos {
+	size := r.size()
+	return r.min.Add(cube.Pos{i / (size[1] * size[2]), i / size[2] % size[1], i % size[2]})
+}
@@ -40,9 +40,7 @@ func (b BlastFurnace) Tick(_ int64, pos cube.Pos, tx *world.Tx) {
 	if b.Lit && rand.Float64() <= 0.016 { // Every three or so seconds.
 		tx.PlaySound(pos.Vec3Centre(), sound.BlastFurnaceCrackle{})
 	}
-	if lit := b.tickSmelting(time.Second*5, time.Millisecond*200, b.Lit, func(i item.SmeltInfo) bool {
-		return i.Ores
-	}); b.Lit != lit {
+	if lit := b.tickSmelting(time.Second*5, time.Millisecond*200, b.Lit, "blast_furnace"); b.Lit != lit {
 		b.Lit = lit
 		tx.SetBlock(pos, b, nil)
 	}
@@ -0,0 +1,72 @@
+package block
+
+import (
+	"image/color"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/potion"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestBrewingStandBrewsCustomPotion(t *testing.T) {
+	haste := registerPotion(t, potion.Custom{
+		Name:    "test:haste",
+		Effects: []effect.Effect{effect.New(effect.Haste, 1, time.Minute)},
+		Colour:  color.RGBA{R: 0xd9, G: 0xc0, B: 0x43, A: 0xff},
+		Base:    potion.Swiftness(),
+	})
+	registerRecipe(t, recipe.NewPotion(item.NewStack(item.Potion{Type: potion.Awkward()}, 1), item.NewStack(item.Diamond{}, 1), item.NewStack(item.Potion{Type: haste}, 1)))
+	registerRecipe(t, recipe.NewPotionContainerChange(item.Potion{}, item.SplashPotion{}, item.NewStack(item.Gunpowder{}, 1)))
+
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		b := NewBrewingStand()
+		inv := b.Inventory(tx, pos)
+		_ = inv.SetItem(0, item.NewStack(item.Diamond{}, 1))
+		_ = inv.SetItem(1, item.NewStack(item.Potion{Type: potion.Awkward()}, 1))
+		_ = inv.SetItem(2, item.NewStack(item.Potion{Type: potion.Swiftness()}, 1))
+		_ = inv.SetItem(4, item.NewStack(item.BlazePowder{}, 1))
+		tx.SetBlock(pos, b, nil)
+
+		for i := 0; i < 400; i++ {
+			b.tickBrewing("brewing_stand", pos, tx)
+		}
+		if it, _ := inv.Item(1); it.Item() != (item.Potion{Type: haste}) {
+			t.Fatalf("expected awkward potion to be brewed into custom potion, got %v", it)
+		}
+		if it, _ := inv.Item(2); it.Item() != (item.Potion{Type: potion.Swiftness()}) {
+			t.Errorf("expected swiftness potion sharing the metadata of the custom potion to be left alone, got %v", it)
+		}
+
+		_ = inv.SetItem(0, item.NewStack(item.Gunpowder{}, 1))
+		for i := 0; i < 400; i++ {
+			b.tickBrewing("brewing_stand", pos, tx)
+		}
+		if it, _ := inv.Item(1); it.Item() != (item.SplashPotion{Type: haste}) {
+			t.Errorf("expected custom potion to be turned into a custom splash potion, got %v", it)
+		}
+	})
+
+	if _, meta := (item.Potion{Type: haste}).EncodeItem(); meta != int16(potion.Swiftness().Uint8()) {
+		t.Errorf("expected custom potion to be encoded with the metadata of its base potion, got %v", meta)
+	}
+	if decoded := (item.Potion{Type: potion.Swiftness()}).DecodeNBT((item.Potion{Type: haste}).EncodeNBT()); decoded != (item.Potion{Type: haste}) {
+		t.Errorf("expected custom potion type to be restored from NBT, got %v", decoded)
+	}
+}
+
+// registerPotion registers a custom potion type for the duration of the test t, so that it does not affect
+// other tests.
+func registerPotion(t *testing.T, c potion.Custom) potion.Potion {
+	p := potion.Register(c)
+	t.Cleanup(func() { potion.Unregister(c.Name) })
+	return p
+}
@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/model"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl64"
@@ -127,12 +128,11 @@ func (c Campfire) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User,
 		return true
 	}
 
-	rawFood, ok := held.Item().(item.Smeltable)
-	if !ok || !rawFood.SmeltInfo().Food {
+	if _, ok := recipe.Smelt(c.recipeBlock(), held); !ok {
 		return false
 	}
 
-	if _, ok = tx.Liquid(pos); ok {
+	if _, ok := tx.Liquid(pos); ok {
 		return false
 	}
 
@@ -189,8 +189,8 @@ func (c Campfire) Tick(_ int64, pos cube.Pos, tx *world.Tx) {
 			continue
 		}
 
-		if food, ok := it.Item.Item().(item.Smeltable); ok {
-			dropItem(tx, food.SmeltInfo().Product, pos.Vec3Middle())
+		if r, ok := recipe.Smelt(c.recipeBlock(), it.Item); ok {
+			dropItem(tx, r.Output()[0], pos.Vec3Middle())
 		}
 		c.Items[i].Item = item.Stack{}
 	}
@@ -262,6 +262,14 @@ func (c Campfire) DecodeNBT(data map[string]any) any {
 	return c
 }
 
+// recipeBlock returns the block name used by furnace recipes that are cooked on the campfire.
+func (c Campfire) recipeBlock() string {
+	if c.Type == SoulFire() {
+		return "soul_campfire"
+	}
+	return "campfire"
+}
+
 // EncodeItem ...
 func (c Campfire) EncodeItem() (name string, meta int16) {
 	switch c.Type {
@@ -0,0 +1,397 @@
+package block
+
+import (
+	"fmt"
+	"math/rand/v2"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/sound"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Crafter is a redstone component that crafts items automatically. When it receives a redstone pulse, the
+// items in its 3x3 grid are matched against the crafting recipes, and the result is ejected from its front.
+type Crafter struct {
+	solid
+
+	// Facing is the direction that the front of the crafter is facing. Crafted items are ejected from this
+	// side.
+	Facing cube.Face
+	// Top is the direction that the top of the crafter is facing if Facing is cube.FaceUp or cube.FaceDown. The
+	// top of a crafter facing a horizontal direction always faces up, in which case Top must be cube.North.
+	Top cube.Direction
+	// Triggered is whether the crafter is currently receiving redstone power.
+	Triggered bool
+	// Crafting is true for a short duration after the crafter crafted an item.
+	Crafting bool
+	// DisabledSlots holds the slots of the grid that are disabled. Disabled slots cannot hold items and are
+	// treated as empty when crafting.
+	DisabledSlots [9]bool
+	// CustomName is the custom name of the crafter. This name is displayed when the crafter is opened, and may
+	// include colour codes.
+	CustomName string
+
+	inventory *inventory.Inventory
+	viewerMu  *sync.RWMutex
+	viewers   map[ContainerViewer]struct{}
+	// queued is true if the crafter received a redstone pulse while crafting. It crafts again once the
+	// crafting state is reset.
+	queued bool
+}
+
+// NewCrafter creates a new initialised crafter. The inventory is properly initialised.
+func NewCrafter() Crafter {
+	m := new(sync.RWMutex)
+	v := make(map[ContainerViewer]struct{}, 1)
+	return Crafter{
+		inventory: inventory.New(9, func(slot int, _, item item.Stack) {
+			m.RLock()
+			defer m.RUnlock()
+			for viewer := range v {
+				viewer.ViewSlotChange(slot, item)
+			}
+		}),
+		viewerMu: m,
+		viewers:  v,
+	}
+}
+
+func (Crafter) ContainerSize() int { return 9 }
+
+// Inventory returns the inventory of the crafter. The size of the inventory will be 9.
+func (c Crafter) Inventory(*world.Tx, cube.Pos) *inventory.Inventory {
+	return c.inventory
+}
+
+// WithName returns the crafter after applying a specific name to the block.
+func (c Crafter) WithName(a ...any) world.Item {
+	c.CustomName = strings.TrimSuffix(fmt.Sprintln(a...), "\n")
+	return c
+}
+
+// AddViewer adds a viewer to the crafter, so that it is updated whenever the inventory of the crafter is
+// changed.
+func (c Crafter) AddViewer(v ContainerViewer, _ *world.Tx, _ cube.Pos) {
+	c.viewerMu.Lock()
+	defer c.viewerMu.Unlock()
+	c.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a viewer from the crafter, so that slot updates in the inventory are no longer sent to
+// it.
+func (c Crafter) RemoveViewer(v ContainerViewer, _ *world.Tx, _ cube.Pos) {
+	c.viewerMu.Lock()
+	defer c.viewerMu.Unlock()
+	delete(c.viewers, v)
+}
+
+// ToggleSlot disables or enables a slot of the crafter at the position passed. A slot can only be disabled if
+// it does not hold an item.
+func (c Crafter) ToggleSlot(pos cube.Pos, tx *world.Tx, slot int, disabled bool) error {
+	if slot < 0 || slot >= len(c.DisabledSlots) {
+		return fmt.Errorf("crafter slot %v out of range", slot)
+	}
+	if it, _ := c.inventory.Item(slot); disabled && !it.Empty() {
+		return fmt.Errorf("crafter slot %v cannot be disabled while holding an item", slot)
+	}
+	if c.DisabledSlots[slot] == disabled {
+		return nil
+	}
+	c.DisabledSlots[slot] = disabled
+	tx.PlaySound(pos.Vec3Centre(), sound.CrafterDisableSlot{})
+	tx.SetBlock(pos, c, nil)
+	return nil
+}
+
+// ComparatorSignal returns the signal strength that a comparator reads from the crafter. It is equal to the
+// number of slots of the grid that either hold an item or are disabled.
+func (c Crafter) ComparatorSignal(cube.Pos, *world.Tx) int {
+	signal := 0
+	for slot, it := range c.inventory.Slots() {
+		if !it.Empty() || c.DisabledSlots[slot] {
+			signal++
+		}
+	}
+	return signal
+}
+
+// Activate ...
+func (Crafter) Activate(pos cube.Pos, _ cube.Face, tx *world.Tx, u item.User, _ *item.UseContext) bool {
+	if opener, ok := u.(ContainerOpener); ok {
+		opener.OpenBlockContainer(pos, tx)
+		return true
+	}
+	return false
+}
+
+// UseOnBlock ...
+func (c Crafter) UseOnBlock(pos cube.Pos, face cube.Face, _ mgl64.Vec3, tx *world.Tx, user item.User, ctx *item.UseContext) bool {
+	pos, _, used := firstReplaceable(tx, pos, face, c)
+	if !used {
+		return false
+	}
+	//noinspection GoAssignmentToReceiver
+	c = NewCrafter()
+	c.Facing = calculateFace(user, pos)
+	switch c.Facing {
+	case cube.FaceUp:
+		c.Top = user.Rotation().Direction()
+	case cube.FaceDown:
+		c.Top = user.Rotation().Direction().Opposite()
+	}
+
+	place(tx, pos, c, user, ctx)
+	return placed(ctx)
+}
+
+// RedstonePowerUpdate updates the triggered state of the crafter. The crafting itself is deferred to the
+// post-update so that cancellation can suppress it. A rising edge received while the crafter is crafting is
+// queued until the crafting state is reset.
+func (c Crafter) RedstonePowerUpdate(_ cube.Pos, _ *world.Tx, power int) (world.Block, bool) {
+	powered := power > 0
+	if powered == c.Triggered {
+		return c, false
+	}
+	c.Triggered = powered
+	if powered && c.Crafting {
+		c.queued = true
+	}
+	return c, true
+}
+
+// RedstonePowerPostUpdate schedules the crafter to craft after an uncancelled rising redstone edge, or
+// reschedules the reset of its crafting state if the triggered state changed while crafting.
+func (c Crafter) RedstonePowerPostUpdate(pos cube.Pos, tx *world.Tx, before, after world.Block, _, _ int) {
+	beforeCrafter, beforeOK := before.(Crafter)
+	afterCrafter, afterOK := after.(Crafter)
+	if !beforeOK || !afterOK || beforeCrafter.Triggered == afterCrafter.Triggered {
+		return
+	}
+	if afterCrafter.Crafting {
+		// Scheduled ticks only run for the block they were scheduled for, so the reset of the crafting
+		// state scheduled before the triggered state changed is rescheduled for the new block.
+		tx.ScheduleBlockUpdate(pos, afterCrafter, redstoneTicks(3))
+		return
+	}
+	if afterCrafter.Triggered {
+		tx.ScheduleBlockUpdate(pos, afterCrafter, redstoneTicks(2))
+	}
+}
+
+// ScheduledTick crafts an item from the grid of the crafter, or resets the crafting state of the crafter if
+// it crafted an item recently. If a redstone pulse was queued while crafting, the crafter crafts again after
+// the reset.
+func (c Crafter) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
+	if c.Crafting {
+		queued := c.queued
+		c.Crafting, c.queued = false, false
+		tx.SetBlock(pos, c, nil)
+		if queued {
+			tx.ScheduleBlockUpdate(pos, c, redstoneTicks(2))
+		}
+		return
+	}
+	if !c.craft(pos, tx) {
+		tx.PlaySound(pos.Vec3Centre(), sound.CrafterFail{})
+		return
+	}
+	tx.PlaySound(pos.Vec3Centre(), sound.CrafterCraft{})
+	c.Crafting = true
+	tx.SetBlock(pos, c, nil)
+	tx.ScheduleBlockUpdate(pos, c, redstoneTicks(3))
+}
+
+// craft matches the grid of the crafter against the registered crafting recipes. If a recipe matches, one
+// item is consumed from every filled slot and the output of the recipe is ejected, together with the items
+// left behind by the items consumed, such as the empty bucket of a milk bucket.
+func (c Crafter) craft(pos cube.Pos, tx *world.Tx) bool {
+	grid := c.inventory.Slots()
+	for slot := range grid {
+		if c.DisabledSlots[slot] {
+			grid[slot] = item.Stack{}
+		}
+	}
+	output, ok := recipe.Craft("crafting_table", grid, 3, 3)
+	if !ok {
+		return false
+	}
+	for slot, it := range grid {
+		if !it.Empty() {
+			_ = c.inventory.SetItem(slot, it.Grow(-1))
+		}
+	}
+	for _, it := range append(output, craftingRemainders(grid, output)...) {
+		c.eject(pos, tx, it)
+	}
+	return true
+}
+
+// craftingRemainders returns the items left behind by the items in the grid passed when they are consumed by a
+// recipe with the output passed. Vanilla recipes already hold these items in their output, such as the empty
+// buckets of the cake recipe, so items that are found in the output are not returned again.
+func craftingRemainders(grid, output []item.Stack) []item.Stack {
+	var remainders []item.Stack
+	for _, it := range grid {
+		if r, ok := craftingRemainder(it); ok {
+			remainders = append(remainders, r)
+		}
+	}
+	for _, it := range output {
+		for n := it.Count(); n > 0; n-- {
+			i := slices.IndexFunc(remainders, it.Comparable)
+			if i == -1 {
+				break
+			}
+			remainders = slices.Delete(remainders, i, i+1)
+		}
+	}
+	return remainders
+}
+
+// craftingRemainder returns the item left behind by an item consumed when crafting, as in a crafting table,
+// and whether the item leaves anything behind.
+func craftingRemainder(it item.Stack) (item.Stack, bool) {
+	switch i := it.Item().(type) {
+	case item.Bucket:
+		if !i.Empty() {
+			return item.NewStack(item.Bucket{}, 1), true
+		}
+	case item.HoneyBottle:
+		return item.NewStack(item.GlassBottle{}, 1), true
+	}
+	return item.Stack{}, false
+}
+
+// eject ejects an item stack from the front of the crafter. If a container is placed in front of the
+// crafter, the item is added to its inventory instead. Items that do not fit in the container are dropped.
+func (c Crafter) eject(pos cube.Pos, tx *world.Tx, it item.Stack) {
+	front := pos.Side(c.Facing)
+	if container, ok := tx.Block(front).(Container); ok {
+		n, _ := container.Inventory(tx, front).AddItem(it)
+		if it = it.Grow(-n); it.Empty() {
+			return
+		}
+	}
+	dir := front.Vec3Centre().Sub(pos.Vec3Centre())
+	create := tx.World().EntityRegistry().Config().Item
+	opts := world.EntitySpawnOpts{Position: pos.Vec3Centre().Add(dir.Mul(0.7)), Velocity: dir.Mul(0.1)}
+	tx.AddEntity(create(opts, it))
+}
+
+// InsertItem inserts an item from a hopper into the enabled slot of the crafter holding the fewest items,
+// so that hoppers fill the grid evenly.
+func (c Crafter) InsertItem(h Hopper, pos cube.Pos, tx *world.Tx) bool {
+	for sourceSlot, sourceStack := range h.inventory.Slots() {
+		if sourceStack.Empty() {
+			continue
+		}
+		slot, count := -1, 0
+		for crafterSlot, it := range c.inventory.Slots() {
+			if c.DisabledSlots[crafterSlot] || (!it.Empty() && (!it.Comparable(sourceStack) || it.Count() == it.MaxCount())) {
+				continue
+			}
+			if slot == -1 || it.Count() < count {
+				slot, count = crafterSlot, it.Count()
+			}
+		}
+		if slot == -1 {
+			continue
+		}
+		_ = c.Inventory(tx, pos).SetItem(slot, sourceStack.Grow(count-sourceStack.Count()+1))
+		_ = h.inventory.SetItem(sourceSlot, sourceStack.Grow(-1))
+		return true
+	}
+	return false
+}
+
+// BreakInfo ...
+func (c Crafter) BreakInfo() BreakInfo {
+	return newBreakInfo(1.5, alwaysHarvestable, pickaxeEffective, oneOf(Crafter{})).withBlastResistance(3.5).withBreakHandler(func(pos cube.Pos, tx *world.Tx, u item.User) {
+		for _, i := range c.Inventory(tx, pos).Clear() {
+			dropItem(tx, i, pos.Vec3())
+		}
+	})
+}
+
+// DecodeNBT ...
+func (c Crafter) DecodeNBT(data map[string]any) any {
+	facing, top, triggered, crafting := c.Facing, c.Top, c.Triggered, c.Crafting
+	//noinspection GoAssignmentToReceiver
+	c = NewCrafter()
+	c.Facing, c.Top, c.Triggered, c.Crafting = facing, top, triggered, crafting
+	c.CustomName = nbtconv.String(data, "CustomName")
+	disabled := nbtconv.Int16(data, "disabled_slots")
+	for slot := range c.DisabledSlots {
+		c.DisabledSlots[slot] = disabled&(1<<slot) != 0
+	}
+	nbtconv.InvFromNBT(c.inventory, nbtconv.Slice(data, "Items"))
+	return c
+}
+
+// EncodeNBT ...
+func (c Crafter) EncodeNBT() map[string]any {
+	if c.inventory == nil {
+		facing, top, triggered, crafting, disabled, customName := c.Facing, c.Top, c.Triggered, c.Crafting, c.DisabledSlots, c.CustomName
+		//noinspection GoAssignmentToReceiver
+		c = NewCrafter()
+		c.Facing, c.Top, c.Triggered, c.Crafting, c.DisabledSlots, c.CustomName = facing, top, triggered, crafting, disabled, customName
+	}
+	var disabled int16
+	for slot, d := range c.DisabledSlots {
+		if d {
+			disabled |= 1 << slot
+		}
+	}
+	m := map[string]any{
+		"Items":          nbtconv.InvToNBT(c.inventory),
+		"disabled_slots": disabled,
+		"id":             "Crafter",
+	}
+	if c.CustomName != "" {
+		m["CustomName"] = c.CustomName
+	}
+	return m
+}
+
+// EncodeItem ...
+func (Crafter) EncodeItem() (name string, meta int16) {
+	return "minecraft:crafter", 0
+}
+
+// EncodeBlock ...
+func (c Crafter) EncodeBlock() (string, map[string]any) {
+	orientation := c.Facing.String() + "_up"
+	if c.Facing == cube.FaceUp || c.Facing == cube.FaceDown {
+		orientation = c.Facing.String() + "_" + c.Top.String()
+	}
+	return "minecraft:crafter", map[string]any{
+		"orientation":   orientation,
+		"triggered_bit": boolByte(c.Triggered),
+		"crafting":      boolByte(c.Crafting),
+	}
+}
+
+// allCrafters ...
+func allCrafters() (crafters []world.Block) {
+	for _, f := range cube.Faces() {
+		for _, d := range cube.Directions() {
+			if d != cube.North && f != cube.FaceUp && f != cube.FaceDown {
+				// The top of a crafter facing a horizontal direction always faces up.
+				continue
+			}
+			for _, triggered := range []bool{false, true} {
+				crafters = append(crafters, Crafter{Facing: f, Top: d, Triggered: triggered})
+				crafters = append(crafters, Crafter{Facing: f, Top: d, Triggered: triggered, Crafting: true})
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,164 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestCrafterCraftsIntoContainer(t *testing.T) {
+	planks := item.NewStack(Planks{Wood: OakWood()}, 1)
+	registerRecipe(t, recipe.NewShaped([]recipe.Item{planks, planks}, item.NewStack(item.Stick{}, 4), recipe.NewShape(1, 2), "crafting_table"))
+
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		c := NewCrafter()
+		c.Facing = cube.FaceEast
+		c.DisabledSlots[5] = true
+		_ = c.Inventory(tx, pos).SetItem(5, planks)
+		_ = c.Inventory(tx, pos).SetItem(1, planks.Grow(1))
+		_ = c.Inventory(tx, pos).SetItem(4, planks)
+		tx.SetBlock(pos, c, nil)
+		tx.SetBlock(pos.Side(cube.FaceEast), NewChest(), nil)
+
+		c.ScheduledTick(pos, tx, nil)
+		chest := tx.Block(pos.Side(cube.FaceEast)).(Chest)
+		if !chest.Inventory(tx, pos.Side(cube.FaceEast)).ContainsItem(item.NewStack(item.Stick{}, 4)) {
+			t.Fatalf("expected crafted sticks in chest in front of crafter")
+		}
+		if it, _ := c.Inventory(tx, pos).Item(1); it.Count() != 1 {
+			t.Errorf("expected one planks to remain in slot 1, got %v", it.Count())
+		}
+		if it, _ := c.Inventory(tx, pos).Item(4); !it.Empty() {
+			t.Errorf("expected slot 4 to be consumed, got %v", it)
+		}
+		if it, _ := c.Inventory(tx, pos).Item(5); it.Count() != 1 {
+			t.Errorf("expected disabled slot 5 to be left alone, got %v", it)
+		}
+		if !tx.Block(pos).(Crafter).Crafting {
+			t.Errorf("expected crafter to be crafting after crafting an item")
+		}
+	})
+}
+
+func TestCrafterEjectsRemainders(t *testing.T) {
+	milk := item.NewStack(item.Bucket{Content: item.MilkBucketContent()}, 1)
+	wheat := item.NewStack(item.Wheat{}, 1)
+	registerRecipe(t, recipe.NewShapeless([]recipe.Item{milk, wheat}, item.NewStack(item.Bread{}, 1), "crafting_table"))
+
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		c := NewCrafter()
+		c.Facing = cube.FaceEast
+		_ = c.Inventory(tx, pos).SetItem(0, milk)
+		_ = c.Inventory(tx, pos).SetItem(1, wheat)
+		tx.SetBlock(pos, c, nil)
+		tx.SetBlock(pos.Side(cube.FaceEast), NewChest(), nil)
+
+		c.ScheduledTick(pos, tx, nil)
+		inv := tx.Block(pos.Side(cube.FaceEast)).(Chest).Inventory(tx, pos.Side(cube.FaceEast))
+		if !inv.ContainsItem(item.NewStack(item.Bread{}, 1)) {
+			t.Fatalf("expected crafted bread in chest in front of crafter")
+		}
+		if !inv.ContainsItem(item.NewStack(item.Bucket{}, 1)) {
+			t.Fatalf("expected empty bucket left behind by milk bucket to be ejected")
+		}
+	})
+}
+
+func TestCraftingRemainders(t *testing.T) {
+	milk := item.NewStack(item.Bucket{Content: item.MilkBucketContent()}, 1)
+	honey := item.NewStack(item.HoneyBottle{}, 1)
+	bucket, bottle := item.NewStack(item.Bucket{}, 1), item.NewStack(item.GlassBottle{}, 1)
+	tests := []struct {
+		name           string
+		grid, output   []item.Stack
+		wantRemainders []item.Stack
+	}{
+		{name: "no remainders", grid: []item.Stack{item.NewStack(item.Wheat{}, 1), {}}, output: []item.Stack{item.NewStack(item.Bread{}, 1)}},
+		{name: "empty bucket", grid: []item.Stack{milk, {}}, output: []item.Stack{item.NewStack(Cake{}, 1)}, wantRemainders: []item.Stack{bucket}},
+		{name: "glass bottle", grid: []item.Stack{honey}, output: []item.Stack{item.NewStack(item.Sugar{}, 3)}, wantRemainders: []item.Stack{bottle}},
+		{name: "empty buckets in output", grid: []item.Stack{milk, milk, milk}, output: []item.Stack{item.NewStack(Cake{}, 1), item.NewStack(item.Bucket{}, 3)}},
+		{name: "some buckets in output", grid: []item.Stack{milk, milk, milk}, output: []item.Stack{item.NewStack(Cake{}, 1), bucket}, wantRemainders: []item.Stack{bucket, bucket}},
+		{name: "empty bucket not a remainder", grid: []item.Stack{bucket}, output: []item.Stack{item.NewStack(item.Bread{}, 1)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			remainders := craftingRemainders(test.grid, test.output)
+			if len(remainders) != len(test.wantRemainders) {
+				t.Fatalf("expected remainders %v, got %v", test.wantRemainders, remainders)
+			}
+			for i, r := range remainders {
+				if !r.Equal(test.wantRemainders[i]) {
+					t.Fatalf("expected remainders %v, got %v", test.wantRemainders, remainders)
+				}
+			}
+		})
+	}
+}
+
+func TestCrafterQueuesPulseWhileCrafting(t *testing.T) {
+	planks := item.NewStack(Planks{Wood: OakWood()}, 1)
+	registerRecipe(t, recipe.NewShaped([]recipe.Item{planks, planks}, item.NewStack(item.Stick{}, 4), recipe.NewShape(1, 2), "crafting_table"))
+
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+	loader := world.NewLoader(1, w, world.NopViewer{})
+	runWorld(w, func(tx *world.Tx) {
+		loader.Load(tx, 1)
+	})
+	defer func() {
+		runWorld(w, func(tx *world.Tx) {
+			loader.Close(tx)
+		})
+	}()
+
+	pos, sourcePos, chestPos := cube.Pos{0, 64, 0}, cube.Pos{-1, 64, 0}, cube.Pos{1, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		c := NewCrafter()
+		c.Facing = cube.FaceEast
+		_ = c.Inventory(tx, pos).SetItem(1, planks.Grow(1))
+		_ = c.Inventory(tx, pos).SetItem(4, planks.Grow(1))
+		tx.SetBlock(pos, c, nil)
+		tx.SetBlock(chestPos, NewChest(), nil)
+	})
+	redstoneWireTestSetBlockAndWait(t, w, sourcePos, RedstoneBlock{})
+
+	crafting := false
+	for range 20 {
+		w.AdvanceTick()
+		runWorld(w, func(tx *world.Tx) {
+			crafting = tx.Block(pos).(Crafter).Crafting
+		})
+		if crafting {
+			break
+		}
+	}
+	if !crafting {
+		t.Fatalf("expected crafter to craft after being powered")
+	}
+	// Pulse the crafter again while it is still crafting.
+	redstoneWireTestSetBlockAndWait(t, w, sourcePos, Air{})
+	redstoneWireTestSetBlockAndWait(t, w, sourcePos, RedstoneBlock{})
+	for range 40 {
+		w.AdvanceTick()
+	}
+
+	runWorld(w, func(tx *world.Tx) {
+		if !tx.Block(chestPos).(Chest).Inventory(tx, chestPos).ContainsItem(item.NewStack(item.Stick{}, 8)) {
+			t.Errorf("expected pulse received while crafting to craft once crafting was reset")
+		}
+		if it, _ := tx.Block(pos).(Crafter).Inventory(tx, pos).Item(1); !it.Empty() {
+			t.Errorf("expected grid to be consumed by both crafts, got %v", it)
+		}
+	})
+}
@@ -74,7 +74,7 @@ func (f Farmland) EntityLand(pos cube.Pos, tx *world.Tx, e world.Entity, _ *floa
 	if living, ok := e.(livingEntity); ok {
 		if fall, ok := living.(fallDistanceEntity); ok && rand.Float64() < fall.FallDistance()-0.5 {
 			ctx := tx.Event()
-			if tx.World().Handler().HandleCropTrample(ctx, pos); !ctx.Cancelled() {
+			if tx.Handler().HandleCropTrample(ctx, pos); !ctx.Cancelled() {
 				tx.SetBlock(pos, Dirt{}, nil)
 			}
 		}
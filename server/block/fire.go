@@ -177,12 +177,12 @@ func (f Fire) tick(pos cube.Pos, tx *world.Tx, r *rand.Rand) {
 func (f Fire) spread(from, to cube.Pos, tx *world.Tx, r *rand.Rand) {
 	if _, air := tx.Block(to).(Air); !air {
 		ctx := tx.Event()
-		if tx.World().Handler().HandleBlockBurn(ctx, to); ctx.Cancelled() {
+		if tx.Handler().HandleBlockBurn(ctx, to); ctx.Cancelled() {
 			return
 		}
 	}
 	ctx := tx.Event()
-	if tx.World().Handler().HandleFireSpread(ctx, from, to); ctx.Cancelled() {
+	if tx.Handler().HandleFireSpread(ctx, from, to); ctx.Cancelled() {
 		return
 	}
 	spread := Fire{Type: f.Type, Age: min(15, f.Age+r.IntN(5)/4)}
@@ -0,0 +1,46 @@
+package block
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestFurnaceUsesOverriddenRecipe(t *testing.T) {
+	input := item.NewStack(Cobblestone{}, 1)
+	registerRecipe(t, recipe.NewFurnace(input, item.NewStack(Stone{}, 1), "furnace", 0.1))
+	registerRecipe(t, recipe.NewFurnace(input, item.NewStack(item.Diamond{}, 1), "furnace", 1))
+
+	w := world.Config{Synchronous: true}.New()
+	defer w.Close()
+
+	pos := cube.Pos{0, 64, 0}
+	runWorld(w, func(tx *world.Tx) {
+		f := NewFurnace(cube.North)
+		_ = f.Inventory(tx, pos).SetItem(0, input)
+		_ = f.Inventory(tx, pos).SetItem(1, item.NewStack(item.Coal{}, 1))
+		tx.SetBlock(pos, f, nil)
+
+		for i := 0; i < 200; i++ {
+			tx.Block(pos).(Furnace).Tick(int64(i), pos, tx)
+		}
+		if it, _ := f.Inventory(tx, pos).Item(2); !it.Comparable(item.NewStack(item.Diamond{}, 1)) || it.Count() != 1 {
+			t.Fatalf("expected furnace to smelt cobblestone into a diamond, got %v", it)
+		}
+		if xp := f.Experience(); xp != 1 {
+			t.Errorf("expected 1 experience from overridden recipe, got %v", xp)
+		}
+		if _, ok := recipe.Smelt("smoker", input); ok {
+			t.Errorf("expected cobblestone not to be smeltable in a smoker")
+		}
+	})
+}
+
+// registerRecipe registers a recipe for the duration of the test t, so that it does not affect other tests.
+func registerRecipe(t *testing.T, r recipe.Recipe) {
+	recipe.Register(r)
+	t.Cleanup(func() { recipe.Unregister(recipe.Name(r)) })
+}
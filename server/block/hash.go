@@ -61,6 +61,7 @@ const (
 	hashCopperTrapdoor
 	hashCoral
 	hashCoralBlock
+	hashCrafter
 	hashCraftingTable
 	hashDeadBush
 	hashDecoratedPot
@@ -460,6 +461,10 @@ func (c CoralBlock) Hash() (uint64, uint64) {
 	return hashCoralBlock, uint64(c.Type.Uint8()) | uint64(boolByte(c.Dead))<<3
 }
 
+func (c Crafter) Hash() (uint64, uint64) {
+	return hashCrafter, uint64(c.Facing) | uint64(c.Top)<<3 | uint64(boolByte(c.Triggered))<<5 | uint64(boolByte(c.Crafting))<<6
+}
+
 func (CraftingTable) Hash() (uint64, uint64) {
 	return hashCraftingTable, 0
 }
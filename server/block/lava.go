@@ -177,7 +177,7 @@ func (l Lava) Harden(pos cube.Pos, tx *world.Tx, flownIntoBy *cube.Pos) bool {
 		}, tx.Range())
 		if b != nil {
 			ctx := tx.Event()
-			if tx.World().Handler().HandleLiquidHarden(ctx, pos, l, water, b); ctx.Cancelled() {
+			if tx.Handler().HandleLiquidHarden(ctx, pos, l, water, b); ctx.Cancelled() {
 				return false
 			}
 			tx.PlaySound(pos.Vec3Centre(), sound.Fizz{})
@@ -197,7 +197,7 @@ func (l Lava) Harden(pos cube.Pos, tx *world.Tx, flownIntoBy *cube.Pos) bool {
 		b = Cobblestone{}
 	}
 	ctx := tx.Event()
-	if tx.World().Handler().HandleLiquidHarden(ctx, pos, l, water, b); ctx.Cancelled() {
+	if tx.Handler().HandleLiquidHarden(ctx, pos, l, water, b); ctx.Cancelled() {
 		return false
 	}
 	tx.SetBlock(pos, b, nil)
@@ -68,7 +68,7 @@ func (l Leaves) RandomTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
 			return
 		}
 		ctx := tx.Event()
-		if tx.World().Handler().HandleLeavesDecay(ctx, pos); ctx.Cancelled() {
+		if tx.Handler().HandleLeavesDecay(ctx, pos); ctx.Cancelled() {
 			// Prevent immediate re-updating.
 			l.ShouldUpdate = false
 			tx.SetBlock(pos, l, nil)
@@ -182,6 +182,7 @@ func init() {
 	registerAll(allConcretePowder())
 	registerAll(allCoral())
 	registerAll(allCoralBlocks())
+	registerAll(allCrafters())
 	registerAll(allDeepslate())
 	registerAll(allDoors())
 	registerAll(allDoubleFlowers())
@@ -302,6 +303,7 @@ func init() {
 	world.RegisterItem(CocoaBean{})
 	world.RegisterItem(Composter{})
 	world.RegisterItem(CopperTorch{})
+	world.RegisterItem(Crafter{})
 	world.RegisterItem(CraftingTable{})
 	world.RegisterItem(DeadBush{})
 	world.RegisterItem(DeepslateBricks{Cracked: true})
@@ -4,6 +4,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/recipe"
 	"github.com/df-mc/dragonfly/server/world"
 	"math"
 	"math/rand/v2"
@@ -175,7 +176,7 @@ func (s *smelter) setDurations(remaining, max, cook time.Duration) {
 
 // tickSmelting ticks the smelter, ensuring the necessary items exist in the furnace, and then processing all inputted
 // items for the necessary duration.
-func (s *smelter) tickSmelting(requirement, decrement time.Duration, lit bool, supported func(item.SmeltInfo) bool) bool {
+func (s *smelter) tickSmelting(requirement, decrement time.Duration, lit bool, block string) bool {
 	s.mu.Lock()
 
 	// First keep track of our past durations, since if any of them change, we need to be able to tell they did and then
@@ -189,10 +190,13 @@ func (s *smelter) tickSmelting(requirement, decrement time.Duration, lit bool, s
 	fuel, _ := s.inventory.Item(1)
 	product, _ := s.inventory.Item(2)
 
-	// Initialise some default smelt info, and update it if we can smelt the item.
-	var inputInfo item.SmeltInfo
-	if i, ok := input.Item().(item.Smeltable); ok && supported(i.SmeltInfo()) {
-		inputInfo = i.SmeltInfo()
+	// Look up the furnace recipe of the smelter that smelts the input, if there is one.
+	var (
+		result     item.Stack
+		experience float64
+	)
+	if r, ok := recipe.Smelt(block, input); ok {
+		result, experience = r.Output()[0], r.Experience()
 	}
 
 	// Initialise some default fuel info, and update it if it can be used as fuel.
@@ -209,7 +213,7 @@ func (s *smelter) tickSmelting(requirement, decrement time.Duration, lit bool, s
 	// the input's product is compatible with the product already in the product slot, the product slot is not full,
 	// and that we have enough fuel to smelt the item. If all of these conditions are met, then we update the remaining
 	// duration and cook duration and create residue.
-	canSmelt := input.Count() > 0 && (inputInfo.Product.Comparable(product)) && !inputInfo.Product.Empty() && product.Count() < product.MaxCount()
+	canSmelt := input.Count() > 0 && (result.Comparable(product)) && !result.Empty() && product.Count() < product.MaxCount()
 	if s.remainingDuration <= 0 && canSmelt && fuelInfo.Duration > 0 && fuel.Count() > 0 {
 		s.remainingDuration, s.maxDuration, lit = fuelInfo.Duration, fuelInfo.Duration, true
 		defer s.inventory.SetItem(1, fuelInfo.Residue)
@@ -230,12 +234,12 @@ func (s *smelter) tickSmelting(requirement, decrement time.Duration, lit bool, s
 			if s.cookDuration >= requirement {
 				// We can now create the product and reduce the input by one.
 				defer s.inventory.SetItem(0, input.Grow(-1))
-				defer s.inventory.SetItem(2, item.NewStack(inputInfo.Product.Item(), product.Count()+inputInfo.Product.Count()))
+				defer s.inventory.SetItem(2, item.NewStack(result.Item(), product.Count()+result.Count()))
 
 				// Calculate the amount of experience to grant. Round the experience down to the nearest integer.
 				// The remaining XP is a chance to be granted an additional experience point.
-				xp := inputInfo.Experience * float64(inputInfo.Product.Count())
-				earned := math.Floor(inputInfo.Experience)
+				xp := experience * float64(result.Count())
+				earned := math.Floor(experience)
 				if chance := xp - earned; chance > 0 && rand.Float64() < chance {
 					earned++
 				}
@@ -40,9 +40,7 @@ func (s Smoker) Tick(_ int64, pos cube.Pos, tx *world.Tx) {
 	if s.Lit && rand.Float64() <= 0.016 { // Every three or so seconds.
 		tx.PlaySound(pos.Vec3Centre(), sound.SmokerCrackle{})
 	}
-	if lit := s.tickSmelting(time.Second*5, time.Millisecond*200, s.Lit, func(i item.SmeltInfo) bool {
-		return i.Food
-	}); s.Lit != lit {
+	if lit := s.tickSmelting(time.Second*5, time.Millisecond*200, s.Lit, "smoker"); s.Lit != lit {
 		s.Lit = lit
 		tx.SetBlock(pos, s, nil)
 	}
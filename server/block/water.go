@@ -126,7 +126,7 @@ func (w Water) ScheduledTick(pos cube.Pos, tx *world.Tx, _ *rand.Rand) {
 				// below this is not falling (full source block).
 				res := Water{Depth: 8, Still: true}
 				ctx := tx.Event()
-				if tx.World().Handler().HandleLiquidFlow(ctx, pos, pos, res, w); ctx.Cancelled() {
+				if tx.Handler().HandleLiquidFlow(ctx, pos, pos, res, w); ctx.Cancelled() {
 					return
 				}
 				tx.SetLiquid(pos, res)
@@ -158,7 +158,7 @@ func (w Water) Harden(pos cube.Pos, tx *world.Tx, flownIntoBy *cube.Pos) bool {
 	}
 	if lava, ok := tx.Block(pos.Side(cube.FaceUp)).(Lava); ok {
 		ctx := tx.Event()
-		if tx.World().Handler().HandleLiquidHarden(ctx, pos, w, lava, Stone{}); ctx.Cancelled() {
+		if tx.Handler().HandleLiquidHarden(ctx, pos, w, lava, Stone{}); ctx.Cancelled() {
 			return false
 		}
 		tx.SetBlock(pos, Stone{}, nil)
@@ -166,7 +166,7 @@ func (w Water) Harden(pos cube.Pos, tx *world.Tx, flownIntoBy *cube.Pos) bool {
 		return true
 	} else if lava, ok := tx.Block(*flownIntoBy).(Lava); ok {
 		ctx := tx.Event()
-		if tx.World().Handler().HandleLiquidHarden(ctx, pos, w, lava, Cobblestone{}); ctx.Cancelled() {
+		if tx.Handler().HandleLiquidHarden(ctx, pos, w, lava, Cobblestone{}); ctx.Cancelled() {
 			return false
 		}
 		tx.SetBlock(*flownIntoBy, Cobblestone{}, nil)
@@ -2,13 +2,10 @@ package cmd
 
 import (
 	"fmt"
-	"github.com/df-mc/dragonfly/server/internal/sliceutil"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
-	"math/rand/v2"
 	"reflect"
 	"slices"
-	"sort"
 	"strconv"
 	"strings"
 )
@@ -268,48 +265,31 @@ func (p parser) targets(line *Line, v reflect.Value, tx *world.Tx) error {
 	return nil
 }
 
-// parseTargets parses one or more Targets from the Line passed.
+// parseTargets parses one or more Targets from the Line passed. Besides player names, target selectors
+// such as '@a' and '@e[type=cow,r=10]' are supported. If a selector spans multiple arguments, all but the
+// last of them are consumed.
 func (p parser) parseTargets(line *Line, tx *world.Tx) ([]Target, error) {
-	entities, players := targets(tx)
-	first, ok := line.Next()
+	first, n, ok := selectorArgument(line)
 	if !ok {
 		return nil, line.UsageError()
 	}
-	if strings.HasPrefix(first, "@") && tx == nil {
-		return nil, MessageNoTargets.F()
-	}
-	switch first[:min(len(first), 2)] {
-	case "@p":
-		pos := line.src.Position()
-		playerDistances := make([]float64, len(players))
-		for i, p := range players {
-			playerDistances[i] = p.Position().Sub(pos).Len()
-		}
-		sort.Slice(players, func(i, j int) bool {
-			return playerDistances[i] < playerDistances[j]
-		})
-		if len(players) == 0 {
-			return nil, nil
-		}
-		return sliceutil.Convert[Target](players[0:1]), nil
-	case "@e":
-		return entities, nil
-	case "@a":
-		return sliceutil.Convert[Target](players), nil
-	case "@s":
-		return []Target{line.src}, nil
-	case "@r":
-		if len(players) == 0 {
-			return nil, nil
-		}
-		return []Target{players[rand.IntN(len(players))]}, nil
-	default:
+	if !strings.HasPrefix(first, "@") {
+		_, players := targets(tx)
 		target, err := p.parsePlayer(first, players)
 		if err != nil {
 			return nil, err
 		}
 		return []Target{target}, nil
 	}
+	sel, ok := parseSelector(first)
+	if !ok {
+		return nil, line.SyntaxError()
+	}
+	if tx == nil {
+		return nil, MessageNoTargets.F()
+	}
+	line.RemoveN(n - 1)
+	return sel.resolve(line.src, tx), nil
 }
 
 // parsePlayer attempts to find a target whose name matches the name passed.
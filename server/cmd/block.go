@@ -0,0 +1,115 @@
+package cmd
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Block is a command parameter type for a world.Block. It is written as the name of the block, optionally
+// followed by block states between square brackets, for example 'stone' or
+// 'oak_log ["pillar_axis"="x"]'. States that are not specified are taken from the default state of the block.
+type Block struct {
+	b world.Block
+}
+
+// Block returns the world.Block that was passed to the parameter.
+func (b Block) Block() world.Block {
+	return b.b
+}
+
+// Parse ...
+func (Block) Parse(line *Line, v reflect.Value) error {
+	name, ok := line.Next()
+	if !ok {
+		return line.UsageError()
+	}
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	props, ok := world.BlockDefaultProperties(name)
+	if !ok {
+		return MessageParameterInvalid.F(name)
+	}
+
+	if args, ok := line.NextN(2); ok && strings.HasPrefix(args[1], "[") {
+		line.RemoveNext()
+		states, n, _ := selectorArgument(line)
+		if err := parseBlockStates(states, props); err != nil {
+			return err
+		}
+		line.RemoveN(n - 1)
+	}
+	b, ok := world.BlockByName(name, props)
+	if !ok {
+		return MessageParameterInvalid.F(name)
+	}
+	v.Set(reflect.ValueOf(Block{b: b}))
+	return nil
+}
+
+// Type ...
+func (Block) Type() string {
+	return "block"
+}
+
+// parseBlockStates parses block states in the format '["key"="value",...]' and stores them in props. Values
+// are converted to the type of the existing value in props. States not yet present in props are invalid.
+func parseBlockStates(s string, props map[string]any) error {
+	if len(s) < 2 || s[0] != '[' || s[len(s)-1] != ']' {
+		return MessageParameterInvalid.F(s)
+	}
+	for _, state := range splitTopLevel(s[1 : len(s)-1]) {
+		state = strings.TrimSpace(state)
+		if state == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(state, "=")
+		if !ok {
+			// Older versions of the game used ':' to separate keys and values.
+			if key, val, ok = strings.Cut(state, ":"); !ok {
+				return MessageParameterInvalid.F(state)
+			}
+		}
+		key, val = strings.Trim(strings.TrimSpace(key), `"`), strings.Trim(strings.TrimSpace(val), `"`)
+
+		current, ok := props[key]
+		if !ok {
+			return MessageParameterInvalid.F(key)
+		}
+		var err error
+		switch current.(type) {
+		case bool:
+			props[key], err = strconv.ParseBool(val)
+		case uint8:
+			// Byte states are often used as booleans, so 'true' and 'false' are accepted too.
+			if val == "true" || val == "false" {
+				props[key] = boolByte(val == "true")
+				break
+			}
+			var u uint64
+			u, err = strconv.ParseUint(val, 10, 8)
+			props[key] = uint8(u)
+		case int32:
+			var i int64
+			i, err = strconv.ParseInt(val, 10, 32)
+			props[key] = int32(i)
+		default:
+			props[key] = val
+		}
+		if err != nil {
+			return MessageParameterInvalid.F(val)
+		}
+	}
+	return nil
+}
+
+// boolByte returns 1 if b is true and 0 if it is false.
+func boolByte(b bool) uint8 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,33 @@
+package cmd
+
+import (
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/entity/effect"
+)
+
+// EffectType is a command parameter type for an effect.Type, such as 'speed' or 'fire_resistance'. It is
+// an Enum with the names of all registered effects as options. EffectType.Effect may be used to obtain
+// the effect.Type selected.
+type EffectType string
+
+// Effect returns the effect.Type that the EffectType refers to. False is returned if no effect with the
+// name is registered.
+func (e EffectType) Effect() (effect.Type, bool) {
+	return effect.ByName(string(e))
+}
+
+// Type ...
+func (EffectType) Type() string {
+	return "Effect"
+}
+
+// Options ...
+func (EffectType) Options(Source) []string {
+	names := effect.Names()
+	slices.Sort(names)
+	return names
+}
+
+// SoftEnum ...
+func (EffectType) SoftEnum() {}
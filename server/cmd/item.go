@@ -0,0 +1,66 @@
+package cmd
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// Item is a command parameter type for a world.Item. It is written as the name of the item, optionally
+// followed by a count and a data (metadata) value, for example 'diamond', 'diamond 64' or 'dye 3 4'. The
+// count and data are only consumed if they are integers.
+type Item struct {
+	it    world.Item
+	count int
+}
+
+// Item returns the world.Item that was passed to the parameter.
+func (i Item) Item() world.Item {
+	return i.it
+}
+
+// Count returns the count passed to the parameter, or 1 if no count was passed.
+func (i Item) Count() int {
+	return i.count
+}
+
+// Parse ...
+func (Item) Parse(line *Line, v reflect.Value) error {
+	args, ok := line.NextN(1)
+	if !ok {
+		return line.UsageError()
+	}
+	name := args[0]
+	if !strings.Contains(name, ":") {
+		name = "minecraft:" + name
+	}
+	count, meta, n := 1, int16(0), 1
+	if args, ok := line.NextN(2); ok {
+		if c, err := strconv.Atoi(args[1]); err == nil {
+			if c <= 0 {
+				line.RemoveNext()
+				return MessageNumberInvalid.F(args[1])
+			}
+			count, n = c, 2
+		}
+	}
+	if args, ok := line.NextN(3); ok && n == 2 {
+		if m, err := strconv.ParseInt(args[2], 10, 16); err == nil {
+			meta, n = int16(m), 3
+		}
+	}
+	it, ok := world.ItemByName(name, meta)
+	if !ok {
+		return MessageParameterInvalid.F(args[0])
+	}
+	line.RemoveN(n - 1)
+	v.Set(reflect.ValueOf(Item{it: it, count: count}))
+	return nil
+}
+
+// Type ...
+func (Item) Type() string {
+	return "item"
+}
@@ -40,6 +40,15 @@ type Enum interface {
 	Options(source Source) []string
 }
 
+// SoftEnum may be implemented by an Enum whose options may change while the server is running, for example
+// because they depend on a registry. The options of a SoftEnum are sent to clients as a soft enum, so that
+// they can be updated without resending all commands.
+type SoftEnum interface {
+	Enum
+	// SoftEnum is a marker method that is never called.
+	SoftEnum()
+}
+
 // ParamDescriber may be implemented by a Runnable to programmatically describe its parameters
 // without relying on struct field reflection.
 type ParamDescriber interface {
@@ -0,0 +1,63 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// rotatedSource is a Source with a position and rotation.
+type rotatedSource struct {
+	pos mgl64.Vec3
+	rot cube.Rotation
+}
+
+func (s rotatedSource) Position() mgl64.Vec3      { return s.pos }
+func (s rotatedSource) Rotation() cube.Rotation   { return s.rot }
+func (s rotatedSource) SendCommandOutput(*Output) {}
+
+func TestPositionResolve(t *testing.T) {
+	src := rotatedSource{pos: mgl64.Vec3{10, 64, 10}}
+	tests := map[[3]string]mgl64.Vec3{
+		{"1", "2", "3"}:    {1, 2, 3},
+		{"~", "~1", "~-1"}: {10, 65, 9},
+		{"~", "70", "~"}:   {10, 70, 10},
+		// With a rotation of 0, 0, the source faces south (+Z) and left is east (+X).
+		{"^1", "^2", "^3"}: {11, 66, 13},
+	}
+	for args, want := range tests {
+		line := &Line{args: args[:]}
+		var p Position
+		if err := p.Parse(line, reflect.ValueOf(&p).Elem()); err != nil {
+			t.Fatalf("parse %v: %v", args, err)
+		}
+		if got := p.Resolve(src); !got.ApproxEqual(want) {
+			t.Errorf("resolve %v: got %v, want %v", args, got, want)
+		}
+	}
+
+	var p Position
+	if err := p.Parse(&Line{args: []string{"^", "~", "^"}}, reflect.ValueOf(&p).Elem()); err == nil {
+		t.Errorf("parse of mixed local and relative coordinates: expected error, got nil")
+	}
+}
+
+func TestParseDuration(t *testing.T) {
+	tests := map[string]time.Duration{
+		"30":    30 * time.Second,
+		"1.5":   1500 * time.Millisecond,
+		"20t":   time.Second,
+		"1m30s": 90 * time.Second,
+	}
+	for s, want := range tests {
+		if got, ok := parseDuration(s); !ok || got != want {
+			t.Errorf("parseDuration(%q): got %v, want %v", s, got, want)
+		}
+	}
+	if _, ok := parseDuration("soon"); ok {
+		t.Errorf("parseDuration(%q): expected failure", "soon")
+	}
+}
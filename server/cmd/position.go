@@ -0,0 +1,109 @@
+package cmd
+
+import (
+	"math"
+	"reflect"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Position is a command parameter type for a position in the world. Unlike mgl64.Vec3, its coordinates may
+// be relative to the position of the Source ('~ ~1 ~'), or local to the position and rotation of the Source
+// ('^ ^ ^5', left, up and forward respectively). The position is obtained by calling Position.Resolve.
+type Position struct {
+	coords [3]coordinate
+	local  bool
+}
+
+// Resolve resolves the Position relative to the Source passed. If the Source does not have a rotation, local
+// coordinates are resolved as if the Source had a rotation of 0, 0.
+func (p Position) Resolve(src Source) mgl64.Vec3 {
+	pos := src.Position()
+	if !p.local {
+		return mgl64.Vec3{p.coords[0].resolve(pos[0]), p.coords[1].resolve(pos[1]), p.coords[2].resolve(pos[2])}
+	}
+	var rot cube.Rotation
+	if r, ok := src.(interface{ Rotation() cube.Rotation }); ok {
+		rot = r.Rotation()
+	}
+	yaw, pitch := mgl64.DegToRad(rot.Yaw()), mgl64.DegToRad(rot.Pitch())
+	forward := rot.Vec3()
+	up := mgl64.Vec3{-math.Sin(pitch) * math.Sin(yaw), math.Cos(pitch), math.Sin(pitch) * math.Cos(yaw)}
+	left := up.Cross(forward)
+	return pos.Add(left.Mul(p.coords[0].val)).Add(up.Mul(p.coords[1].val)).Add(forward.Mul(p.coords[2].val))
+}
+
+// Parse ...
+func (Position) Parse(line *Line, v reflect.Value) error {
+	args, ok := line.NextN(3)
+	if !ok {
+		return line.UsageError()
+	}
+	var p Position
+	for i, arg := range args {
+		local := strings.HasPrefix(arg, "^")
+		if i > 0 && local != p.local {
+			// Local coordinates cannot be mixed with world coordinates.
+			line.RemoveN(i)
+			return MessageParameterInvalid.F(arg)
+		}
+		p.local = local
+
+		c, ok := parseCoordinate(strings.Replace(arg, "^", "~", 1))
+		if !ok {
+			line.RemoveN(i)
+			return MessageNumberInvalid.F(arg)
+		}
+		p.coords[i] = c
+	}
+	line.RemoveN(2)
+	v.Set(reflect.ValueOf(p))
+	return nil
+}
+
+// Type ...
+func (Position) Type() string {
+	return "x y z"
+}
+
+// Rotation is a command parameter type for a yaw and pitch, which may both be relative to the rotation of the
+// Source ('~90 ~'). The rotation is obtained by calling Rotation.Resolve.
+type Rotation struct {
+	yaw, pitch coordinate
+}
+
+// Resolve resolves the Rotation relative to the Source passed. If the Source does not have a rotation, the
+// Rotation is resolved as if the Source had a rotation of 0, 0.
+func (r Rotation) Resolve(src Source) cube.Rotation {
+	var rot cube.Rotation
+	if s, ok := src.(interface{ Rotation() cube.Rotation }); ok {
+		rot = s.Rotation()
+	}
+	return cube.Rotation{r.yaw.resolve(rot.Yaw()), r.pitch.resolve(rot.Pitch())}
+}
+
+// Parse ...
+func (Rotation) Parse(line *Line, v reflect.Value) error {
+	args, ok := line.NextN(2)
+	if !ok {
+		return line.UsageError()
+	}
+	yaw, ok := parseCoordinate(args[0])
+	if !ok {
+		return MessageNumberInvalid.F(args[0])
+	}
+	line.RemoveNext()
+	pitch, ok := parseCoordinate(args[1])
+	if !ok {
+		return MessageNumberInvalid.F(args[1])
+	}
+	v.Set(reflect.ValueOf(Rotation{yaw: yaw, pitch: pitch}))
+	return nil
+}
+
+// Type ...
+func (Rotation) Type() string {
+	return "yaw pitch"
+}
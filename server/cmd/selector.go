@@ -0,0 +1,470 @@
+package cmd
+
+import (
+	"cmp"
+	"math"
+	"math/rand/v2"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/internal/sliceutil"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// selector is a parsed target selector, such as '@a' or '@e[type=cow,r=10,c=2]'. It holds the base selector
+// variable and all arguments that were set between the square brackets.
+type selector struct {
+	// variable is the selector variable, one of 'p', 'a', 'e', 's' or 'r'.
+	variable byte
+
+	// origin holds the x, y and z arguments. Coordinates that are not set default to the position of the
+	// Source executing the command.
+	origin [3]coordinate
+	// r and rm are the maximum and minimum distance from the origin. They are negative if not set.
+	r, rm float64
+	// volume holds the dx, dy and dz arguments.
+	volume    mgl64.Vec3
+	hasVolume bool
+
+	types, names, tags, modes []negatable
+	scores                    map[string]scoreRange
+
+	// l and lm are the maximum and minimum experience level. They are negative if not set.
+	l, lm int
+
+	// limit is the maximum amount of targets selected. It is 0 if not set.
+	limit int
+	sort  string
+}
+
+// coordinate is a single, possibly relative, coordinate used as selector origin.
+type coordinate struct {
+	val      float64
+	relative bool
+	set      bool
+}
+
+// resolve returns the value of the coordinate, using base as the value if the coordinate is unset or as the
+// value it is relative to.
+func (c coordinate) resolve(base float64) float64 {
+	if !c.set {
+		return base
+	}
+	if c.relative {
+		return base + c.val
+	}
+	return c.val
+}
+
+// negatable is a selector argument value that may be inverted by prefixing it with '!'.
+type negatable struct {
+	val string
+	neg bool
+}
+
+// matches checks if the negatable matches the condition passed, accounting for negation.
+func (n negatable) matches(cond bool) bool {
+	return cond != n.neg
+}
+
+// scoreRange is a (possibly inverted) inclusive range of scores, such as '5', '1..5', '..5' or '!3..'.
+type scoreRange struct {
+	min, max int
+	neg      bool
+}
+
+// contains checks if the score passed falls within the range.
+func (r scoreRange) contains(score int) bool {
+	return (score >= r.min && score <= r.max) != r.neg
+}
+
+// selectorSorts holds all values accepted by the 'sort' selector argument.
+var selectorSorts = []string{"nearest", "furthest", "random", "arbitrary"}
+
+// selectorArgument reads a full selector from the Line passed. Selector arguments may contain spaces, in which
+// case the selector spans multiple arguments of the Line. The selector and the number of Line arguments it
+// spans are returned.
+func selectorArgument(line *Line) (string, int, bool) {
+	first, ok := line.Next()
+	if !ok {
+		return "", 0, false
+	}
+	n, s := 1, first
+	for depth(s) > 0 {
+		args, ok := line.NextN(n + 1)
+		if !ok {
+			// The selector was never closed: Return it as-is so that parsing fails on it.
+			break
+		}
+		s, n = s+" "+args[n], n+1
+	}
+	return s, n, true
+}
+
+// depth returns the number of square brackets opened in s that are not yet closed.
+func depth(s string) int {
+	return strings.Count(s, "[") - strings.Count(s, "]")
+}
+
+// parseSelector parses a selector such as '@a[r=10]' from the string passed. False is returned if the string
+// passed is not a valid selector.
+func parseSelector(s string) (selector, bool) {
+	sel := selector{r: -1, rm: -1, l: -1, lm: -1}
+	if len(s) < 2 || s[0] != '@' || !strings.ContainsRune("paesr", rune(s[1])) {
+		return sel, false
+	}
+	sel.variable = s[1]
+
+	rest := s[2:]
+	if rest == "" {
+		return sel, true
+	}
+	if rest[0] != '[' || rest[len(rest)-1] != ']' {
+		return sel, false
+	}
+	for _, arg := range splitTopLevel(rest[1 : len(rest)-1]) {
+		arg = strings.TrimSpace(arg)
+		if arg == "" {
+			continue
+		}
+		key, val, ok := strings.Cut(arg, "=")
+		if !ok || !sel.parseArgument(strings.TrimSpace(key), strings.TrimSpace(val)) {
+			return sel, false
+		}
+	}
+	return sel, true
+}
+
+// parseArgument parses a single key=value argument of a selector and stores it in the selector. False is
+// returned if the key or value is not valid.
+func (sel *selector) parseArgument(key, val string) bool {
+	ok := true
+	switch key {
+	case "x", "y", "z":
+		sel.origin[key[0]-'x'], ok = parseCoordinate(val)
+	case "r":
+		sel.r, ok = parseSelectorDistance(val)
+	case "rm":
+		sel.rm, ok = parseSelectorDistance(val)
+	case "dx", "dy", "dz":
+		sel.hasVolume = true
+		sel.volume[key[1]-'x'], ok = parseSelectorFloat(val)
+	case "type":
+		sel.types = append(sel.types, parseNegatable(val))
+	case "name":
+		sel.names = append(sel.names, parseNegatable(strings.Trim(val, `"`)))
+	case "tag":
+		sel.tags = append(sel.tags, parseNegatable(val))
+	case "m":
+		n := parseNegatable(val)
+		if _, ok = gameModeByName(n.val); ok {
+			sel.modes = append(sel.modes, n)
+		}
+	case "l":
+		sel.l, ok = parseSelectorInt(val)
+	case "lm":
+		sel.lm, ok = parseSelectorInt(val)
+	case "c", "limit":
+		var err error
+		sel.limit, err = strconv.Atoi(val)
+		ok = err == nil && sel.limit != 0 && (key != "limit" || sel.limit > 0)
+	case "sort":
+		sel.sort, ok = val, slices.Contains(selectorSorts, val)
+	case "scores":
+		sel.scores, ok = parseScores(val)
+	default:
+		return false
+	}
+	return ok
+}
+
+// splitTopLevel splits s by commas that are not nested in curly braces or quotes.
+func splitTopLevel(s string) []string {
+	var (
+		parts  []string
+		nested int
+		quoted bool
+		start  int
+	)
+	for i, r := range s {
+		switch {
+		case r == '"':
+			quoted = !quoted
+		case quoted:
+		case r == '{':
+			nested++
+		case r == '}':
+			nested--
+		case r == ',' && nested == 0:
+			parts = append(parts, s[start:i])
+			start = i + 1
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// parseCoordinate parses a coordinate which may be relative if prefixed with '~'.
+func parseCoordinate(s string) (coordinate, bool) {
+	c := coordinate{set: true}
+	if rest, ok := strings.CutPrefix(s, "~"); ok {
+		c.relative, s = true, rest
+		if s == "" {
+			return c, true
+		}
+	}
+	v, err := strconv.ParseFloat(s, 64)
+	c.val = v
+	return c, err == nil
+}
+
+// parseSelectorFloat parses a float64 selector argument value.
+func parseSelectorFloat(s string) (float64, bool) {
+	v, err := strconv.ParseFloat(s, 64)
+	return v, err == nil && !math.IsNaN(v) && !math.IsInf(v, 0)
+}
+
+// parseSelectorDistance parses a non-negative float64 selector argument value.
+func parseSelectorDistance(s string) (float64, bool) {
+	v, ok := parseSelectorFloat(s)
+	return v, ok && v >= 0
+}
+
+// parseSelectorInt parses a non-negative int selector argument value.
+func parseSelectorInt(s string) (int, bool) {
+	v, err := strconv.Atoi(s)
+	return v, err == nil && v >= 0
+}
+
+// parseNegatable parses a selector argument value that may be prefixed with '!'.
+func parseNegatable(s string) negatable {
+	if rest, ok := strings.CutPrefix(s, "!"); ok {
+		return negatable{val: rest, neg: true}
+	}
+	return negatable{val: s}
+}
+
+// parseScores parses the value of a scores argument, such as '{kills=5..,deaths=!0}'.
+func parseScores(s string) (map[string]scoreRange, bool) {
+	if len(s) < 2 || s[0] != '{' || s[len(s)-1] != '}' {
+		return nil, false
+	}
+	scores := make(map[string]scoreRange)
+	for _, arg := range splitTopLevel(s[1 : len(s)-1]) {
+		arg = strings.TrimSpace(arg)
+		if arg == "" {
+			continue
+		}
+		objective, val, ok := strings.Cut(arg, "=")
+		if !ok {
+			return nil, false
+		}
+		r, ok := parseScoreRange(strings.TrimSpace(val))
+		if !ok {
+			return nil, false
+		}
+		scores[strings.TrimSpace(objective)] = r
+	}
+	return scores, true
+}
+
+// parseScoreRange parses a range such as '5', '1..5', '..5', '5..' or '!1..5'.
+func parseScoreRange(s string) (scoreRange, bool) {
+	r := scoreRange{min: math.MinInt, max: math.MaxInt}
+	if rest, ok := strings.CutPrefix(s, "!"); ok {
+		r.neg, s = true, rest
+	}
+	lo, hi, isRange := strings.Cut(s, "..")
+	if !isRange {
+		v, err := strconv.Atoi(s)
+		r.min, r.max = v, v
+		return r, err == nil
+	}
+	if lo == "" && hi == "" {
+		return r, false
+	}
+	var err error
+	if lo != "" {
+		if r.min, err = strconv.Atoi(lo); err != nil {
+			return r, false
+		}
+	}
+	if hi != "" {
+		if r.max, err = strconv.Atoi(hi); err != nil {
+			return r, false
+		}
+	}
+	return r, true
+}
+
+// gameModeByName looks up a world.GameMode by one of the names or IDs it may be referred to by in a selector.
+func gameModeByName(name string) (world.GameMode, bool) {
+	switch strings.ToLower(name) {
+	case "survival", "s":
+		return world.GameModeSurvival, true
+	case "creative", "c":
+		return world.GameModeCreative, true
+	case "adventure", "a":
+		return world.GameModeAdventure, true
+	case "spectator", "sp":
+		return world.GameModeSpectator, true
+	}
+	if id, err := strconv.Atoi(name); err == nil {
+		return world.GameModeByID(id)
+	}
+	return nil, false
+}
+
+// resolve returns all Targets in the world.Tx passed that are selected by the selector, as seen from the
+// Source passed.
+func (sel selector) resolve(src Source, tx *world.Tx) []Target {
+	entities, players := targets(tx)
+
+	var candidates []Target
+	switch {
+	case sel.variable == 's':
+		candidates = []Target{src}
+	case sel.variable == 'e', sel.variable == 'r' && len(sel.types) > 0:
+		candidates = entities
+	default:
+		candidates = sliceutil.Convert[Target](players)
+	}
+
+	origin := sel.originFrom(src.Position())
+	selected := sliceutil.Filter(candidates, func(t Target) bool {
+		return sel.matches(t, origin)
+	})
+
+	sorting, limit := sel.sort, sel.limit
+	switch sel.variable {
+	case 'p':
+		sorting, limit = cmp.Or(sorting, "nearest"), cmp.Or(limit, 1)
+	case 'r':
+		sorting, limit = cmp.Or(sorting, "random"), cmp.Or(limit, 1)
+	}
+	if limit < 0 {
+		// A negative count selects the furthest targets first.
+		sorting, limit = "furthest", -limit
+	} else if limit > 0 && sorting == "" && sel.variable != 'r' {
+		sorting = "nearest"
+	}
+	switch sorting {
+	case "nearest", "furthest":
+		slices.SortStableFunc(selected, func(a, b Target) int {
+			da, db := a.Position().Sub(origin).Len(), b.Position().Sub(origin).Len()
+			if sorting == "furthest" {
+				da, db = db, da
+			}
+			return cmp.Compare(da, db)
+		})
+	case "random":
+		rand.Shuffle(len(selected), func(i, j int) {
+			selected[i], selected[j] = selected[j], selected[i]
+		})
+	}
+	if limit > 0 && len(selected) > limit {
+		selected = selected[:limit]
+	}
+	return selected
+}
+
+// originFrom returns the origin of the selector, with the x, y and z arguments applied to the position
+// passed.
+func (sel selector) originFrom(pos mgl64.Vec3) mgl64.Vec3 {
+	return mgl64.Vec3{sel.origin[0].resolve(pos[0]), sel.origin[1].resolve(pos[1]), sel.origin[2].resolve(pos[2])}
+}
+
+// matches checks if the Target passed satisfies all arguments of the selector, using origin as the position
+// that distances and volumes are relative to.
+func (sel selector) matches(t Target, origin mgl64.Vec3) bool {
+	pos := t.Position()
+	if dist := pos.Sub(origin).Len(); (sel.r >= 0 && dist > sel.r) || (sel.rm >= 0 && dist < sel.rm) {
+		return false
+	}
+	if sel.hasVolume {
+		for i := range 3 {
+			lo, hi := origin[i], origin[i]+sel.volume[i]
+			if lo > hi {
+				lo, hi = hi, lo
+			}
+			if pos[i] < lo || pos[i] >= hi+1 {
+				return false
+			}
+		}
+	}
+	for _, n := range sel.types {
+		if !n.matches(entityTypeOf(t) == strings.TrimPrefix(n.val, "minecraft:")) {
+			return false
+		}
+	}
+	for _, n := range sel.names {
+		if !n.matches(nameOf(t) == n.val) {
+			return false
+		}
+	}
+	for _, n := range sel.tags {
+		var tags []string
+		if tagged, ok := t.(TaggedTarget); ok {
+			tags = tagged.Tags()
+		}
+		if n.val == "" {
+			// 'tag=' selects targets without tags, 'tag=!' selects targets with any tag.
+			if !n.matches(len(tags) == 0) {
+				return false
+			}
+			continue
+		}
+		if !n.matches(slices.Contains(tags, n.val)) {
+			return false
+		}
+	}
+	for _, n := range sel.modes {
+		g, ok := t.(interface{ GameMode() world.GameMode })
+		if !ok {
+			return false
+		}
+		mode, _ := gameModeByName(n.val)
+		if !n.matches(g.GameMode() == mode) {
+			return false
+		}
+	}
+	if sel.l >= 0 || sel.lm >= 0 {
+		e, ok := t.(interface{ ExperienceLevel() int })
+		if !ok || (sel.l >= 0 && e.ExperienceLevel() > sel.l) || (sel.lm >= 0 && e.ExperienceLevel() < sel.lm) {
+			return false
+		}
+	}
+	if len(sel.scores) > 0 {
+		scored, ok := t.(ScoredTarget)
+		if !ok {
+			return false
+		}
+		for objective, r := range sel.scores {
+			if score, ok := scored.Score(objective); !ok || !r.contains(score) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// entityTypeOf returns the encoded entity type of a Target without the 'minecraft:' prefix, or an empty
+// string if the Target is not an entity.
+func entityTypeOf(t Target) string {
+	if e, ok := t.(world.Entity); ok {
+		return strings.TrimPrefix(e.H().Type().EncodeEntity(), "minecraft:")
+	}
+	return ""
+}
+
+// nameOf returns the name of a Target. This is the name of a NamedTarget or the name tag of other entities.
+func nameOf(t Target) string {
+	switch t := t.(type) {
+	case NamedTarget:
+		return t.Name()
+	case interface{ NameTag() string }:
+		return t.NameTag()
+	}
+	return ""
+}
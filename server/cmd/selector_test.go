@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// selectorTarget is a Target used to test selector arguments against.
+type selectorTarget struct {
+	name   string
+	pos    mgl64.Vec3
+	mode   world.GameMode
+	level  int
+	tags   []string
+	scores map[string]int
+}
+
+func (t selectorTarget) Position() mgl64.Vec3         { return t.pos }
+func (t selectorTarget) Name() string                 { return t.name }
+func (t selectorTarget) GameMode() world.GameMode     { return t.mode }
+func (t selectorTarget) ExperienceLevel() int         { return t.level }
+func (t selectorTarget) Tags() []string               { return t.tags }
+func (t selectorTarget) SendCommandOutput(*Output)    {}
+func (t selectorTarget) Score(obj string) (int, bool) { v, ok := t.scores[obj]; return v, ok }
+
+func TestParseSelectorInvalid(t *testing.T) {
+	for _, s := range []string{"@", "@x", "@a[", "@a[r=abc]", "@a[r=-1]", "@a[unknown=1]", "@a[m=flying]",
+		"@a[c=0]", "@a[limit=-2]", "@a[sort=closest]", "@a[scores=kills=5]", "@a[scores={kills=..}]", "@ab"} {
+		if _, ok := parseSelector(s); ok {
+			t.Errorf("parseSelector(%q): expected selector to be invalid", s)
+		}
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	target := selectorTarget{
+		name:   "Steve",
+		pos:    mgl64.Vec3{10, 64, 10},
+		mode:   world.GameModeCreative,
+		level:  12,
+		tags:   []string{"red"},
+		scores: map[string]int{"kills": 5},
+	}
+	tests := map[string]bool{
+		"@a":                                 true,
+		"@a[r=10]":                           true,
+		"@a[r=5]":                            false,
+		"@a[rm=5]":                           true,
+		"@a[x=10,y=64,z=10,r=0.5]":           true,
+		"@a[x=~5,y=~,z=~5,r=0.5]":            true,
+		"@a[dx=10,dy=0,dz=10]":               true,
+		"@a[dx=2,dy=2,dz=2]":                 false,
+		"@a[name=Steve]":                     true,
+		"@a[name=!Steve]":                    false,
+		"@a[tag=red]":                        true,
+		"@a[tag=!red]":                       false,
+		"@a[tag=]":                           false,
+		"@a[tag=!]":                          true,
+		"@a[m=c]":                            true,
+		"@a[m=creative]":                     true,
+		"@a[m=!1]":                           false,
+		"@a[l=20,lm=10]":                     true,
+		"@a[lm=13]":                          false,
+		"@a[scores={kills=5}]":               true,
+		"@a[scores={kills=1..4}]":            false,
+		"@a[scores={kills=!1..4, deaths=0}]": false,
+		"@a[scores={kills=5..}]":             true,
+	}
+	for s, want := range tests {
+		sel, ok := parseSelector(s)
+		if !ok {
+			t.Fatalf("parseSelector(%q): invalid selector", s)
+		}
+		if got := sel.matches(target, sel.originFrom(mgl64.Vec3{5, 64, 5})); got != want {
+			t.Errorf("%v matches: got %v, want %v", s, got, want)
+		}
+	}
+}
+
+func TestSelectorArgumentSpaces(t *testing.T) {
+	line := &Line{args: []string{"@a[name=\"Foo", "Bar\",", "r=5]", "hello"}}
+	s, n, ok := selectorArgument(line)
+	if !ok || n != 3 || s != "@a[name=\"Foo Bar\", r=5]" {
+		t.Fatalf("selectorArgument: got %q (%v args), want 3 args", s, n)
+	}
+	sel, ok := parseSelector(s)
+	if !ok {
+		t.Fatalf("parseSelector(%q): invalid selector", s)
+	}
+	if len(sel.names) != 1 || sel.names[0].val != "Foo Bar" || sel.r != 5 {
+		t.Errorf("unexpected selector parsed from %q: %+v", s, sel)
+	}
+}
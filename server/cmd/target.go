@@ -21,6 +21,23 @@ type NamedTarget interface {
 	Name() string
 }
 
+// TaggedTarget is a Target that may carry tags. These tags are matched against by the 'tag' argument of
+// target selectors, such as '@e[tag=red]'. Players implement TaggedTarget.
+type TaggedTarget interface {
+	Target
+	// Tags returns all tags that the Target currently has.
+	Tags() []string
+}
+
+// ScoredTarget is a Target that holds scores for objectives. These scores are matched against by the
+// 'scores' argument of target selectors, such as '@a[scores={kills=5..}]'. Players implement ScoredTarget.
+type ScoredTarget interface {
+	Target
+	// Score returns the score of the Target for the objective passed. If the Target has no score for the
+	// objective, false is returned.
+	Score(objective string) (int, bool)
+}
+
 // targets returns all Targets selectable by the Source passed.
 func targets(tx *world.Tx) (entities []Target, players []NamedTarget) {
 	if tx == nil {
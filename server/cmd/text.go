@@ -0,0 +1,76 @@
+package cmd
+
+import (
+	"encoding/json"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// JSON is a command parameter type for a JSON value, such as the raw text '{"rawtext":[{"text":"Hello"}]}'.
+// The value may contain spaces, in which case it spans multiple arguments. JSON.Unmarshal may be used to
+// decode it.
+type JSON json.RawMessage
+
+// Unmarshal decodes the JSON value into the value pointed to by v.
+func (j JSON) Unmarshal(v any) error {
+	return json.Unmarshal(j, v)
+}
+
+// Parse ...
+func (JSON) Parse(line *Line, v reflect.Value) error {
+	for n := 1; n <= line.Len(); n++ {
+		args, _ := line.NextN(n)
+		if raw := strings.Join(args, " "); json.Valid([]byte(raw)) {
+			line.RemoveN(n - 1)
+			v.SetBytes([]byte(raw))
+			return nil
+		}
+	}
+	if line.Len() == 0 {
+		return line.UsageError()
+	}
+	return MessageParameterInvalid.F(strings.Join(line.args, " "))
+}
+
+// Type ...
+func (JSON) Type() string {
+	return "json"
+}
+
+// Duration is a command parameter type for a time.Duration. Durations may be written as a number of seconds
+// ('30'), a number of ticks ('600t') or in the format accepted by time.ParseDuration ('1m30s').
+type Duration time.Duration
+
+// Parse ...
+func (Duration) Parse(line *Line, v reflect.Value) error {
+	arg, ok := line.Next()
+	if !ok {
+		return line.UsageError()
+	}
+	d, ok := parseDuration(arg)
+	if !ok || d < 0 {
+		return MessageParameterInvalid.F(arg)
+	}
+	v.SetInt(int64(d))
+	return nil
+}
+
+// Type ...
+func (Duration) Type() string {
+	return "duration"
+}
+
+// parseDuration parses a duration written as seconds, ticks or in the format of time.ParseDuration.
+func parseDuration(s string) (time.Duration, bool) {
+	if ticks, ok := strings.CutSuffix(s, "t"); ok {
+		v, err := strconv.ParseFloat(ticks, 64)
+		return time.Duration(v * float64(time.Second/20)), err == nil
+	}
+	if v, err := strconv.ParseFloat(s, 64); err == nil {
+		return time.Duration(v * float64(time.Second)), true
+	}
+	d, err := time.ParseDuration(s)
+	return d, err == nil
+}
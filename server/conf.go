@@ -12,6 +12,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/entity"
 	"github.com/df-mc/dragonfly/server/internal/packbuilder"
+	"github.com/df-mc/dragonfly/server/metrics"
 	"github.com/df-mc/dragonfly/server/player"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/playerdb"
@@ -68,6 +69,49 @@ type Config struct {
 	// MaxChunkRadius is the maximum view distance that each player may have,
 	// measured in chunks. A chunk radius generally leads to more memory usage.
 	MaxChunkRadius int
+	// ValidateMovement specifies if the movement of players should be
+	// validated by predicting it server-side. Movement that deviates from the
+	// prediction by more than MovementTolerance blocks is reported through
+	// player.Handler.HandleMovementViolation and corrected by teleporting the
+	// player back.
+	ValidateMovement bool
+	// MovementTolerance is the distance in blocks that the movement of a
+	// player may deviate from the predicted movement per tick if
+	// ValidateMovement is true. If 0, a tolerance of 0.1 is used.
+	MovementTolerance float64
+	// ValidateInteractions specifies if attacks and block interactions of
+	// players should be validated. Interactions that exceed the reach of the
+	// player, that are obstructed by blocks, that exceed MaxClicksPerSecond or
+	// that break blocks too quickly are reported through
+	// player.Handler.HandleInteractionViolation and cancelled.
+	ValidateInteractions bool
+	// ReachTolerance is the distance in blocks that players may exceed their
+	// reach by if ValidateInteractions is true. If 0, a tolerance of 0.5 is
+	// used.
+	ReachTolerance float64
+	// LineOfSightTolerance is the distance in blocks from the target within
+	// which obstructing blocks are ignored if ValidateInteractions is true. If
+	// 0, a tolerance of 0.4 is used.
+	LineOfSightTolerance float64
+	// MaxClicksPerSecond is the maximum number of attacks and swings that
+	// players may perform per second if ValidateInteractions is true. If 0, a
+	// maximum of 20 is used.
+	MaxClicksPerSecond int
+	// BreakTimeTolerance is the duration that players may break blocks faster
+	// than possible if ValidateInteractions is true. If 0, a tolerance of
+	// 150ms is used.
+	BreakTimeTolerance time.Duration
+	// Metrics is the metrics.Registry that metrics of the worlds and sessions
+	// of the server are recorded in. These include tick durations, loaded
+	// chunks and entities, chunk load and generation latency, provider save
+	// durations and the packets sent and received by each player. If nil and
+	// MetricsAddress is set, a new metrics.Registry is created.
+	Metrics *metrics.Registry
+	// MetricsAddress is the address, such as "127.0.0.1:9100", that the
+	// metrics in Metrics are served on in the Prometheus text format under the
+	// /metrics path. If empty, metrics are not served, but are still recorded
+	// if Metrics is set.
+	MetricsAddress string
 	// JoinMessage, QuitMessage and ShutdownMessage are the messages to send for
 	// when a player joins or quits the server and when the server shuts down,
 	// kicking all online players. If set, JoinMessage and QuitMessage must have
@@ -171,6 +215,9 @@ func (conf Config) New() *Server {
 	if conf.Blocks == nil {
 		conf.Blocks = world.DefaultBlockRegistry
 	}
+	if conf.Metrics == nil && conf.MetricsAddress != "" {
+		conf.Metrics = metrics.NewRegistry()
+	}
 
 	// Initialize the passed block registry and also initialize the default block registry which
 	// is used in some vanilla paths.
@@ -232,6 +279,10 @@ type UserConfig struct {
 		DisableJoinQuitMessages bool
 		// MuteEmoteChat specifies if the player emote chat should be muted or not.
 		MuteEmoteChat bool
+		// MetricsAddress is the address on which metrics of the server are
+		// served in the Prometheus text format, such as "127.0.0.1:9100". If
+		// empty, no metrics are recorded.
+		MetricsAddress string
 	}
 	World struct {
 		// SaveData controls whether a world's data will be saved and loaded.
@@ -289,6 +340,7 @@ func (uc UserConfig) Config(log *slog.Logger) (Config, error) {
 		MaxPlayers:              uc.Players.MaxCount,
 		MaxChunkRadius:          uc.Players.MaximumChunkRadius,
 		DisableResourceBuilding: !uc.Resources.AutoBuildPack,
+		MetricsAddress:          uc.Server.MetricsAddress,
 	}
 	if !uc.Server.DisableJoinQuitMessages {
 		conf.JoinMessage, conf.QuitMessage = chat.MessageJoin, chat.MessageQuit
@@ -59,7 +59,7 @@ func (areaEffectCloudType) BBox(e world.Entity) cube.BBox {
 
 func (areaEffectCloudType) DecodeNBT(m map[string]any, data *world.EntityData) {
 	data.Data = AreaEffectCloudBehaviourConfig{
-		Potion:             potion.From(nbtconv.Int32(m, "PotionId")),
+		Potion:             readPotion(m, nbtconv.Int32(m, "PotionId")),
 		Radius:             float64(nbtconv.Float32(m, "Radius")),
 		RadiusUseGrowth:    float64(nbtconv.Float32(m, "RadiusOnUse")),
 		RadiusTickGrowth:   float64(nbtconv.Float32(m, "RadiusPerTick")),
@@ -71,13 +71,13 @@ func (areaEffectCloudType) DecodeNBT(m map[string]any, data *world.EntityData) {
 
 func (areaEffectCloudType) EncodeNBT(data *world.EntityData) map[string]any {
 	a := data.Data.(*AreaEffectCloudBehaviour)
-	return map[string]any{
-		"PotionId":           int32(a.conf.Potion.Uint8()),
+	return writePotion(map[string]any{
+		"PotionId":           int32(a.conf.Potion.Base().Uint8()),
 		"ReapplicationDelay": int32(a.conf.ReapplicationDelay / (time.Second / 20)),
 		"RadiusPerTick":      float32(a.conf.RadiusTickGrowth),
 		"RadiusOnUse":        float32(a.conf.RadiusUseGrowth),
 		"DurationOnUse":      int32(a.conf.DurationUseGrowth / (time.Second / 20)),
 		"Radius":             float32(a.radius),
 		"Duration":           int32(a.duration / (time.Second / 20)),
-	}
+	}, a.conf.Potion)
 }
@@ -1,6 +1,7 @@
 package entity
 
 import (
+	"image/color"
 	"iter"
 	"time"
 
@@ -82,6 +83,11 @@ func (a *AreaEffectCloudBehaviour) Effects() []effect.Effect {
 	return a.conf.Potion.Effects()
 }
 
+// Colour returns the colour of the area effect cloud, which is the colour of its potion.
+func (a *AreaEffectCloudBehaviour) Colour() color.RGBA {
+	return a.conf.Potion.Colour()
+}
+
 // Tick ...
 func (a *AreaEffectCloudBehaviour) Tick(e *Ent, tx *world.Tx) *Movement {
 	a.stationary.Tick(e, tx)
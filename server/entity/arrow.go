@@ -70,7 +70,7 @@ func (arrowType) BBox(world.Entity) cube.BBox {
 func (arrowType) DecodeNBT(m map[string]any, data *world.EntityData) {
 	conf := arrowConf
 	conf.Damage = float64(nbtconv.Float32(m, "Damage"))
-	conf.Potion = potion.From(nbtconv.Int32(m, "auxValue") - 1)
+	conf.Potion = readPotion(m, nbtconv.Int32(m, "auxValue")-1)
 	conf.DisablePickup = !nbtconv.Bool(m, "player")
 	if !nbtconv.Bool(m, "isCreative") {
 		conf.PickupItem = item.NewStack(item.Arrow{Tip: conf.Potion}, 1)
@@ -86,7 +86,7 @@ func (arrowType) EncodeNBT(data *world.EntityData) map[string]any {
 	m := map[string]any{
 		"Damage":       float32(b.conf.Damage),
 		"enchantPunch": byte(b.conf.KnockBackForceAddend / enchantment.Punch.KnockBackMultiplier()),
-		"auxValue":     int32(b.conf.Potion.Uint8() + 1),
+		"auxValue":     int32(b.conf.Potion.Base().Uint8() + 1),
 		"player":       boolByte(!b.conf.DisablePickup),
 		"isCreative":   boolByte(b.conf.PickupItem.Empty()),
 	}
@@ -94,5 +94,5 @@ func (arrowType) EncodeNBT(data *world.EntityData) map[string]any {
 	if b.collided {
 		m["StuckToBlockPos"] = nbtconv.PosToInt32Slice(b.collisionPos)
 	}
-	return m
+	return writePotion(m, b.conf.Potion)
 }
@@ -23,6 +23,10 @@ type (
 	// suffocating in a block.
 	SuffocationDamageSource struct{}
 
+	// BorderDamageSource is used for damage caused by an entity being
+	// outside the world border.
+	BorderDamageSource struct{}
+
 	// DrowningDamageSource is used for damage caused by an entity drowning in
 	// water.
 	DrowningDamageSource struct{}
@@ -78,6 +82,10 @@ func (SuffocationDamageSource) ReducedByResistance() bool { return false }
 func (SuffocationDamageSource) ReducedByArmour() bool     { return false }
 func (SuffocationDamageSource) Fire() bool                { return false }
 func (SuffocationDamageSource) IgnoreTotem() bool         { return false }
+func (BorderDamageSource) ReducedByResistance() bool      { return true }
+func (BorderDamageSource) ReducedByArmour() bool          { return false }
+func (BorderDamageSource) Fire() bool                     { return false }
+func (BorderDamageSource) IgnoreTotem() bool              { return false }
 func (DrowningDamageSource) ReducedByResistance() bool    { return false }
 func (DrowningDamageSource) ReducedByArmour() bool        { return false }
 func (DrowningDamageSource) Fire() bool                   { return false }
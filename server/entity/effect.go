@@ -4,7 +4,10 @@ import (
 	"fmt"
 	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/particle"
+	"github.com/go-gl/mathgl/mgl64"
 	"maps"
+	"math/rand/v2"
 	"reflect"
 	"slices"
 )
@@ -107,6 +110,10 @@ func (m *EffectManager) Tick(entity Living, tx *world.Tx) {
 			continue
 		}
 		eff.Type().Apply(entity, eff)
+		if c, ok := eff.Type().(effect.CustomType); ok && !eff.ParticlesHidden() {
+			// The client is unable to display the particles of custom effects, so they are added by the server.
+			m.addParticles(entity, tx, c, eff)
+		}
 		m.effects[i] = eff.TickDuration()
 	}
 
@@ -117,6 +124,21 @@ func (m *EffectManager) Tick(entity Living, tx *world.Tx) {
 	}
 }
 
+// addParticles adds a particle with the colour of the CustomType passed around the Living entity. Ambient
+// effects add particles less frequently.
+func (m *EffectManager) addParticles(entity Living, tx *world.Tx, c effect.CustomType, eff effect.Effect) {
+	interval := 5
+	if eff.Ambient() {
+		interval = 20
+	}
+	if eff.Tick()%interval != 0 {
+		return
+	}
+	box := entity.H().Type().BBox(entity)
+	offset := mgl64.Vec3{(rand.Float64() - 0.5) * box.Width(), rand.Float64() * box.Height(), (rand.Float64() - 0.5) * box.Length()}
+	tx.AddParticle(entity.Position().Add(offset), particle.Effect{Colour: c.RGBA()})
+}
+
 // flushInitialEffects flushes the initial effects, applying them onto the Living entity passed.
 func (m *EffectManager) flushInitialEffects(entity Living) {
 	initialEffects := m.initialEffects
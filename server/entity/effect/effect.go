@@ -1,6 +1,7 @@
 package effect
 
 import (
+	"image"
 	"image/color"
 	"time"
 
@@ -17,6 +18,28 @@ type LastingType interface {
 	End(e world.Entity, lvl int)
 }
 
+// CustomType is a LastingType that is not known to the client. Custom effects
+// are registered using Register with an ID that is not used by vanilla effects.
+// Start, Apply and End may be implemented to run code when the effect is added,
+// every tick while it is active and when it is removed respectively. The RGBA
+// colour of a CustomType is used for the particles shown around entities that
+// have the effect.
+// Custom effects are never sent to the client and are not shown in the effect
+// list of players, but their name and icon are added to the resource pack, so
+// that they may be used in text and forms.
+type CustomType interface {
+	LastingType
+	// Identifier returns the unique identifier of the effect, including a
+	// namespace, such as 'example:bleeding'. It is used to save the effect.
+	Identifier() string
+	// Name returns the name of the effect as displayed to players. It is added
+	// to the resource pack under the 'potion.<identifier>' language key.
+	Name() string
+	// Icon returns the icon of the effect that is added to the resource pack.
+	// Icon may return nil if the effect has no icon.
+	Icon() image.Image
+}
+
 // Type is an effect implementation that can be applied to an entity.
 type Type interface {
 	// RGBA returns the colour of the effect. If multiple effects are present,
@@ -1,11 +1,19 @@
 package effect
 
+import "slices"
+
 // Register registers an Effect with a specific ID to translate from and to on disk and network. An Effect
 // instance may be created by creating a struct instance in this package like
 // effect.regeneration{}.
+// Effects implementing CustomType are registered under their identifier, so
+// that they may be found using ByName.
 func Register(id int, e Type) {
 	effects[id] = e
 	effectIds[e] = id
+	if c, ok := e.(CustomType); ok {
+		names[id] = c.Identifier()
+		customs = append(customs, c)
+	}
 }
 
 // init registers all implemented effects.
@@ -45,6 +53,17 @@ func init() {
 var (
 	effects   = map[int]Type{}
 	effectIds = map[Type]int{}
+	customs   []CustomType
+	// names holds the identifiers of all vanilla and custom effects, indexed by the ID they are registered
+	// with.
+	names = map[int]string{
+		1: "speed", 2: "slowness", 3: "haste", 4: "mining_fatigue", 5: "strength", 6: "instant_health",
+		7: "instant_damage", 8: "jump_boost", 9: "nausea", 10: "regeneration", 11: "resistance",
+		12: "fire_resistance", 13: "water_breathing", 14: "invisibility", 15: "blindness", 16: "night_vision",
+		17: "hunger", 18: "weakness", 19: "poison", 20: "wither", 21: "health_boost", 22: "absorption",
+		23: "saturation", 24: "levitation", 25: "fatal_poison", 26: "conduit_power", 27: "slow_falling",
+		28: "bad_omen", 29: "village_hero", 30: "darkness",
+	}
 )
 
 // ByID attempts to return an effect by the ID it was registered with. If found, the effect found
@@ -60,3 +79,41 @@ func ID(e Type) (int, bool) {
 	id, ok := effectIds[e]
 	return id, ok
 }
+
+// ByName attempts to return a registered effect by its identifier, such as 'speed' or 'fire_resistance'. If
+// found, the effect is returned and the bool true.
+func ByName(name string) (Type, bool) {
+	for id, n := range names {
+		if n == name {
+			return ByID(id)
+		}
+	}
+	return nil, false
+}
+
+// Name attempts to return the identifier of a registered effect, such as 'speed' or 'fire_resistance'. If
+// the effect is not registered or has no identifier, false is returned.
+func Name(e Type) (string, bool) {
+	id, ok := ID(e)
+	if !ok {
+		return "", false
+	}
+	name, ok := names[id]
+	return name, ok
+}
+
+// Names returns the identifiers of all registered effects that have one.
+func Names() []string {
+	m := make([]string, 0, len(effects))
+	for id := range effects {
+		if name, ok := names[id]; ok {
+			m = append(m, name)
+		}
+	}
+	return m
+}
+
+// CustomTypes returns all registered effects that implement CustomType.
+func CustomTypes() []CustomType {
+	return slices.Clone(customs)
+}
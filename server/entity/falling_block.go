@@ -43,3 +43,11 @@ func (fallingBlockType) EncodeNBT(data *world.EntityData) map[string]any {
 	b := data.Data.(*FallingBlockBehaviour)
 	return map[string]any{"FallDistance": b.passive.fallDistance, "FallingBlock": nbtconv.WriteBlock(b.block)}
 }
+
+// BlockDisguise is a world.EntityType that may be used to disguise an entity as a block. The entity is shown
+// as a falling block of the Block passed, for example through world.ViewLayer.ViewDisguise.
+type BlockDisguise struct {
+	fallingBlockType
+	// Block is the block that the entity is shown as.
+	Block world.Block
+}
@@ -2,7 +2,6 @@ package entity
 
 import (
 	"github.com/df-mc/dragonfly/server/block/cube"
-	"github.com/df-mc/dragonfly/server/entity/effect"
 	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item/potion"
 	"github.com/df-mc/dragonfly/server/world"
@@ -13,11 +12,10 @@ import (
 // variant of a splash potion that can be thrown to leave clouds with status
 // effects that linger on the ground in an area.
 func NewLingeringPotion(opts world.EntitySpawnOpts, t potion.Potion, owner world.Entity) *world.EntityHandle {
-	colour, _ := effect.ResultingColour(t.Effects())
 
 	conf := splashPotionConf
 	conf.Potion = t
-	conf.Particle = particle.Splash{Colour: colour}
+	conf.Particle = particle.Splash{Colour: t.Colour()}
 	conf.Hit = potionSplash(0.25, t, true)
 	conf.Owner = owner.H()
 	return opts.New(LingeringPotionType, conf)
@@ -41,14 +39,14 @@ func (lingeringPotionType) BBox(world.Entity) cube.BBox {
 
 func (lingeringPotionType) DecodeNBT(m map[string]any, data *world.EntityData) {
 	conf := splashPotionConf
-	conf.Potion = potion.From(nbtconv.Int32(m, "PotionId"))
-	colour, _ := effect.ResultingColour(conf.Potion.Effects())
-	conf.Particle = particle.Splash{Colour: colour}
+	conf.Potion = readPotion(m, nbtconv.Int32(m, "PotionId"))
+	conf.Particle = particle.Splash{Colour: conf.Potion.Colour()}
 	conf.Hit = potionSplash(0.25, conf.Potion, true)
 
 	data.Data = conf.New()
 }
 
 func (lingeringPotionType) EncodeNBT(data *world.EntityData) map[string]any {
-	return map[string]any{"PotionId": int32(data.Data.(*ProjectileBehaviour).conf.Potion.Uint8())}
+	t := data.Data.(*ProjectileBehaviour).conf.Potion
+	return writePotion(map[string]any{"PotionId": int32(t.Base().Uint8())}, t)
 }
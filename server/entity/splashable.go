@@ -5,6 +5,7 @@ import (
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/block/cube/trace"
 	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
 	"github.com/df-mc/dragonfly/server/item/potion"
 	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
@@ -102,3 +103,21 @@ func potionSplash(durMul float64, pot potion.Potion, linger bool) func(e *Ent, t
 		}
 	}
 }
+
+// readPotion returns the potion type stored in the NBT of an entity. Custom potion types are stored by name,
+// as their IDs may change between restarts, and take precedence over the vanilla potion ID passed.
+func readPotion(m map[string]any, id int32) potion.Potion {
+	if t, ok := potion.ByName(nbtconv.String(m, "CustomPotionType")); ok {
+		return t
+	}
+	return potion.From(id)
+}
+
+// writePotion stores the name of the potion type passed in the NBT of an entity if it is a custom potion
+// type, and returns the NBT.
+func writePotion(m map[string]any, p potion.Potion) map[string]any {
+	if c, ok := p.Custom(); ok {
+		m["CustomPotionType"] = c.Name
+	}
+	return m
+}
@@ -0,0 +1,47 @@
+package packbuilder
+
+import (
+	"fmt"
+	"image"
+	"image/png"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/entity/effect"
+)
+
+// buildEffects builds all the effect-related files for the resource pack. This includes the icons and language
+// entries of custom effects.
+func buildEffects(dir string) (count int, lang []string) {
+	for _, e := range effect.CustomTypes() {
+		identifier := e.Identifier()
+		lang = append(lang, fmt.Sprintf("potion.%s=%s", identifier, e.Name()))
+
+		if icon := e.Icon(); icon != nil {
+			name := strings.ReplaceAll(identifier, ":", "_")
+			buildEffectIcon(dir, name, icon)
+		}
+		count++
+	}
+	return
+}
+
+// buildEffectIcon creates a PNG file for the icon of an effect from the provided image and name and writes it
+// to the pack.
+func buildEffectIcon(dir, name string, img image.Image) {
+	if err := os.MkdirAll(filepath.Join(dir, "textures/ui"), os.ModePerm); err != nil {
+		panic(err)
+	}
+	icon, err := os.Create(filepath.Join(dir, "textures/ui", name+"_effect.png"))
+	if err != nil {
+		panic(err)
+	}
+	if err := png.Encode(icon, img); err != nil {
+		_ = icon.Close()
+		panic(err)
+	}
+	if err := icon.Close(); err != nil {
+		panic(err)
+	}
+}
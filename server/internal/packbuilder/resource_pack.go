@@ -33,6 +33,10 @@ func BuildResourcePack(reg world.BlockRegistry) (*resource.Pack, bool) {
 	assets += blockCount
 	lang = append(lang, blockLang...)
 
+	effectCount, effectLang := buildEffects(dir)
+	assets += effectCount
+	lang = append(lang, effectLang...)
+
 	if assets > 0 {
 		buildLanguageFile(dir, lang)
 		if err := os.WriteFile(dir+"/pack_icon.png", packIcon, 0666); err != nil {
@@ -0,0 +1,86 @@
+package packbuilder
+
+import (
+	"archive/zip"
+	"bytes"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// testEffect is a custom effect type with an optional icon.
+type testEffect struct {
+	id, name string
+	icon     image.Image
+}
+
+func (testEffect) RGBA() color.RGBA                  { return color.RGBA{R: 0xff, A: 0xff} }
+func (testEffect) Apply(world.Entity, effect.Effect) {}
+func (testEffect) Start(world.Entity, int)           {}
+func (testEffect) End(world.Entity, int)             {}
+func (e testEffect) Identifier() string              { return e.id }
+func (e testEffect) Name() string                    { return e.name }
+func (e testEffect) Icon() image.Image               { return e.icon }
+
+// Effects cannot be unregistered, so the effects used by the tests are registered once for the test binary.
+func init() {
+	effect.Register(200, testEffect{id: "test:bleeding", name: "Bleeding", icon: image.NewRGBA(image.Rect(0, 0, 18, 18))})
+	effect.Register(201, testEffect{id: "test:focus", name: "Focus"})
+}
+
+func TestBuildResourcePackEffects(t *testing.T) {
+	pack, ok := BuildResourcePack(world.DefaultBlockRegistry)
+	if !ok {
+		t.Fatalf("expected resource pack to be built for custom effects")
+	}
+	r, err := zip.NewReader(pack, int64(pack.Len()))
+	if err != nil {
+		t.Fatalf("read pack: %v", err)
+	}
+	// readFile returns the contents of the file at the path passed in the pack, or false if it does not exist.
+	readFile := func(name string) ([]byte, bool) {
+		f, err := r.Open(name)
+		if err != nil {
+			return nil, false
+		}
+		defer f.Close()
+		b, err := io.ReadAll(f)
+		if err != nil {
+			t.Fatalf("read %v: %v", name, err)
+		}
+		return b, true
+	}
+
+	lang, ok := readFile("texts/en_US.lang")
+	if !ok {
+		t.Fatalf("expected pack to have a language file")
+	}
+	lines := strings.Split(string(lang), "\n")
+	for _, want := range []string{"potion.test:bleeding=Bleeding", "potion.test:focus=Focus"} {
+		if !slices.Contains(lines, want) {
+			t.Errorf("expected language entry %q, got %q", want, lines)
+		}
+	}
+
+	icon, ok := readFile("textures/ui/test_bleeding_effect.png")
+	if !ok {
+		t.Fatalf("expected pack to have an icon for test:bleeding")
+	}
+	img, err := png.Decode(bytes.NewReader(icon))
+	if err != nil {
+		t.Fatalf("decode icon: %v", err)
+	}
+	if size := img.Bounds().Size(); size != image.Pt(18, 18) {
+		t.Errorf("expected 18x18 icon, got %v", size)
+	}
+	if _, ok := readFile("textures/ui/test_focus_effect.png"); ok {
+		t.Errorf("expected no icon for test:focus, which has no icon")
+	}
+}
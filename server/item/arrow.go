@@ -11,12 +11,23 @@ type Arrow struct {
 
 // EncodeItem ...
 func (a Arrow) EncodeItem() (name string, meta int16) {
-	if tip := a.Tip.Uint8(); tip > 4 {
+	if tip := a.Tip.Base().Uint8(); tip > 4 {
 		return "minecraft:arrow", int16(tip + 1)
 	}
 	return "minecraft:arrow", 0
 }
 
+// DecodeNBT ...
+func (a Arrow) DecodeNBT(data map[string]any) any {
+	a.Tip = potionFromNBT(data, a.Tip)
+	return a
+}
+
+// EncodeNBT ...
+func (a Arrow) EncodeNBT() map[string]any {
+	return potionToNBT(a.Tip)
+}
+
 // OffHand ...
 func (Arrow) OffHand() bool {
 	return true
@@ -0,0 +1,136 @@
+package item
+
+import (
+	"slices"
+	"strings"
+)
+
+// Bundle is an item that can store a mix of different items in a single inventory slot. The number of items
+// it can hold is limited by their weight: Items that stack to 64 take up less space than items that stack
+// to 16 or that do not stack at all.
+type Bundle struct {
+	// Colour is the colour of the bundle. It is only used if Dyed is true.
+	Colour Colour
+	// Dyed specifies if the bundle was dyed. Bundles that are not dyed have their natural brown colour.
+	Dyed bool
+	// Contents holds the stacks of items stored in the bundle. The first stack is the stack most recently
+	// inserted, which is shown on top and extracted first.
+	Contents []Stack
+}
+
+// bundleCapacity is the total weight of the items that a bundle can hold.
+const bundleCapacity = 64
+
+// MaxCount always returns 1.
+func (Bundle) MaxCount() int {
+	return 1
+}
+
+// Weight returns the total weight of the contents of the bundle. A bundle can hold items with a weight of
+// at most 64.
+func (b Bundle) Weight() int {
+	weight := 0
+	for _, s := range b.Contents {
+		weight += BundleWeight(s)
+	}
+	return weight
+}
+
+// Empty checks if the bundle does not hold any items.
+func (b Bundle) Empty() bool {
+	return len(b.Contents) == 0
+}
+
+// BundleWeight returns the weight that the Stack passed takes up when stored in a bundle. Every item takes up
+// 64 divided by the max count of the item, so that a bundle can hold at most one full stack of items. A bundle
+// stored in another bundle weighs 4, plus the weight of its contents.
+func BundleWeight(s Stack) int {
+	if b, ok := s.Item().(Bundle); ok {
+		return s.Count() * (4 + b.Weight())
+	}
+	return s.Count() * (bundleCapacity / max(s.MaxCount(), 1))
+}
+
+// CanStore checks if the Stack passed may be stored in a bundle. Shulker boxes cannot be stored in bundles.
+func (Bundle) CanStore(s Stack) bool {
+	if s.Empty() {
+		return false
+	}
+	name, _ := s.Item().EncodeItem()
+	return !strings.HasSuffix(name, "shulker_box")
+}
+
+// Insert inserts as many items of the Stack passed into the bundle as fit. The items are merged with the
+// stack on top of the bundle if possible, or put on top of the bundle otherwise. The resulting bundle is
+// returned, together with the items that did not fit in the bundle.
+func (b Bundle) Insert(s Stack) (Bundle, Stack) {
+	if !b.CanStore(s) {
+		return b, s
+	}
+	weight := BundleWeight(s.Grow(1 - s.Count()))
+	n := min(s.Count(), (bundleCapacity-b.Weight())/max(weight, 1))
+	if n <= 0 {
+		return b, s
+	}
+	contents := slices.Clone(b.Contents)
+	added := s.Grow(n - s.Count())
+	if ind := slices.IndexFunc(contents, func(existing Stack) bool {
+		return existing.Comparable(added) && existing.Count()+n <= existing.MaxCount()
+	}); ind >= 0 {
+		added = contents[ind].Grow(n)
+		contents = slices.Delete(contents, ind, ind+1)
+	}
+	b.Contents = append([]Stack{added}, contents...)
+	return b, s.Grow(-n)
+}
+
+// Extract removes the stack at the index passed from the bundle. The resulting bundle is returned, together
+// with the stack removed. If the index is out of range, the bundle is returned unchanged together with an
+// empty Stack.
+func (b Bundle) Extract(index int) (Bundle, Stack) {
+	if index < 0 || index >= len(b.Contents) {
+		return b, Stack{}
+	}
+	s := b.Contents[index]
+	b.Contents = slices.Delete(slices.Clone(b.Contents), index, index+1)
+	return b, s
+}
+
+// EncodeItem ...
+func (b Bundle) EncodeItem() (name string, meta int16) {
+	if b.Dyed {
+		return "minecraft:" + b.Colour.String() + "_bundle", 0
+	}
+	return "minecraft:bundle", 0
+}
+
+// DecodeNBT ...
+func (b Bundle) DecodeNBT(data map[string]any) any {
+	contents, ok := data["storage_item_component_content"].([]map[string]any)
+	if !ok {
+		for _, v := range nbtSlice(data, "storage_item_component_content") {
+			if m, ok := v.(map[string]any); ok {
+				contents = append(contents, m)
+			}
+		}
+	}
+	b.Contents = nil
+	for _, m := range contents {
+		if s := ReadNBT(m, nil); !s.Empty() {
+			b.Contents = append(b.Contents, s)
+		}
+	}
+	return b
+}
+
+// EncodeNBT ...
+func (b Bundle) EncodeNBT() map[string]any {
+	if len(b.Contents) == 0 {
+		return nil
+	}
+	contents := make([]any, 0, len(b.Contents))
+	for _, s := range b.Contents {
+		contents = append(contents, WriteNBT(s, true))
+	}
+	return map[string]any{"storage_item_component_content": contents}
+}
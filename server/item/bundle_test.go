@@ -0,0 +1,128 @@
+package item
+
+import (
+	"testing"
+)
+
+// bundleOf returns a Bundle holding the stacks passed, with the first stack on top.
+func bundleOf(contents ...Stack) Bundle {
+	return Bundle{Contents: contents}
+}
+
+// sameContents checks if the contents of two bundles hold the same items in the same order.
+func sameContents(a, b []Stack) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if !a[i].Equal(b[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestBundleWeight(t *testing.T) {
+	tests := []struct {
+		name  string
+		stack Stack
+		want  int
+	}{
+		{name: "stack size 64", stack: NewStack(Stick{}, 1), want: 1},
+		{name: "full stack size 64", stack: NewStack(Stick{}, 64), want: 64},
+		{name: "stack size 16", stack: NewStack(EnderPearl{}, 1), want: 4},
+		{name: "full stack size 16", stack: NewStack(EnderPearl{}, 16), want: 64},
+		{name: "stack size 1", stack: NewStack(Shears{}, 1), want: 64},
+		{name: "empty bundle", stack: NewStack(Bundle{}, 1), want: 4},
+		{name: "nested bundle", stack: NewStack(bundleOf(NewStack(Stick{}, 10), NewStack(EnderPearl{}, 2)), 1), want: 22},
+		{name: "doubly nested bundle", stack: NewStack(bundleOf(NewStack(bundleOf(NewStack(Stick{}, 10)), 1)), 1), want: 18},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := BundleWeight(test.stack); got != test.want {
+				t.Fatalf("expected weight %v, got %v", test.want, got)
+			}
+			if got := bundleOf(test.stack).Weight(); got != test.want {
+				t.Fatalf("expected bundle weight %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestBundleInsert(t *testing.T) {
+	sticks := func(n int) Stack { return NewStack(Stick{}, n) }
+	pearls := func(n int) Stack { return NewStack(EnderPearl{}, n) }
+	tests := []struct {
+		name         string
+		bundle       Bundle
+		stack        Stack
+		wantContents []Stack
+		wantLeft     int
+	}{
+		{name: "empty", stack: sticks(10), wantContents: []Stack{sticks(10)}},
+		{name: "merge with top", bundle: bundleOf(sticks(10)), stack: sticks(5), wantContents: []Stack{sticks(15)}},
+		{name: "new stack on top", bundle: bundleOf(sticks(10)), stack: pearls(2), wantContents: []Stack{pearls(2), sticks(10)}},
+		{name: "merge moves stack to top", bundle: bundleOf(pearls(2), sticks(10)), stack: sticks(5), wantContents: []Stack{sticks(15), pearls(2)}},
+		{name: "fill exactly", bundle: bundleOf(sticks(60)), stack: sticks(4), wantContents: []Stack{sticks(64)}},
+		{name: "exactly full", bundle: bundleOf(sticks(64)), stack: sticks(1), wantContents: []Stack{sticks(64)}, wantLeft: 1},
+		{name: "partial", bundle: bundleOf(sticks(60)), stack: sticks(10), wantContents: []Stack{sticks(64)}, wantLeft: 6},
+		{name: "stack size 16", stack: pearls(16), wantContents: []Stack{pearls(16)}},
+		{name: "stack size 16 partial", bundle: bundleOf(sticks(32)), stack: pearls(16), wantContents: []Stack{pearls(8), sticks(32)}, wantLeft: 8},
+		{name: "stack size 16 rounds down", bundle: bundleOf(sticks(61)), stack: pearls(1), wantContents: []Stack{sticks(61)}, wantLeft: 1},
+		{name: "stack size 1", stack: NewStack(Shears{}, 1), wantContents: []Stack{NewStack(Shears{}, 1)}},
+		{name: "stack size 1 does not fit", bundle: bundleOf(sticks(1)), stack: NewStack(Shears{}, 1), wantContents: []Stack{sticks(1)}, wantLeft: 1},
+		{name: "nested bundle", bundle: bundleOf(sticks(56)), stack: NewStack(bundleOf(sticks(4)), 1), wantContents: []Stack{NewStack(bundleOf(sticks(4)), 1), sticks(56)}},
+		{name: "nested bundle does not fit", bundle: bundleOf(sticks(56)), stack: NewStack(bundleOf(sticks(5)), 1), wantContents: []Stack{sticks(56)}, wantLeft: 1},
+		{name: "nested empty bundle", bundle: bundleOf(sticks(60)), stack: NewStack(Bundle{}, 1), wantContents: []Stack{NewStack(Bundle{}, 1), sticks(60)}},
+		{name: "empty stack", bundle: bundleOf(sticks(1)), stack: Stack{}, wantContents: []Stack{sticks(1)}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			before := append([]Stack(nil), test.bundle.Contents...)
+			b, left := test.bundle.Insert(test.stack)
+			if !sameContents(b.Contents, test.wantContents) {
+				t.Fatalf("expected contents %v, got %v", test.wantContents, b.Contents)
+			}
+			if left.Count() != test.wantLeft || (test.wantLeft > 0 && !left.Comparable(test.stack)) {
+				t.Fatalf("expected %v items to be left, got %v", test.wantLeft, left)
+			}
+			if b.Weight() > bundleCapacity {
+				t.Fatalf("expected weight to be at most %v, got %v", bundleCapacity, b.Weight())
+			}
+			if !sameContents(test.bundle.Contents, before) {
+				t.Fatalf("expected contents of original bundle to be left unchanged, got %v", test.bundle.Contents)
+			}
+		})
+	}
+}
+
+func TestBundleExtract(t *testing.T) {
+	sticks, pearls, shears := NewStack(Stick{}, 10), NewStack(EnderPearl{}, 2), NewStack(Shears{}, 1)
+	tests := []struct {
+		name         string
+		index        int
+		want         Stack
+		wantContents []Stack
+	}{
+		{name: "top", index: 0, want: sticks, wantContents: []Stack{pearls, shears}},
+		{name: "middle", index: 1, want: pearls, wantContents: []Stack{sticks, shears}},
+		{name: "bottom", index: 2, want: shears, wantContents: []Stack{sticks, pearls}},
+		{name: "negative", index: -1, wantContents: []Stack{sticks, pearls, shears}},
+		{name: "out of range", index: 3, wantContents: []Stack{sticks, pearls, shears}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			bundle := bundleOf(sticks, pearls, shears)
+			b, s := bundle.Extract(test.index)
+			if !s.Equal(test.want) || s.Empty() != test.want.Empty() {
+				t.Fatalf("expected %v to be extracted, got %v", test.want, s)
+			}
+			if !sameContents(b.Contents, test.wantContents) {
+				t.Fatalf("expected contents %v, got %v", test.wantContents, b.Contents)
+			}
+			if !sameContents(bundle.Contents, []Stack{sticks, pearls, shears}) {
+				t.Fatalf("expected contents of original bundle to be left unchanged, got %v", bundle.Contents)
+			}
+		})
+	}
+}
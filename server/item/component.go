@@ -0,0 +1,192 @@
+package item
+
+import (
+	"fmt"
+	"maps"
+	"reflect"
+)
+
+// ComponentCodec encodes and decodes the values of a Component to and from a value that can be stored in NBT,
+// such as a string, an int32, a []any or a map[string]any.
+type ComponentCodec[T any] interface {
+	// Encode encodes the value passed into a value that can be stored in NBT.
+	Encode(v T) any
+	// Decode decodes data previously returned by Encode. The version passed is the version of the Component
+	// at the time the data was encoded, which may be lower than the current version if the data was stored
+	// before the Component was changed. Decode should migrate such data or return an error if it cannot.
+	Decode(data any, version int) (T, error)
+}
+
+// Component is a typed piece of data that may be attached to a Stack. A Component is registered using
+// RegisterComponent, after which its value may be set on a Stack using Component.Set and read using
+// Component.Get. Unlike values set using Stack.WithValue, the values of components are encoded using a
+// ComponentCodec and stored together with the version of the Component, so that data saved by older versions
+// may be migrated when it is read.
+// Two stacks are only Comparable if their components are equal.
+type Component[T any] struct {
+	key     string
+	version int
+	codec   ComponentCodec[T]
+	def     T
+}
+
+// RegisterComponent registers a Component with the key, version and codec passed. The default value passed is
+// returned by Component.Get for stacks that do not have a value set for the Component. RegisterComponent panics
+// if a Component with the same key was already registered.
+// The key should include a namespace, such as 'example:level', to prevent conflicts with other components.
+func RegisterComponent[T any](key string, version int, codec ComponentCodec[T], def T) Component[T] {
+	if _, ok := componentTypes[key]; ok {
+		panic(fmt.Sprintf("component %v is already registered", key))
+	}
+	c := Component[T]{key: key, version: version, codec: codec, def: def}
+	componentTypes[key] = c
+	return c
+}
+
+// Key returns the key that the Component was registered with.
+func (c Component[T]) Key() string {
+	return c.key
+}
+
+// Version returns the current version of the Component, as passed to RegisterComponent.
+func (c Component[T]) Version() int {
+	return c.version
+}
+
+// Default returns the default value of the Component, as passed to RegisterComponent.
+func (c Component[T]) Default() T {
+	return c.def
+}
+
+// Get returns the value of the Component set on the Stack passed. If no value was set, or if the value could
+// not be decoded, the default value of the Component is returned.
+func (c Component[T]) Get(s Stack) T {
+	if v, ok := c.Lookup(s); ok {
+		return v
+	}
+	return c.def
+}
+
+// Lookup returns the value of the Component set on the Stack passed. If no value was set, or if the value
+// could not be decoded, the default value of the Component and false are returned.
+func (c Component[T]) Lookup(s Stack) (T, bool) {
+	if s.Empty() {
+		return c.def, false
+	}
+	switch v := s.components[c.key].(type) {
+	case T:
+		return v, true
+	case rawComponent:
+		// The value was read before the Component was registered, so it is decoded now.
+		if c.codec == nil {
+			break
+		}
+		if val, err := c.codec.Decode(v.data, v.version); err == nil {
+			return val, true
+		}
+	}
+	return c.def, false
+}
+
+// Set returns the Stack passed with the value of the Component set to v. Set panics if the Component was not
+// returned by RegisterComponent, such as a zero Component.
+func (c Component[T]) Set(s Stack, v T) Stack {
+	if _, ok := componentTypes[c.key]; !ok || c.codec == nil {
+		panic(fmt.Sprintf("component %q is not registered: use RegisterComponent to create components", c.key))
+	}
+	s.components = cloneMap(s.components)
+	s.components[c.key] = v
+	return s
+}
+
+// Remove returns the Stack passed with the value of the Component removed.
+func (c Component[T]) Remove(s Stack) Stack {
+	if _, ok := s.components[c.key]; !ok {
+		return s
+	}
+	s.components = maps.Clone(s.components)
+	delete(s.components, c.key)
+	if len(s.components) == 0 {
+		s.components = nil
+	}
+	return s
+}
+
+// encode encodes a value of the Component, returning the current version of the Component and the data
+// produced by its codec.
+func (c Component[T]) encode(v any) (int, any) {
+	return c.version, c.codec.Encode(v.(T))
+}
+
+// decode decodes data of the Component encoded with the version passed.
+func (c Component[T]) decode(data any, version int) (any, error) {
+	return c.codec.Decode(data, version)
+}
+
+// componentType is the type-erased form of a Component stored in the registry.
+type componentType interface {
+	encode(v any) (int, any)
+	decode(data any, version int) (any, error)
+}
+
+// rawComponent holds the encoded value of a component that could not be decoded, either because its Component
+// was not registered or because decoding failed. The data is kept so that it is not lost when the Stack is
+// saved again.
+type rawComponent struct {
+	version int
+	data    any
+}
+
+// componentTypes holds all registered components, indexed by their key.
+var componentTypes = map[string]componentType{}
+
+// encodeComponent encodes the value of the component with the key passed, returning the version it was
+// encoded with and its encoded data. If the value cannot be encoded because no component with the key is
+// registered, false is returned.
+func encodeComponent(key string, v any) (int, any, bool) {
+	if raw, ok := v.(rawComponent); ok {
+		return raw.version, raw.data, true
+	}
+	t, ok := componentTypes[key]
+	if !ok {
+		return 0, nil, false
+	}
+	version, data := t.encode(v)
+	return version, data, true
+}
+
+// decodeComponent decodes the data of the component with the key passed. If the component is not registered
+// or if the data could not be decoded, a rawComponent holding the data is returned.
+func decodeComponent(key string, data any, version int) any {
+	if t, ok := componentTypes[key]; ok {
+		if v, err := t.decode(data, version); err == nil {
+			return v
+		}
+	}
+	return rawComponent{version: version, data: data}
+}
+
+// componentsEqual checks if the components passed are equal by comparing their encoded data.
+func componentsEqual(a, b map[string]any) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for k, v := range a {
+		v2, ok := b[k]
+		if !ok {
+			return false
+		}
+		ver, data, ok := encodeComponent(k, v)
+		ver2, data2, ok2 := encodeComponent(k, v2)
+		if !ok || !ok2 {
+			if !reflect.DeepEqual(v, v2) {
+				return false
+			}
+			continue
+		}
+		if ver != ver2 || !reflect.DeepEqual(data, data2) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,108 @@
+package item
+
+import (
+	"fmt"
+	"testing"
+)
+
+// levelCodec encodes an int as a map holding the level. Version 1 of the component stored the level directly
+// as an int32.
+type levelCodec struct{}
+
+func (levelCodec) Encode(v int) any {
+	return map[string]any{"level": int32(v)}
+}
+
+func (levelCodec) Decode(data any, version int) (int, error) {
+	if version < 2 {
+		v, ok := data.(int32)
+		if !ok {
+			return 0, fmt.Errorf("expected int32, got %T", data)
+		}
+		return int(v), nil
+	}
+	m, ok := data.(map[string]any)
+	if !ok {
+		return 0, fmt.Errorf("expected map, got %T", data)
+	}
+	v, ok := m["level"].(int32)
+	if !ok {
+		return 0, fmt.Errorf("expected int32 level, got %T", m["level"])
+	}
+	return int(v), nil
+}
+
+var testLevel = RegisterComponent[int]("test:level", 2, levelCodec{}, 1)
+
+func TestComponentNBTRoundTrip(t *testing.T) {
+	s := testLevel.Set(NewStack(Stick{}, 1), 5)
+	read := ReadNBT(WriteNBT(s, false), &Stack{item: Stick{}, count: 1})
+	if v, ok := testLevel.Lookup(read); !ok || v != 5 {
+		t.Fatalf("expected level 5 after round-trip, got %v (%v)", v, ok)
+	}
+	if !read.Comparable(s) {
+		t.Errorf("expected stack read to be comparable to the stack written")
+	}
+	if v := testLevel.Get(testLevel.Remove(read)); v != 1 {
+		t.Errorf("expected default level 1 after removing the component, got %v", v)
+	}
+}
+
+func TestComponentMigration(t *testing.T) {
+	data := map[string]any{"dragonflyComponents": map[string]any{
+		"test:level": map[string]any{"version": int32(1), "value": int32(7)},
+	}}
+	s := ReadNBT(data, &Stack{item: Stick{}, count: 1})
+	if v, ok := testLevel.Lookup(s); !ok || v != 7 {
+		t.Fatalf("expected level 7 migrated from version 1, got %v (%v)", v, ok)
+	}
+	c := WriteNBT(s, false)["dragonflyComponents"].(map[string]any)["test:level"].(map[string]any)
+	if c["version"] != int32(2) {
+		t.Errorf("expected component to be written with version 2, got %v", c["version"])
+	}
+}
+
+func TestComponentReadBeforeRegistration(t *testing.T) {
+	const key = "test:late"
+	data := map[string]any{"dragonflyComponents": map[string]any{
+		key: map[string]any{"version": int32(2), "value": map[string]any{"level": int32(3)}},
+	}}
+	s := ReadNBT(data, &Stack{item: Stick{}, count: 1})
+	if _, ok := s.components[key].(rawComponent); !ok {
+		t.Fatalf("expected unregistered component to be kept raw, got %T", s.components[key])
+	}
+	if c, ok := WriteNBT(s, false)["dragonflyComponents"].(map[string]any)[key].(map[string]any); !ok || c["version"] != int32(2) {
+		t.Errorf("expected raw component to be written unchanged, got %v", c)
+	}
+
+	late := RegisterComponent[int](key, 2, levelCodec{}, 1)
+	t.Cleanup(func() { delete(componentTypes, key) })
+	if v, ok := late.Lookup(s); !ok || v != 3 {
+		t.Errorf("expected level 3 decoded after registration, got %v (%v)", v, ok)
+	}
+}
+
+func TestComponentStacking(t *testing.T) {
+	a, b := testLevel.Set(NewStack(Stick{}, 1), 2), testLevel.Set(NewStack(Stick{}, 1), 3)
+	if a.Comparable(b) {
+		t.Fatalf("expected stacks with different components not to be comparable")
+	}
+	if a.Comparable(NewStack(Stick{}, 1)) {
+		t.Errorf("expected stack with a component not to be comparable to a stack without")
+	}
+	if merged, left := a.AddStack(b); merged.Count() != 1 || left.Count() != 1 {
+		t.Errorf("expected stacks with different components not to merge, got %v and %v", merged, left)
+	}
+	if merged, left := a.AddStack(testLevel.Set(NewStack(Stick{}, 1), 2)); merged.Count() != 2 || !left.Empty() {
+		t.Errorf("expected stacks with equal components to merge, got %v and %v", merged, left)
+	}
+}
+
+func TestComponentZeroSet(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Errorf("expected Set on a zero component to panic")
+		}
+	}()
+	Component[int]{}.Set(NewStack(Stick{}, 1), 1)
+}
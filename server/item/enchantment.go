@@ -1,6 +1,7 @@
 package item
 
 import (
+	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
 	"maps"
 	"slices"
@@ -62,6 +63,8 @@ type Enchantable interface {
 
 // RegisterEnchantment registers an enchantment with the ID passed. Once registered, enchantments may be received
 // by instantiating an EnchantmentType struct (e.g. enchantment.Protection{})
+// Custom enchantments may be registered with IDs that are not used by vanilla enchantments. Their behaviour may
+// be implemented using AttackEnchantment, HurtEnchantment, BreakEnchantment and TickEnchantment.
 func RegisterEnchantment(id int, enchantment EnchantmentType) {
 	enchantmentsMap[id] = enchantment
 	enchantmentIDs[enchantment] = id
@@ -96,3 +99,39 @@ func Enchantments() []EnchantmentType {
 	})
 	return e
 }
+
+// AttackEnchantment is an EnchantmentType with behaviour that is triggered when an entity is attacked with an
+// item that has the enchantment.
+type AttackEnchantment interface {
+	EnchantmentType
+	// Attack is called when the attacker passed attacks the target using an item with the enchantment, before
+	// the target is hurt. The damage dealt to the target may be modified.
+	Attack(level int, tx *world.Tx, attacker, target world.Entity, dmg *float64)
+}
+
+// HurtEnchantment is an EnchantmentType with behaviour that is triggered when an entity wearing an item with
+// the enchantment takes damage.
+type HurtEnchantment interface {
+	EnchantmentType
+	// Hurt is called when the damage that the entity passed takes from the source passed is calculated, and
+	// returns the damage left after the enchantment is applied. Hurt may be called without the entity actually
+	// being hurt, so it should not have side effects.
+	Hurt(level int, tx *world.Tx, e world.Entity, dmg float64, src world.DamageSource) float64
+}
+
+// BreakEnchantment is an EnchantmentType with behaviour that is triggered when a block is broken using an item
+// that has the enchantment.
+type BreakEnchantment interface {
+	EnchantmentType
+	// BreakBlock is called when the entity passed breaks the block at the position passed using an item with
+	// the enchantment, before the block is removed. The drops and experience of the block may be modified.
+	BreakBlock(level int, tx *world.Tx, e world.Entity, pos cube.Pos, drops *[]Stack, xp *int)
+}
+
+// TickEnchantment is an EnchantmentType with behaviour that is triggered every tick while an item with the
+// enchantment is held or worn by an entity.
+type TickEnchantment interface {
+	EnchantmentType
+	// Tick is called every tick for the entity holding or wearing an item with the enchantment.
+	Tick(level int, tx *world.Tx, e world.Entity, current int64)
+}
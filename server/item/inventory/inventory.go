@@ -64,6 +64,18 @@ func (inv *Inventory) SlotFunc(f SlotFunc) {
 	inv.f = f
 }
 
+// AddSlotFunc adds a function called when a slot in the inventory is changed. Unlike SlotFunc, the function
+// currently set is kept and called before the function passed.
+func (inv *Inventory) AddSlotFunc(f SlotFunc) {
+	inv.mu.Lock()
+	defer inv.mu.Unlock()
+	prev := inv.f
+	inv.f = func(slot int, before, after item.Stack) {
+		prev(slot, before, after)
+		f(slot, before, after)
+	}
+}
+
 // SlotValidatorFunc changes the function that limits item placement in the inventory slot.
 func (inv *Inventory) SlotValidatorFunc(f SlotValidatorFunc) {
 	inv.mu.Lock()
@@ -3,6 +3,7 @@ package item
 import (
 	"encoding/binary"
 	"image/color"
+	"strings"
 	"time"
 
 	"github.com/df-mc/dragonfly/server/block/cube"
@@ -137,6 +138,28 @@ type Cooldown interface {
 	Cooldown() time.Duration
 }
 
+// CategorisedCooldown represents an item with a cooldown that is shared with all other items in the same
+// cooldown category.
+type CategorisedCooldown interface {
+	Cooldown
+	// CooldownCategory returns the name of the category that the cooldown of the item belongs to.
+	CooldownCategory() string
+}
+
+// CooldownCategory returns the cooldown category of the item passed. Items implementing CategorisedCooldown
+// return their own category, while the category of other items is their name without namespace, which is how
+// the client groups the cooldowns of vanilla items, such as 'ender_pearl'.
+func CooldownCategory(it world.Item) string {
+	if c, ok := it.(CategorisedCooldown); ok {
+		return c.CooldownCategory()
+	}
+	name, _ := it.EncodeItem()
+	if _, after, ok := strings.Cut(name, ":"); ok {
+		return after
+	}
+	return name
+}
+
 // nameable represents a block that may be named. These are often containers such as chests, which have a
 // name displayed in their interface.
 type nameable interface {
@@ -31,5 +31,16 @@ func (l LingeringPotion) Use(tx *world.Tx, user User, ctx *UseContext) bool {
 
 // EncodeItem ...
 func (l LingeringPotion) EncodeItem() (name string, meta int16) {
-	return "minecraft:lingering_potion", int16(l.Type.Uint8())
+	return "minecraft:lingering_potion", int16(l.Type.Base().Uint8())
+}
+
+// DecodeNBT ...
+func (l LingeringPotion) DecodeNBT(data map[string]any) any {
+	l.Type = potionFromNBT(data, l.Type)
+	return l
+}
+
+// EncodeNBT ...
+func (l LingeringPotion) EncodeNBT() map[string]any {
+	return potionToNBT(l.Type)
 }
@@ -26,6 +26,7 @@ func WriteNBT(s Stack, disk bool) map[string]any {
 	writeDamage(tag, s, disk)
 	writeDisplay(tag, s)
 	writeDragonflyData(tag, s)
+	writeComponents(tag, s)
 	writeEnchantments(tag, s)
 	writeUnbreakable(tag, s)
 
@@ -60,6 +61,7 @@ func ReadNBT(data map[string]any, s *Stack) Stack {
 	readDamage(tag, s, disk)
 	readDisplay(tag, s)
 	readDragonflyData(tag, s)
+	readComponents(tag, s)
 	readEnchantments(tag, s)
 	readUnbreakable(tag, s)
 	return *s
@@ -105,6 +107,21 @@ func writeDragonflyData(m map[string]any, s Stack) {
 	}
 }
 
+// writeComponents writes the components of a Stack to a map for NBT encoding. Each component is stored with
+// the version of the Component that it was encoded with.
+func writeComponents(m map[string]any, s Stack) {
+	if len(s.components) == 0 {
+		return
+	}
+	components := make(map[string]any, len(s.components))
+	for k, v := range s.components {
+		if version, data, ok := encodeComponent(k, v); ok {
+			components[k] = map[string]any{"version": int32(version), "value": data}
+		}
+	}
+	m["dragonflyComponents"] = components
+}
+
 // mapToSlice converts a map to a slice of the type mapValue and orders the slice by the keys in the map to ensure a
 // deterministic order.
 func mapToSlice(m map[string]any) []mapValue {
@@ -279,6 +296,21 @@ func readDragonflyData(m map[string]any, s *Stack) {
 	}
 }
 
+// readComponents reads the components written to the dragonflyComponents field in the NBT of an item and adds
+// them to the Stack passed. Components that are not registered are kept as they are.
+func readComponents(m map[string]any, s *Stack) {
+	components, ok := m["dragonflyComponents"].(map[string]any)
+	if !ok || len(components) == 0 {
+		return
+	}
+	s.components = make(map[string]any, len(components))
+	for k, v := range components {
+		if c, ok := v.(map[string]any); ok {
+			s.components[k] = decodeComponent(k, c["value"], int(nbtInt32(c, "version")))
+		}
+	}
+}
+
 // readUnbreakable reads the unbreakable value stored in the NBT with the Unbreakable tag and saves it to the Stack
 // passed.
 func readUnbreakable(m map[string]any, s *Stack) {
@@ -37,5 +37,36 @@ func (p Potion) Consume(_ *world.Tx, c Consumer) Stack {
 
 // EncodeItem ...
 func (p Potion) EncodeItem() (name string, meta int16) {
-	return "minecraft:potion", int16(p.Type.Uint8())
+	return "minecraft:potion", int16(p.Type.Base().Uint8())
+}
+
+// DecodeNBT ...
+func (p Potion) DecodeNBT(data map[string]any) any {
+	p.Type = potionFromNBT(data, p.Type)
+	return p
+}
+
+// EncodeNBT ...
+func (p Potion) EncodeNBT() map[string]any {
+	return potionToNBT(p.Type)
+}
+
+// potionFromNBT returns the custom potion type stored in the NBT passed. If the NBT does not hold a custom
+// potion type, the potion passed is returned.
+func potionFromNBT(data map[string]any, p potion.Potion) potion.Potion {
+	if name, ok := data["CustomPotionType"].(string); ok {
+		if t, ok := potion.ByName(name); ok {
+			return t
+		}
+	}
+	return p
+}
+
+// potionToNBT encodes the potion type passed to NBT if it is a custom potion type. Vanilla potion types are
+// stored in the metadata value of items, so nil is returned for them.
+func potionToNBT(p potion.Potion) map[string]any {
+	if c, ok := p.Custom(); ok {
+		return map[string]any{"CustomPotionType": c.Name}
+	}
+	return nil
 }
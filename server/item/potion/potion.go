@@ -2,6 +2,10 @@ package potion
 
 import (
 	"github.com/df-mc/dragonfly/server/entity/effect"
+	"image/color"
+	"math"
+	"slices"
+	"sync"
 	"time"
 )
 
@@ -232,6 +236,9 @@ func From(id int32) Potion {
 
 // Effects returns the effects of the potion.
 func (p Potion) Effects() []effect.Effect {
+	if c, ok := p.Custom(); ok {
+		return slices.Clone(c.Effects)
+	}
 	switch p {
 	case NightVision():
 		return []effect.Effect{effect.New(effect.NightVision, 1, 3*time.Minute)}
@@ -341,3 +348,103 @@ func All() []Potion {
 		SlowFalling(), LongSlowFalling(), StrongSlowness(),
 	}
 }
+
+// Custom holds the properties of a custom potion type registered using Register.
+type Custom struct {
+	// Name is the unique name of the potion type, such as "example:haste". It is used to save the potion type
+	// in item and entity data, as the ID of a custom potion type may change between restarts.
+	Name string
+	// Effects are the effects given by the potion.
+	Effects []effect.Effect
+	// Colour is the colour of the potion and its particles. If left empty, the colour is calculated from the
+	// Effects of the potion.
+	Colour color.RGBA
+	// Base is the vanilla potion that the client displays the potion as. The client has no knowledge of custom
+	// potion types, so the name and icon of the potion item are those of the Base.
+	Base Potion
+}
+
+var (
+	customMu sync.RWMutex
+	// customs holds all custom potion types registered, indexed by their ID.
+	customs = map[potion]Custom{}
+	// customNames maps the name of each custom potion type to the potion.
+	customNames = map[string]Potion{}
+)
+
+// Register registers a custom potion type and returns the Potion that represents it. Registering a potion
+// type with the same name as a custom potion type registered earlier replaces its properties. Brewing
+// recipes for the potion may be registered using recipe.NewPotion.
+func Register(c Custom) Potion {
+	customMu.Lock()
+	defer customMu.Unlock()
+
+	if p, ok := customNames[c.Name]; ok {
+		customs[p.potion] = c
+		return p
+	}
+	// IDs of custom potion types that were unregistered are reused.
+	id := len(All())
+	for ; id <= math.MaxUint8; id++ {
+		if _, ok := customs[potion(id)]; !ok {
+			break
+		}
+	}
+	if id > math.MaxUint8 {
+		panic("potion: too many custom potion types registered")
+	}
+	p := Potion{potion(id)}
+	customs[p.potion], customNames[c.Name] = c, p
+	return p
+}
+
+// Unregister removes the custom potion type with the name passed, so that its ID may be reused by potion
+// types registered later. Potions of the type that still exist are no longer custom and should not be used
+// anymore. False is returned if no custom potion type with the name was registered.
+func Unregister(name string) bool {
+	customMu.Lock()
+	defer customMu.Unlock()
+
+	p, ok := customNames[name]
+	if !ok {
+		return false
+	}
+	delete(customNames, name)
+	delete(customs, p.potion)
+	return true
+}
+
+// ByName returns the custom potion type registered with the name passed. False is returned if no custom
+// potion type with the name was registered.
+func ByName(name string) (Potion, bool) {
+	customMu.RLock()
+	defer customMu.RUnlock()
+	p, ok := customNames[name]
+	return p, ok
+}
+
+// Custom returns the properties of the potion if it is a custom potion type registered using Register.
+func (p Potion) Custom() (Custom, bool) {
+	customMu.RLock()
+	defer customMu.RUnlock()
+	c, ok := customs[p.potion]
+	return c, ok
+}
+
+// Base returns the vanilla potion that the client displays the potion as. For vanilla potions, the potion
+// itself is returned.
+func (p Potion) Base() Potion {
+	if c, ok := p.Custom(); ok {
+		return c.Base.Base()
+	}
+	return p
+}
+
+// Colour returns the colour of the potion and its particles.
+func (p Potion) Colour() color.RGBA {
+	if c, ok := p.Custom(); ok && c.Colour != (color.RGBA{}) {
+		return c.Colour
+	}
+	colour, _ := effect.ResultingColour(p.Effects())
+	return colour
+}
@@ -0,0 +1,37 @@
+package potion
+
+import (
+	"testing"
+)
+
+func TestUnregister(t *testing.T) {
+	a := Register(Custom{Name: "test:a", Base: Swiftness()})
+	b := Register(Custom{Name: "test:b", Base: Swiftness()})
+	t.Cleanup(func() { Unregister("test:b") })
+
+	if !Unregister("test:a") {
+		t.Fatalf("expected test:a to be unregistered")
+	}
+	if Unregister("test:a") {
+		t.Fatalf("expected test:a to be unregistered only once")
+	}
+	if _, ok := ByName("test:a"); ok {
+		t.Fatalf("expected test:a not to be found after unregistering it")
+	}
+	if _, ok := a.Custom(); ok {
+		t.Fatalf("expected potion of test:a not to be custom after unregistering it")
+	}
+	if _, ok := b.Custom(); !ok {
+		t.Fatalf("expected test:b to be kept")
+	}
+
+	// The ID of an unregistered potion type is reused, without taking the ID of a registered potion type.
+	c := Register(Custom{Name: "test:c", Base: Swiftness()})
+	t.Cleanup(func() { Unregister("test:c") })
+	if c != a {
+		t.Fatalf("expected ID %v of test:a to be reused, got %v", a.Uint8(), c.Uint8())
+	}
+	if custom, _ := b.Custom(); custom.Name != "test:b" {
+		t.Fatalf("expected test:b to keep its ID, got %v", custom.Name)
+	}
+}
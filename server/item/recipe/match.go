@@ -0,0 +1,167 @@
+package recipe
+
+import (
+	"fmt"
+
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// MatchItem checks if the item has matches the expected item of a recipe in a crafting scenario. Either item
+// may be an item.Stack or an ItemTag. The counts of the items are not compared.
+func MatchItem(has, expected Item) bool {
+	switch expected := expected.(type) {
+	case item.Stack:
+		switch has := has.(type) {
+		case ItemTag:
+			name, _ := expected.Item().EncodeItem()
+			return has.Contains(name)
+		case item.Stack:
+			_, variants := expected.Value("variants")
+			if !variants {
+				return has.Comparable(expected)
+			}
+			nameOne, _ := has.Item().EncodeItem()
+			nameTwo, _ := expected.Item().EncodeItem()
+			return nameOne == nameTwo
+		}
+		panic(fmt.Errorf("unexpected recipe item %T", has))
+	case ItemTag:
+		switch has := has.(type) {
+		case item.Stack:
+			name, _ := has.Item().EncodeItem()
+			return expected.Contains(name)
+		case ItemTag:
+			return has.Tag() == expected.Tag()
+		}
+		panic(fmt.Errorf("unexpected recipe item %T", has))
+	}
+	panic(fmt.Errorf("tried to match with unexpected recipe item %T", expected))
+}
+
+// Match checks if the items in a crafting grid with the width and height passed match the shaped recipe. The
+// grid holds the items of the grid row by row, with empty stacks for empty slots. The shape of the recipe may
+// be positioned anywhere in the grid and may be mirrored horizontally, as long as all other slots are empty.
+func (r Shaped) Match(grid []item.Stack, width, height int) bool {
+	w, h := r.shape.Width(), r.shape.Height()
+	if w > width || h > height || len(grid) != width*height {
+		return false
+	}
+	for offsetY := 0; offsetY <= height-h; offsetY++ {
+		for offsetX := 0; offsetX <= width-w; offsetX++ {
+			if r.matchAt(grid, width, height, offsetX, offsetY, false) || r.matchAt(grid, width, height, offsetX, offsetY, true) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matchAt checks if the grid matches the shaped recipe with the top left corner of the shape positioned at
+// the offset passed, optionally mirroring the shape horizontally.
+func (r Shaped) matchAt(grid []item.Stack, width, height, offsetX, offsetY int, mirror bool) bool {
+	w, h := r.shape.Width(), r.shape.Height()
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			has := grid[y*width+x]
+			rx, ry := x-offsetX, y-offsetY
+			if rx < 0 || ry < 0 || rx >= w || ry >= h {
+				if !has.Empty() {
+					return false
+				}
+				continue
+			}
+			if mirror {
+				rx = w - 1 - rx
+			}
+			expected := r.input[ry*w+rx]
+			if has.Empty() || expected.Empty() {
+				if has.Empty() != expected.Empty() {
+					return false
+				}
+				continue
+			}
+			if has.Count() < expected.Count() || !MatchItem(has, expected) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Match checks if the items in a crafting grid match the shapeless recipe. The position of the items in the
+// grid does not matter, but every item must be used by the recipe and every input of the recipe must be
+// present.
+func (r Shapeless) Match(grid []item.Stack) bool {
+	consumed := make([]bool, len(grid))
+	inputs := 0
+	for _, expected := range r.input {
+		if expected.Empty() {
+			continue
+		}
+		inputs++
+		var processed bool
+		for slot, has := range grid {
+			if consumed[slot] || has.Empty() || has.Count() < expected.Count() || !MatchItem(has, expected) {
+				continue
+			}
+			processed, consumed[slot] = true, true
+			break
+		}
+		if !processed {
+			return false
+		}
+	}
+	for _, has := range grid {
+		if !has.Empty() {
+			inputs--
+		}
+	}
+	return inputs == 0
+}
+
+// Craft finds a shaped, shapeless or dynamic recipe crafted on the block passed that matches the items in a
+// crafting grid with the width and height passed, and returns the output of the recipe. The grid holds the
+// items of the grid row by row, with empty stacks for empty slots. If multiple recipes match, the recipe with
+// the lowest priority is used. False is returned if no recipe matches the grid.
+func Craft(block string, grid []item.Stack, width, height int) (output []item.Stack, ok bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+
+	var (
+		match    Recipe
+		priority uint32
+	)
+	for _, r := range recipes {
+		if r.Block() != block || (match != nil && r.Priority() >= priority) {
+			continue
+		}
+		switch r := r.(type) {
+		case Shaped:
+			ok = r.Match(grid, width, height)
+		case Shapeless:
+			ok = r.Match(grid)
+		default:
+			ok = false
+		}
+		if ok {
+			match, priority = r, r.Priority()
+		}
+	}
+	if match != nil {
+		return match.Output(), true
+	}
+
+	input := make([]Item, len(grid))
+	for i, it := range grid {
+		input[i] = it
+	}
+	for _, r := range dynamicRecipes {
+		if r.Block() != block {
+			continue
+		}
+		if output, ok := r.Match(input); ok {
+			return output, true
+		}
+	}
+	return nil, false
+}
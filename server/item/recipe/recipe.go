@@ -140,6 +140,31 @@ func NewPotion(input, reagent Item, output item.Stack) Potion {
 	}}
 }
 
+// Furnace is a smelting recipe that turns a single input item into an output item, crafted in a furnace,
+// blast furnace, smoker or on a campfire depending on its block. Vanilla furnace recipes are registered from
+// the item.SmeltInfo of all items, and may be overridden by registering another recipe with the same input
+// and block.
+type Furnace struct {
+	recipe
+	// experience is the experience that is gained for every output item smelted.
+	experience float64
+}
+
+// NewFurnace creates a new furnace recipe and returns it. The block must be one of "furnace", "blast_furnace",
+// "smoker", "campfire" or "soul_campfire". The experience passed is gained for every output item smelted.
+func NewFurnace(input Item, output item.Stack, block string, experience float64) Furnace {
+	return Furnace{experience: experience, recipe: recipe{
+		input:  []Item{input},
+		output: []item.Stack{output},
+		block:  block,
+	}}
+}
+
+// Experience returns the experience gained for every output item smelted using the recipe.
+func (r Furnace) Experience() float64 {
+	return r.experience
+}
+
 // Shaped is a recipe that has a specific shape that must be used to craft the output of the recipe.
 type Shaped struct {
 	recipe
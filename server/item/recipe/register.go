@@ -1,17 +1,24 @@
 package recipe
 
 import (
+	"fmt"
 	"github.com/df-mc/dragonfly/server/internal/sliceutil"
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/world"
+	"hash/fnv"
+	"io"
 	"slices"
 	"sort"
+	"strconv"
 	"strings"
+	"sync"
 	"unsafe"
 )
 
-// recipes is a list of each recipe.
 var (
+	// mu guards all recipes registered and the caches of lookups performed on them.
+	mu sync.RWMutex
+	// recipes is a list of each recipe.
 	recipes []Recipe
 	// dynamicRecipes is a list of each dynamic recipe.
 	dynamicRecipes []DynamicRecipe
@@ -19,21 +26,46 @@ var (
 	index = make(map[string]map[string]Recipe)
 	// reagent maps the item name and an item.Stack.
 	reagent = make(map[string]item.Stack)
+	// names maps the name of each recipe to the recipe.
+	names = make(map[string]Recipe)
+	// ingredients caches the names of the recipes that use an item as input, indexed by the name and metadata
+	// of the item.
+	ingredients = make(map[string][]string)
+	// smelts caches the furnace recipe used to smelt an item, indexed by the block and the name and metadata
+	// of the item. Items that cannot be smelted are cached with a false value.
+	smelts = make(map[string]smelt)
 )
 
+// smelt is a cached result of Smelt.
+type smelt struct {
+	recipe Furnace
+	ok     bool
+}
+
 // Recipes returns each recipe in a slice.
 func Recipes() []Recipe {
+	mu.RLock()
+	defer mu.RUnlock()
 	return slices.Clone(recipes)
 }
 
 // DynamicRecipes returns each dynamic recipe in a slice.
 func DynamicRecipes() []DynamicRecipe {
+	mu.RLock()
+	defer mu.RUnlock()
 	return slices.Clone(dynamicRecipes)
 }
 
-// Register registers a new recipe.
+// Register registers a new recipe. The recipe may be looked up by its name, as returned by Name, using
+// ByName.
 func Register(recipe Recipe) {
+	mu.Lock()
+	defer mu.Unlock()
+
 	recipes = append(recipes, recipe)
+	names[Name(recipe)] = recipe
+	clear(ingredients)
+	clear(smelts)
 
 	_, ok := recipe.(PotionContainerChange)
 	p, okTwo := recipe.(Potion)
@@ -61,9 +93,174 @@ func Register(recipe Recipe) {
 	}
 }
 
+// Unregister removes the recipe with the name passed, as returned by Name, so that it can no longer be crafted.
+// Recipes with the same type, inputs, outputs and block share a name and are all removed. Players that are
+// already connected keep seeing the recipe until they reconnect. False is returned if no recipe with the name
+// was registered.
+func Unregister(name string) bool {
+	mu.Lock()
+	defer mu.Unlock()
+
+	if _, ok := names[name]; !ok {
+		return false
+	}
+	delete(names, name)
+	recipes = slices.DeleteFunc(recipes, func(r Recipe) bool {
+		return Name(r) == name
+	})
+	for _, blockInd := range index {
+		for hash, r := range blockInd {
+			if Name(r) == name {
+				delete(blockInd, hash)
+			}
+		}
+	}
+	// Reagents are only kept if a potion recipe that is still registered uses them.
+	clear(reagent)
+	for _, r := range recipes {
+		if p, ok := r.(Potion); ok {
+			stack := p.Input()[1].(item.Stack)
+			name, _ := stack.Item().EncodeItem()
+			reagent[name] = stack
+		}
+	}
+	clear(ingredients)
+	clear(smelts)
+	return true
+}
+
+// Smelt returns the furnace recipe crafted on the block passed that smelts the input passed. If multiple
+// recipes match the input, the recipe registered last is used, so that vanilla recipes may be overridden by
+// registering a new recipe. False is returned if the input cannot be smelted on the block.
+func Smelt(block string, input item.Stack) (Furnace, bool) {
+	if input.Empty() {
+		return Furnace{}, false
+	}
+	name, meta := input.Item().EncodeItem()
+	key := block + ";" + name + ":" + strconv.Itoa(int(meta))
+	mu.RLock()
+	cached, ok := smelts[key]
+	mu.RUnlock()
+	if ok {
+		return cached.recipe, cached.ok
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// Only the item type matters for smelting, so custom names, enchantments and other data should not stop
+	// the item from being smelted.
+	input = item.NewStack(input.Item(), 1)
+	for _, r := range slices.Backward(recipes) {
+		if f, ok := r.(Furnace); ok && f.Block() == block && MatchItem(input, f.Input()[0]) {
+			smelts[key] = smelt{recipe: f, ok: true}
+			return f, true
+		}
+	}
+	smelts[key] = smelt{}
+	return Furnace{}, false
+}
+
+// ByName returns the recipe registered with the name passed, as returned by Name. If no recipe with the name
+// exists, false is returned.
+func ByName(name string) (Recipe, bool) {
+	mu.RLock()
+	defer mu.RUnlock()
+	r, ok := names[name]
+	return r, ok
+}
+
+// ByIngredient returns the names of all recipes that have the item passed as one of their inputs. Players
+// unlock these recipes when they obtain the item. Only crafting and smithing recipes are returned, as other
+// recipes, such as furnace recipes, are not shown in the recipe book.
+func ByIngredient(it item.Stack) []string {
+	if it.Empty() {
+		return nil
+	}
+	name, meta := it.Item().EncodeItem()
+	key := name + ":" + strconv.Itoa(int(meta))
+	mu.RLock()
+	cached, ok := ingredients[key]
+	mu.RUnlock()
+	if ok {
+		return cached
+	}
+
+	mu.Lock()
+	defer mu.Unlock()
+	// Only the item type matters for unlocking recipes, so custom names, enchantments and other data should
+	// not stop the item from matching.
+	it = item.NewStack(it.Item(), 1)
+
+	var matches []string
+	for _, r := range recipes {
+		switch r.(type) {
+		case Shaped, Shapeless, UserDataShapeless, SmithingTransform, SmithingTrim:
+		default:
+			continue
+		}
+		if slices.ContainsFunc(r.Input(), func(expected Item) bool {
+			return !expected.Empty() && MatchItem(it, expected)
+		}) {
+			matches = append(matches, Name(r))
+		}
+	}
+	ingredients[key] = matches
+	return matches
+}
+
+// Name returns the name of the recipe passed, which is used to identify the recipe to clients and to unlock
+// it for players. The name is derived from the type, inputs, outputs and block of the recipe, so that it stays
+// the same between restarts and does not depend on the order in which recipes are registered. It starts with
+// the name of the output of the recipe, followed by the block if the recipe is not crafted on a crafting
+// table. Multi recipes are named after their UUID.
+func Name(r Recipe) string {
+	if m, ok := r.(Multi); ok {
+		return m.UUID().String()
+	}
+	base := "minecraft:" + r.Block()
+	if out := r.Output(); len(out) > 0 && !out[0].Empty() {
+		base, _ = out[0].Item().EncodeItem()
+		if r.Block() != "crafting_table" {
+			base += "_" + r.Block()
+		}
+	}
+	h := fnv.New32a()
+	_, _ = fmt.Fprintf(h, "%T;%v;", r, r.Block())
+	if s, ok := r.(Shaped); ok {
+		_, _ = fmt.Fprintf(h, "%vx%v;", s.Shape().Width(), s.Shape().Height())
+	}
+	for _, i := range r.Input() {
+		writeItem(h, i)
+	}
+	_, _ = io.WriteString(h, ";")
+	for _, i := range r.Output() {
+		writeItem(h, i)
+	}
+	return fmt.Sprintf("%v_%08x", base, h.Sum32())
+}
+
+// writeItem writes the type and count of a recipe Item to w, for use in the name of a recipe.
+func writeItem(w io.Writer, i Item) {
+	switch i := i.(type) {
+	case item.Stack:
+		if i.Empty() {
+			_, _ = io.WriteString(w, "air,")
+			return
+		}
+		name, meta := i.Item().EncodeItem()
+		_, _ = fmt.Fprintf(w, "%v:%v*%v,", name, meta, i.Count())
+	case ItemTag:
+		_, _ = fmt.Fprintf(w, "#%v*%v,", i.Tag(), i.Count())
+	default:
+		_, _ = fmt.Fprintf(w, "%T*%v,", i, i.Count())
+	}
+}
+
 // Perform performs the recipe with the given block and inputs and returns the outputs. If the inputs do not map to
 // any outputs, false is returned for the second return value.
 func Perform(block string, input ...world.Item) (output []item.Stack, ok bool) {
+	mu.RLock()
+	defer mu.RUnlock()
 	blockInd, ok := index[block]
 	if !ok {
 		// Block specific index didn't exist.
@@ -82,6 +279,12 @@ func Perform(block string, input ...world.Item) (output []item.Stack, ok bool) {
 			name, _ := it.Item().EncodeItem()
 			_, meta := input[ind].EncodeItem()
 			if i, ok := world.ItemByName(name, meta); ok {
+				// Carry over data stored in NBT, such as custom potion types, to the output.
+				from, okOne := input[ind].(world.NBTer)
+				to, okTwo := i.(world.NBTer)
+				if okOne && okTwo {
+					i = to.DecodeNBT(from.EncodeNBT()).(world.Item)
+				}
 				it = item.NewStack(i, it.Count())
 			}
 		}
@@ -111,6 +314,13 @@ func hashItems(items []world.Item, useMeta bool) string {
 		if useMeta {
 			a := *(*[2]byte)(unsafe.Pointer(&meta))
 			b.Write(a[:])
+			// Items such as custom potions share their metadata value with vanilla items, so their NBT is
+			// needed to tell them apart.
+			if nbter, ok := it.(world.NBTer); ok {
+				if data := nbter.EncodeNBT(); len(data) > 0 {
+					_, _ = fmt.Fprint(&b, data)
+				}
+			}
 		}
 	}
 	return b.String()
@@ -119,6 +329,8 @@ func hashItems(items []world.Item, useMeta bool) string {
 // ValidBrewingReagent checks if the world.Item is a brewing reagent.
 func ValidBrewingReagent(i world.Item) bool {
 	name, _ := i.EncodeItem()
+	mu.RLock()
+	defer mu.RUnlock()
 	_, exists := reagent[name]
 	return exists
 }
@@ -126,5 +338,7 @@ func ValidBrewingReagent(i world.Item) bool {
 // RegisterDynamic registers a new dynamic recipe. Dynamic recipes are not sent to the client
 // and are validated server-side.
 func RegisterDynamic(recipe DynamicRecipe) {
+	mu.Lock()
+	defer mu.Unlock()
 	dynamicRecipes = append(dynamicRecipes, recipe)
 }
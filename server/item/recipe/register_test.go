@@ -0,0 +1,136 @@
+package recipe
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/potion"
+)
+
+func TestNameStable(t *testing.T) {
+	stick, diamond := item.NewStack(item.Stick{}, 1), item.NewStack(item.Diamond{}, 1)
+	shaped := func() Recipe {
+		return NewShaped([]Item{diamond, diamond}, item.NewStack(item.Stick{}, 4), NewShape(1, 2), "crafting_table")
+	}
+	tests := []struct {
+		name   string
+		recipe Recipe
+		want   string
+	}{
+		// Players unlock recipes by name, so the name of a recipe must not change between versions.
+		{name: "shaped", recipe: shaped(), want: "minecraft:stick_7ed2cd5f"},
+		{name: "shapeless", recipe: NewShapeless([]Item{stick, stick}, item.NewStack(item.Diamond{}, 1), "crafting_table")},
+		{name: "furnace", recipe: NewFurnace(stick, item.NewStack(item.Charcoal{}, 1), "furnace", 0.1)},
+		{name: "potion", recipe: NewPotion(item.NewStack(item.Potion{Type: potion.Awkward()}, 1), item.NewStack(item.Sugar{}, 1), item.NewStack(item.Potion{Type: potion.Swiftness()}, 1))},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			name := Name(test.recipe)
+			if name != Name(test.recipe) {
+				t.Fatalf("expected name of recipe to be the same every time")
+			}
+			if test.want != "" && name != test.want {
+				t.Fatalf("expected name %v, got %v", test.want, name)
+			}
+		})
+	}
+
+	// Names are derived from the recipe only, so registering other recipes does not change them.
+	before := Name(shaped())
+	for _, test := range tests[1:] {
+		Register(test.recipe)
+		t.Cleanup(func() { Unregister(Name(test.recipe)) })
+	}
+	if after := Name(shaped()); after != before {
+		t.Fatalf("expected name %v to stay the same after registering recipes, got %v", before, after)
+	}
+
+	// Recipes that differ in their type, inputs, outputs or block have different names.
+	different := []Recipe{
+		shaped(),
+		NewShaped([]Item{diamond, diamond}, item.NewStack(item.Stick{}, 4), NewShape(2, 1), "crafting_table"),
+		NewShaped([]Item{diamond, diamond}, item.NewStack(item.Stick{}, 3), NewShape(1, 2), "crafting_table"),
+		NewShaped([]Item{diamond, stick}, item.NewStack(item.Stick{}, 4), NewShape(1, 2), "crafting_table"),
+		NewShaped([]Item{diamond, diamond}, item.NewStack(item.Stick{}, 4), NewShape(1, 2), "stonecutter"),
+		NewShapeless([]Item{diamond, diamond}, item.NewStack(item.Stick{}, 4), "crafting_table"),
+	}
+	seen := make(map[string]Recipe)
+	for _, r := range different {
+		if other, ok := seen[Name(r)]; ok {
+			t.Fatalf("expected recipes %#v and %#v to have different names, both got %v", r, other, Name(r))
+		}
+		seen[Name(r)] = r
+	}
+}
+
+func TestUnregister(t *testing.T) {
+	stick := item.NewStack(item.Stick{}, 1)
+	shaped := NewShaped([]Item{stick, stick}, item.NewStack(item.Diamond{}, 1), NewShape(1, 2), "crafting_table")
+	furnace := NewFurnace(stick, item.NewStack(item.Charcoal{}, 1), "furnace", 0.1)
+	brewing := NewPotion(item.NewStack(item.Potion{Type: potion.Awkward()}, 1), item.NewStack(item.Sugar{}, 1), item.NewStack(item.Potion{Type: potion.Swiftness()}, 1))
+	// other shares its ingredient with the recipes unregistered, and must be kept.
+	other := NewShapeless([]Item{stick}, item.NewStack(item.Coal{}, 1), "crafting_table")
+	for _, r := range []Recipe{shaped, furnace, brewing, other} {
+		Register(r)
+		t.Cleanup(func() { Unregister(Name(r)) })
+	}
+
+	// Fill the caches of lookups before unregistering.
+	if names := ByIngredient(stick); !slices.Contains(names, Name(shaped)) {
+		t.Fatalf("expected %v to be found by its ingredient, got %v", Name(shaped), names)
+	}
+	if _, ok := Smelt("furnace", stick); !ok {
+		t.Fatalf("expected stick to be smeltable")
+	}
+	if !ValidBrewingReagent(item.Sugar{}) {
+		t.Fatalf("expected sugar to be a brewing reagent")
+	}
+	if _, ok := Perform("brewing_stand", item.Potion{Type: potion.Awkward()}, item.Sugar{}); !ok {
+		t.Fatalf("expected potion recipe to be performed")
+	}
+
+	for _, r := range []Recipe{shaped, furnace, brewing} {
+		name := Name(r)
+		if !Unregister(name) {
+			t.Fatalf("expected %v to be unregistered", name)
+		}
+		if Unregister(name) {
+			t.Fatalf("expected %v to be unregistered only once", name)
+		}
+		mu.RLock()
+		if slices.ContainsFunc(recipes, func(r Recipe) bool { return Name(r) == name }) {
+			t.Errorf("expected %v to be removed from recipes", name)
+		}
+		if _, ok := names[name]; ok {
+			t.Errorf("expected %v to be removed from names", name)
+		}
+		for block, blockInd := range index {
+			for hash, r := range blockInd {
+				if Name(r) == name {
+					t.Errorf("expected %v to be removed from index of %v at %q", name, block, hash)
+				}
+			}
+		}
+		if len(ingredients) != 0 || len(smelts) != 0 {
+			t.Errorf("expected caches to be cleared after unregistering %v, got %v and %v", name, ingredients, smelts)
+		}
+		mu.RUnlock()
+	}
+
+	if names := ByIngredient(stick); !slices.Equal(names, []string{Name(other)}) {
+		t.Fatalf("expected only %v to be found by its ingredient, got %v", Name(other), names)
+	}
+	if _, ok := Smelt("furnace", stick); ok {
+		t.Fatalf("expected stick not to be smeltable after unregistering furnace recipe")
+	}
+	if ValidBrewingReagent(item.Sugar{}) {
+		t.Fatalf("expected sugar not to be a brewing reagent after unregistering potion recipe")
+	}
+	if _, ok := Perform("brewing_stand", item.Potion{Type: potion.Awkward()}, item.Sugar{}); ok {
+		t.Fatalf("expected potion recipe not to be performed after unregistering it")
+	}
+	if _, ok := ByName(Name(other)); !ok {
+		t.Fatalf("expected %v to be kept", Name(other))
+	}
+}
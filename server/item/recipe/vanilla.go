@@ -202,6 +202,27 @@ func registerVanilla() {
 		}})
 	}
 
+	for _, it := range world.Items() {
+		smeltable, ok := it.(item.Smeltable)
+		if !ok {
+			continue
+		}
+		info := smeltable.SmeltInfo()
+		if info.Product.Empty() {
+			continue
+		}
+		blocks := []string{"furnace"}
+		if info.Food {
+			blocks = append(blocks, "smoker", "campfire", "soul_campfire")
+		}
+		if info.Ores {
+			blocks = append(blocks, "blast_furnace")
+		}
+		for _, block := range blocks {
+			Register(NewFurnace(item.NewStack(it, 1), info.Product, block, info.Experience))
+		}
+	}
+
 	// Register dynamic recipes
 	RegisterDynamic(NewDecoratedPotRecipe())
 }
@@ -28,6 +28,7 @@ func init() {
 	world.RegisterItem(Bread{})
 	world.RegisterItem(Brick{})
 	world.RegisterItem(Bucket{})
+	world.RegisterItem(Bundle{})
 	world.RegisterItem(CarrotOnAStick{})
 	world.RegisterItem(Charcoal{})
 	world.RegisterItem(Chicken{Cooked: true})
@@ -141,6 +142,7 @@ func init() {
 		world.RegisterItem(BannerPattern{Type: pattern})
 	}
 	for _, c := range Colours() {
+		world.RegisterItem(Bundle{Colour: c, Dyed: true})
 		world.RegisterItem(Dye{Colour: c})
 		world.RegisterItem(FireworkStar{FireworkExplosion: FireworkExplosion{Colour: c}})
 	}
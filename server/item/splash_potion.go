@@ -45,5 +45,16 @@ func throwableOffset(r cube.Rotation) cube.Rotation {
 
 // EncodeItem ...
 func (s SplashPotion) EncodeItem() (name string, meta int16) {
-	return "minecraft:splash_potion", int16(s.Type.Uint8())
+	return "minecraft:splash_potion", int16(s.Type.Base().Uint8())
+}
+
+// DecodeNBT ...
+func (s SplashPotion) DecodeNBT(data map[string]any) any {
+	s.Type = potionFromNBT(data, s.Type)
+	return s
+}
+
+// EncodeNBT ...
+func (s SplashPotion) EncodeNBT() map[string]any {
+	return potionToNBT(s.Type)
 }
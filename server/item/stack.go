@@ -28,7 +28,8 @@ type Stack struct {
 
 	anvilCost int
 
-	data map[string]any
+	data       map[string]any
+	components map[string]any
 
 	enchantments map[EnchantmentType]Enchantment
 }
@@ -375,6 +376,7 @@ func (s Stack) WithItem(t world.Item) Stack {
 		WithAnvilCost(s.anvilCost)
 	cp.unbreakable = s.unbreakable && s.MaxDurability() != -1
 	cp.data = s.data
+	cp.components = s.components
 	return cp
 }
 
@@ -409,7 +411,8 @@ func (s Stack) Equal(s2 Stack) bool {
 }
 
 // Comparable checks if two stacks can be considered comparable. True is returned if the two stacks have an
-// equal item type and have equal enchantments, lore and custom names, or if one of the stacks is empty.
+// equal item type and have equal enchantments, lore, custom names and components, or if one of the stacks is
+// empty.
 // Comparable does not check if the two stacks have the same durability.
 func (s Stack) Comparable(s2 Stack) bool {
 	if s.Empty() || s2.Empty() {
@@ -432,7 +435,7 @@ func (s Stack) Comparable(s2 Stack) bool {
 			return false
 		}
 	}
-	if !reflect.DeepEqual(s.data, s2.data) {
+	if !reflect.DeepEqual(s.data, s2.data) || !componentsEqual(s.components, s2.components) {
 		return false
 	}
 	if nbt, ok := s.Item().(world.NBTer); ok {
@@ -486,6 +489,16 @@ func id(s Stack) int32 {
 	return s.id
 }
 
+// withItem returns the stack passed with its item type replaced by the item passed. Unlike Stack.WithItem, the
+// unique ID of the stack is kept.
+// noinspection GoUnusedFunction
+//
+//lint:ignore U1000 Function is used through compiler directives.
+func withItem(s Stack, t world.Item) Stack {
+	s.item = t
+	return s
+}
+
 // format is a utility function to format a list of Values to have spaces between them, but no newline at the
 // end, which is typically used for sending messages, popups and tips.
 func format(a []any) string {
@@ -5,9 +5,13 @@ import (
 	"fmt"
 	"io"
 	"log/slog"
+	"net"
+	"sync"
+	"sync/atomic"
 
 	"github.com/df-mc/dragonfly/server/session"
 	"github.com/sandertv/gophertunnel/minecraft"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
 
 // Listener is a source for connections that may be listened on by a Server using Server.listen. Proxies can use this to
@@ -24,6 +28,7 @@ type Listener interface {
 // listenerFunc may be used to return a *minecraft.Listener using a Config. It
 // is the standard listener used when UserConfig.Config() is called.
 func (uc UserConfig) listenerFunc(conf Config) (Listener, error) {
+	l := &listener{}
 	cfg := minecraft.ListenConfig{
 		MaximumPlayers:         conf.MaxPlayers,
 		StatusProvider:         conf.StatusProvider,
@@ -36,31 +41,96 @@ func (uc UserConfig) listenerFunc(conf Config) (Listener, error) {
 	if conf.Log.Enabled(context.Background(), slog.LevelDebug) {
 		cfg.ErrorLog = conf.Log.With("net origin", "gophertunnel")
 	}
-	l, err := cfg.Listen("raknet", uc.Network.Address)
+	if conf.Metrics != nil {
+		cfg.PacketFunc = l.countPacket
+	}
+	ml, err := cfg.Listen("raknet", uc.Network.Address)
 	if err != nil {
 		return nil, fmt.Errorf("create minecraft listener: %w", err)
 	}
+	l.Listener = ml
 	conf.Log.Info("Listener running.", "addr", l.Addr())
-	return listener{l}, nil
+	return l, nil
 }
 
 // listener is a Listener implementation that wraps around a minecraft.Listener so that it can be listened on by
 // Server.
 type listener struct {
 	*minecraft.Listener
+	// conns holds the connections returned by Accept that are still open,
+	// keyed by their remote address.
+	conns sync.Map
 }
 
 // Accept blocks until the next connection is established and returns it. An error is returned if the Listener was
 // closed using Close.
-func (l listener) Accept() (session.Conn, error) {
-	conn, err := l.Listener.Accept()
+func (l *listener) Accept() (session.Conn, error) {
+	c, err := l.Listener.Accept()
 	if err != nil {
 		return nil, err
 	}
-	return conn.(session.Conn), err
+	mc := &conn{Conn: c.(*minecraft.Conn), l: l}
+	l.conns.Store(mc.RemoteAddr().String(), mc)
+	return mc, nil
 }
 
 // Disconnect disconnects a connection from the Listener with a reason.
-func (l listener) Disconnect(conn session.Conn, reason string) error {
-	return l.Listener.Disconnect(conn.(*minecraft.Conn), reason)
+func (l *listener) Disconnect(c session.Conn, reason string) error {
+	mc := c.(*conn)
+	l.conns.Delete(mc.RemoteAddr().String())
+	return l.Listener.Disconnect(mc.Conn, reason)
+}
+
+// countPacket is used as minecraft.ListenConfig.PacketFunc. It passes the
+// encoded size of every packet read from or written to a connection returned
+// by Accept to the function set using conn.CountBytes.
+func (l *listener) countPacket(header packet.Header, payload []byte, src, dst net.Addr) {
+	sent := true
+	v, ok := l.conns.Load(dst.String())
+	if !ok {
+		if v, ok = l.conns.Load(src.String()); !ok {
+			// The packet was sent during login, before the connection was
+			// returned by Accept.
+			return
+		}
+		sent = false
+	}
+	if f := v.(*conn).count.Load(); f != nil {
+		w := &countingByteWriter{}
+		_ = header.Write(w)
+		(*f)(w.n+len(payload), sent)
+	}
+}
+
+// conn is a session.Conn that wraps around a *minecraft.Conn returned by a
+// listener, so that the bytes of the packets read from and written to it can
+// be counted.
+type conn struct {
+	*minecraft.Conn
+	l     *listener
+	count atomic.Pointer[func(n int, sent bool)]
+}
+
+// CountBytes makes the conn call f with the encoded size of every packet read
+// from it (sent is false) or written to it (sent is true).
+func (c *conn) CountBytes(f func(n int, sent bool)) {
+	c.count.Store(&f)
+}
+
+// Close closes the conn and stops counting the bytes of its packets.
+func (c *conn) Close() error {
+	c.l.conns.Delete(c.RemoteAddr().String())
+	return c.Conn.Close()
+}
+
+// countingByteWriter is an io.ByteWriter that discards all data written to
+// it, counting the number of bytes.
+type countingByteWriter struct {
+	n int
+}
+
+// WriteByte ...
+func (w *countingByteWriter) WriteByte(byte) error {
+	w.n++
+	return nil
 }
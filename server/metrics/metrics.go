@@ -0,0 +1,272 @@
+// Package metrics implements a small collection of metrics that may be exposed
+// in the Prometheus text format, so that a server may be monitored without
+// depending on an external client library.
+//
+// All types in the package are safe for concurrent use. Methods on nil
+// values are no-ops, so that metrics may be recorded unconditionally and are
+// simply discarded if no Registry was set.
+package metrics
+
+import (
+	"math"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// DefaultBuckets are the default upper bounds, in seconds, of the buckets of
+// a Histogram. They are suitable for durations ranging from a millisecond to
+// several seconds.
+var DefaultBuckets = []float64{0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10}
+
+// kind is the type of metric held by a family.
+type kind int
+
+const (
+	kindCounter kind = iota
+	kindGauge
+	kindHistogram
+)
+
+// String returns the name of the kind as used in the Prometheus text format.
+func (k kind) String() string {
+	switch k {
+	case kindCounter:
+		return "counter"
+	case kindGauge:
+		return "gauge"
+	}
+	return "histogram"
+}
+
+// family is a metric with a name and a set of label names. It holds a series
+// for every combination of label values that was recorded.
+type family struct {
+	name, help string
+	kind       kind
+	labels     []string
+	buckets    []float64
+
+	mu     sync.RWMutex
+	series map[string]*series
+}
+
+// with returns the series with the label values passed, creating it if it
+// does not yet exist. with panics if the number of values does not match the
+// number of label names of the family.
+func (f *family) with(values []string) *series {
+	if len(values) != len(f.labels) {
+		panic("metrics: " + f.name + ": expected " + strconv.Itoa(len(f.labels)) + " label values, got " + strconv.Itoa(len(values)))
+	}
+	key := strings.Join(values, "\xff")
+	f.mu.RLock()
+	s, ok := f.series[key]
+	f.mu.RUnlock()
+	if ok {
+		return s
+	}
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if s, ok = f.series[key]; !ok {
+		s = &series{values: slices.Clone(values)}
+		if f.kind == kindHistogram {
+			s.counts = make([]uint64, len(f.buckets))
+		}
+		f.series[key] = s
+	}
+	return s
+}
+
+// delete removes the series with the label values passed.
+func (f *family) delete(values []string) {
+	f.mu.Lock()
+	delete(f.series, strings.Join(values, "\xff"))
+	f.mu.Unlock()
+}
+
+// series holds the value of a single combination of label values of a
+// family.
+type series struct {
+	values []string
+	// bits holds the float64 bits of the value of counters and gauges.
+	bits atomic.Uint64
+
+	mu     sync.Mutex
+	counts []uint64
+	sum    float64
+	count  uint64
+}
+
+// add adds delta to the value of the series.
+func (s *series) add(delta float64) {
+	for {
+		old := s.bits.Load()
+		if s.bits.CompareAndSwap(old, math.Float64bits(math.Float64frombits(old)+delta)) {
+			return
+		}
+	}
+}
+
+// CounterVec is a family of Counters that share a name and label names, but
+// have different label values.
+type CounterVec struct{ f *family }
+
+// With returns the Counter with the label values passed, creating it if it
+// does not yet exist. The number of values must match the number of label
+// names passed when creating the CounterVec.
+func (v *CounterVec) With(values ...string) *Counter {
+	if v == nil {
+		return nil
+	}
+	return (*Counter)(v.f.with(values))
+}
+
+// Delete removes the Counter with the label values passed, so that it is no
+// longer exposed.
+func (v *CounterVec) Delete(values ...string) {
+	if v != nil {
+		v.f.delete(values)
+	}
+}
+
+// Counter is a metric with a value that only ever increases, such as the
+// number of packets sent.
+type Counter series
+
+// Inc increases the value of the Counter by 1.
+func (c *Counter) Inc() {
+	c.Add(1)
+}
+
+// Add increases the value of the Counter by the delta passed. Negative deltas
+// are ignored.
+func (c *Counter) Add(delta float64) {
+	if c != nil && delta > 0 {
+		(*series)(c).add(delta)
+	}
+}
+
+// Value returns the current value of the Counter.
+func (c *Counter) Value() float64 {
+	if c == nil {
+		return 0
+	}
+	return math.Float64frombits(c.bits.Load())
+}
+
+// GaugeVec is a family of Gauges that share a name and label names, but have
+// different label values.
+type GaugeVec struct{ f *family }
+
+// With returns the Gauge with the label values passed, creating it if it does
+// not yet exist. The number of values must match the number of label names
+// passed when creating the GaugeVec.
+func (v *GaugeVec) With(values ...string) *Gauge {
+	if v == nil {
+		return nil
+	}
+	return (*Gauge)(v.f.with(values))
+}
+
+// Delete removes the Gauge with the label values passed, so that it is no
+// longer exposed.
+func (v *GaugeVec) Delete(values ...string) {
+	if v != nil {
+		v.f.delete(values)
+	}
+}
+
+// Gauge is a metric with a value that may increase and decrease, such as the
+// number of chunks loaded.
+type Gauge series
+
+// Set sets the value of the Gauge.
+func (g *Gauge) Set(v float64) {
+	if g != nil {
+		g.bits.Store(math.Float64bits(v))
+	}
+}
+
+// Add adds the delta passed to the value of the Gauge. The delta may be
+// negative.
+func (g *Gauge) Add(delta float64) {
+	if g != nil {
+		(*series)(g).add(delta)
+	}
+}
+
+// Value returns the current value of the Gauge.
+func (g *Gauge) Value() float64 {
+	if g == nil {
+		return 0
+	}
+	return math.Float64frombits(g.bits.Load())
+}
+
+// HistogramVec is a family of Histograms that share a name, buckets and label
+// names, but have different label values.
+type HistogramVec struct{ f *family }
+
+// With returns the Histogram with the label values passed, creating it if it
+// does not yet exist. The number of values must match the number of label
+// names passed when creating the HistogramVec.
+func (v *HistogramVec) With(values ...string) *Histogram {
+	if v == nil {
+		return nil
+	}
+	return &Histogram{s: v.f.with(values), buckets: v.f.buckets}
+}
+
+// Delete removes the Histogram with the label values passed, so that it is no
+// longer exposed.
+func (v *HistogramVec) Delete(values ...string) {
+	if v != nil {
+		v.f.delete(values)
+	}
+}
+
+// Histogram is a metric that counts observed values, such as durations, in
+// buckets.
+type Histogram struct {
+	s       *series
+	buckets []float64
+}
+
+// Observe adds a single value to the Histogram.
+func (h *Histogram) Observe(v float64) {
+	if h == nil {
+		return
+	}
+	h.s.mu.Lock()
+	defer h.s.mu.Unlock()
+	if i, _ := slices.BinarySearch(h.buckets, v); i < len(h.buckets) {
+		h.s.counts[i]++
+	}
+	h.s.sum += v
+	h.s.count++
+}
+
+// ObserveDuration adds a duration to the Histogram in seconds.
+func (h *Histogram) ObserveDuration(d time.Duration) {
+	h.Observe(d.Seconds())
+}
+
+// ObserveSince adds the time passed since t to the Histogram in seconds.
+func (h *Histogram) ObserveSince(t time.Time) {
+	if h != nil {
+		h.ObserveDuration(time.Since(t))
+	}
+}
+
+// Count returns the number of values observed by the Histogram.
+func (h *Histogram) Count() uint64 {
+	if h == nil {
+		return 0
+	}
+	h.s.mu.Lock()
+	defer h.s.mu.Unlock()
+	return h.s.count
+}
@@ -0,0 +1,237 @@
+package metrics
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"math"
+	"net"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Registry holds a set of metrics and writes them in the Prometheus text
+// format. Metrics are created using the Counter, Gauge and Histogram methods.
+// A nil *Registry is valid and returns nil metrics, which discard all values
+// recorded.
+type Registry struct {
+	mu       sync.Mutex
+	families map[string]*family
+}
+
+// NewRegistry creates an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{families: make(map[string]*family)}
+}
+
+// Counter returns the CounterVec with the name passed, creating it with the
+// help text and label names passed if it does not yet exist. Counter panics
+// if a metric of another type or with other label names was already created
+// with the same name.
+func (r *Registry) Counter(name, help string, labels ...string) *CounterVec {
+	if r == nil {
+		return nil
+	}
+	return &CounterVec{f: r.family(name, help, kindCounter, labels, nil)}
+}
+
+// Gauge returns the GaugeVec with the name passed, creating it with the help
+// text and label names passed if it does not yet exist. Gauge panics if a
+// metric of another type or with other label names was already created with
+// the same name.
+func (r *Registry) Gauge(name, help string, labels ...string) *GaugeVec {
+	if r == nil {
+		return nil
+	}
+	return &GaugeVec{f: r.family(name, help, kindGauge, labels, nil)}
+}
+
+// Histogram returns the HistogramVec with the name passed, creating it with
+// the help text, bucket upper bounds and label names passed if it does not
+// yet exist. If buckets is nil, DefaultBuckets is used. Histogram panics if a
+// metric of another type or with other label names was already created with
+// the same name.
+func (r *Registry) Histogram(name, help string, buckets []float64, labels ...string) *HistogramVec {
+	if r == nil {
+		return nil
+	}
+	if buckets == nil {
+		buckets = DefaultBuckets
+	}
+	buckets = slices.Clone(buckets)
+	slices.Sort(buckets)
+	return &HistogramVec{f: r.family(name, help, kindHistogram, labels, buckets)}
+}
+
+// family returns the family with the name passed, creating it if it does not
+// yet exist.
+func (r *Registry) family(name, help string, k kind, labels []string, buckets []float64) *family {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if f, ok := r.families[name]; ok {
+		if f.kind != k || !slices.Equal(f.labels, labels) {
+			panic("metrics: " + name + " already registered as " + f.kind.String() + " with labels " + strings.Join(f.labels, ","))
+		}
+		return f
+	}
+	f := &family{name: name, help: help, kind: k, labels: slices.Clone(labels), buckets: buckets, series: make(map[string]*series)}
+	r.families[name] = f
+	return f
+}
+
+// WriteTo writes all metrics in the Registry to w in the Prometheus text
+// format. Metrics are sorted by name and label values.
+func (r *Registry) WriteTo(w io.Writer) (int64, error) {
+	r.mu.Lock()
+	families := make([]*family, 0, len(r.families))
+	for _, f := range r.families {
+		families = append(families, f)
+	}
+	r.mu.Unlock()
+	slices.SortFunc(families, func(a, b *family) int { return strings.Compare(a.name, b.name) })
+
+	cw := &countingWriter{w: w}
+	bw := bufio.NewWriter(cw)
+	for _, f := range families {
+		f.write(bw)
+	}
+	err := bw.Flush()
+	return cw.n, err
+}
+
+// write writes the family and all of its series to w.
+func (f *family) write(w *bufio.Writer) {
+	f.mu.RLock()
+	all := make([]*series, 0, len(f.series))
+	for _, s := range f.series {
+		all = append(all, s)
+	}
+	f.mu.RUnlock()
+	slices.SortFunc(all, func(a, b *series) int { return slices.Compare(a.values, b.values) })
+
+	if f.help != "" {
+		w.WriteString("# HELP " + f.name + " " + escapeHelp(f.help) + "\n")
+	}
+	w.WriteString("# TYPE " + f.name + " " + f.kind.String() + "\n")
+	for _, s := range all {
+		if f.kind != kindHistogram {
+			w.WriteString(f.name + labelString(f.labels, s.values, "", "") + " " + formatFloat(math.Float64frombits(s.bits.Load())) + "\n")
+			continue
+		}
+		s.mu.Lock()
+		counts, sum, count := slices.Clone(s.counts), s.sum, s.count
+		s.mu.Unlock()
+
+		var cumulative uint64
+		for i, bound := range f.buckets {
+			cumulative += counts[i]
+			w.WriteString(f.name + "_bucket" + labelString(f.labels, s.values, "le", formatFloat(bound)) + " " + strconv.FormatUint(cumulative, 10) + "\n")
+		}
+		w.WriteString(f.name + "_bucket" + labelString(f.labels, s.values, "le", "+Inf") + " " + strconv.FormatUint(count, 10) + "\n")
+		w.WriteString(f.name + "_sum" + labelString(f.labels, s.values, "", "") + " " + formatFloat(sum) + "\n")
+		w.WriteString(f.name + "_count" + labelString(f.labels, s.values, "", "") + " " + strconv.FormatUint(count, 10) + "\n")
+	}
+}
+
+// ServeHTTP writes all metrics in the Registry in the Prometheus text format
+// as response to the request. It allows a Registry to be used as
+// http.Handler.
+func (r *Registry) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_, _ = r.WriteTo(w)
+}
+
+// Serve serves the metrics in the Registry over HTTP on the address passed,
+// such as "127.0.0.1:9100", under the /metrics path. Serve returns once the
+// listener is created. The server is shut down when ctx is cancelled. An
+// error is returned if listening on the address failed.
+func (r *Registry) Serve(ctx context.Context, addr string) error {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return err
+	}
+	mux := http.NewServeMux()
+	mux.Handle("/metrics", r)
+	srv := &http.Server{Handler: mux, ReadHeaderTimeout: time.Second * 5}
+	go func() {
+		<-ctx.Done()
+		_ = srv.Close()
+	}()
+	go func() {
+		_ = srv.Serve(l)
+	}()
+	return nil
+}
+
+// labelString formats the label names and values passed as a label set, such
+// as {world="World",dimension="Overworld"}. If extraName is not empty, an
+// additional label with that name and extraValue is appended.
+func labelString(names, values []string, extraName, extraValue string) string {
+	if len(names) == 0 && extraName == "" {
+		return ""
+	}
+	var sb strings.Builder
+	sb.WriteByte('{')
+	for i, name := range names {
+		if i > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(name + `="` + escapeLabel(values[i]) + `"`)
+	}
+	if extraName != "" {
+		if len(names) > 0 {
+			sb.WriteByte(',')
+		}
+		sb.WriteString(extraName + `="` + extraValue + `"`)
+	}
+	sb.WriteByte('}')
+	return sb.String()
+}
+
+// labelEscaper escapes backslashes, double quotes and line feeds in label
+// values.
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// escapeLabel escapes a label value for the Prometheus text format.
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+// helpEscaper escapes backslashes and line feeds in help texts.
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+// escapeHelp escapes a help text for the Prometheus text format.
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+// formatFloat formats a float64 for the Prometheus text format.
+func formatFloat(f float64) string {
+	switch {
+	case math.IsInf(f, 1):
+		return "+Inf"
+	case math.IsInf(f, -1):
+		return "-Inf"
+	case math.IsNaN(f):
+		return "NaN"
+	}
+	return strconv.FormatFloat(f, 'g', -1, 64)
+}
+
+// countingWriter is an io.Writer that counts the number of bytes written to
+// it.
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+// Write ...
+func (c *countingWriter) Write(b []byte) (int, error) {
+	n, err := c.w.Write(b)
+	c.n += int64(n)
+	return n, err
+}
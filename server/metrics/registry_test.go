@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRegistryWriteTo(t *testing.T) {
+	r := NewRegistry()
+	r.Counter("packets_total", "Packets.", "player").With(`a"b`).Add(3)
+	r.Gauge("chunks", "Loaded chunks.").With().Set(12)
+	h := r.Histogram("tick_seconds", "", []float64{0.01, 0.05}, "world").With("w")
+	h.Observe(0.005)
+	h.Observe(0.02)
+	h.Observe(1)
+
+	var sb strings.Builder
+	if _, err := r.WriteTo(&sb); err != nil {
+		t.Fatalf("write metrics: %v", err)
+	}
+	want := `# HELP chunks Loaded chunks.
+# TYPE chunks gauge
+chunks 12
+# HELP packets_total Packets.
+# TYPE packets_total counter
+packets_total{player="a\"b"} 3
+# TYPE tick_seconds histogram
+tick_seconds_bucket{world="w",le="0.01"} 1
+tick_seconds_bucket{world="w",le="0.05"} 2
+tick_seconds_bucket{world="w",le="+Inf"} 3
+tick_seconds_sum{world="w"} 1.025
+tick_seconds_count{world="w"} 3
+`
+	if got := sb.String(); got != want {
+		t.Errorf("unexpected output:\n%v\nwant:\n%v", got, want)
+	}
+
+	r.Counter("packets_total", "Packets.", "player").Delete(`a"b`)
+	sb.Reset()
+	_, _ = r.WriteTo(&sb)
+	if strings.Contains(sb.String(), "packets_total{") {
+		t.Errorf("deleted series still written:\n%v", sb.String())
+	}
+}
+
+func TestNilMetrics(t *testing.T) {
+	var r *Registry
+	r.Counter("c", "").With().Inc()
+	r.Gauge("g", "").With().Set(1)
+	r.Histogram("h", "", nil).With().Observe(1)
+}
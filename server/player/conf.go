@@ -1,6 +1,7 @@
 package player
 
 import (
+	"maps"
 	"math/rand/v2"
 	"time"
 
@@ -47,6 +48,10 @@ type Config struct {
 	FireTicks              int64
 	FallDistance           float64
 	Effects                []effect.Effect
+	UnlockedRecipes        []string
+	Cooldowns              map[string]time.Duration
+	Tags                   []string
+	Scores                 map[string]int
 }
 
 // Apply applies fields from a Config to a world.EntityData, filling out empty
@@ -68,7 +73,9 @@ func (cfg Config) Apply(data *world.EntityData) {
 		experience:          entity.NewExperienceManager(),
 		effects:             entity.NewEffectManager(conf.Effects...),
 		locale:              conf.Locale,
-		cooldowns:           make(map[string]time.Time),
+		cooldowns:           make(map[string]int64, len(conf.Cooldowns)),
+		tags:                make(map[string]struct{}, len(conf.Tags)),
+		scores:              maps.Clone(conf.Scores),
 		mc:                  &entity.MovementComputer{Gravity: 0.08, Drag: 0.02, DragBeforeGravity: true},
 		heldSlot:            &slot,
 		gameMode:            conf.GameMode,
@@ -87,6 +94,21 @@ func (cfg Config) Apply(data *world.EntityData) {
 		alwaysShowNameTag:   true,
 		fireTicks:           conf.FireTicks,
 		fallDistance:        conf.FallDistance,
+		recipes:             make(map[string]struct{}, len(conf.UnlockedRecipes)),
+	}
+	for _, name := range conf.UnlockedRecipes {
+		pdata.recipes[name] = struct{}{}
+	}
+	for _, tag := range conf.Tags {
+		pdata.tags[tag] = struct{}{}
+	}
+	if pdata.scores == nil {
+		pdata.scores = make(map[string]int)
+	}
+	for category, d := range conf.Cooldowns {
+		if ticks := int64((d + time.Second/20 - 1) / (time.Second / 20)); ticks > 0 {
+			pdata.cooldowns[category] = ticks
+		}
 	}
 	playerUUID := conf.UUID
 	pdata.portalTravel = &entity.PortalTravelComputer{
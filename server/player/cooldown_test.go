@@ -0,0 +1,118 @@
+package player
+
+import (
+	"context"
+	"maps"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// testCooldownItem is an item with a cooldown in the cooldown category of the item.
+type testCooldownItem struct {
+	name, category string
+}
+
+func (i testCooldownItem) EncodeItem() (string, int16) { return i.name, 0 }
+func (testCooldownItem) Cooldown() time.Duration       { return time.Second }
+func (i testCooldownItem) CooldownCategory() string    { return i.category }
+
+// testCooldownHandler records the cooldown categories whose cooldown ended.
+type testCooldownHandler struct {
+	NopHandler
+	ended []string
+}
+
+func (h *testCooldownHandler) HandleCooldownEnd(_ *Player, category string) {
+	h.ended = append(h.ended, category)
+}
+
+// runPlayer adds a player created from the Config passed to a new world and runs f with it.
+func runPlayer(t *testing.T, conf Config, f func(p *Player)) {
+	t.Helper()
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	w.Do(func(tx *world.Tx) {
+		f(tx.AddEntity(world.NewEntity(Type, conf)).(*Player))
+	}).Wait(context.Background())
+}
+
+func TestCooldownExpiry(t *testing.T) {
+	runPlayer(t, Config{}, func(p *Player) {
+		h := &testCooldownHandler{}
+		p.Handle(h)
+
+		// Cooldowns are rounded up to whole ticks.
+		p.SetCategoryCooldown("pearl", time.Second/10+time.Millisecond)
+		if d := p.CategoryCooldown("pearl"); d != time.Second/20*3 {
+			t.Fatalf("expected cooldown to be rounded up to 3 ticks, got %v", d)
+		}
+		for range 2 {
+			p.tickCooldowns()
+			if !p.HasCategoryCooldown("pearl") {
+				t.Fatalf("expected cooldown to be active before it expires")
+			}
+		}
+		p.tickCooldowns()
+		if p.HasCategoryCooldown("pearl") || p.CategoryCooldown("pearl") != 0 {
+			t.Fatalf("expected cooldown to expire after 3 ticks")
+		}
+		if !slices.Equal(h.ended, []string{"pearl"}) {
+			t.Fatalf("expected end of cooldown to be handled once, got %v", h.ended)
+		}
+
+		// Setting a cooldown of 0 removes the cooldown immediately.
+		p.SetCategoryCooldown("pearl", time.Second)
+		p.SetCategoryCooldown("pearl", 0)
+		if p.HasCategoryCooldown("pearl") {
+			t.Fatalf("expected cooldown of 0 to remove the cooldown")
+		}
+		if !slices.Equal(h.ended, []string{"pearl", "pearl"}) {
+			t.Fatalf("expected removal of cooldown to be handled as its end, got %v", h.ended)
+		}
+	})
+}
+
+func TestCooldownCategories(t *testing.T) {
+	shield, otherShield := testCooldownItem{name: "test:shield", category: "shield"}, testCooldownItem{name: "test:other_shield", category: "shield"}
+	horn := testCooldownItem{name: "test:horn", category: "horn"}
+	runPlayer(t, Config{}, func(p *Player) {
+		p.SetCooldown(shield, time.Second)
+		if !p.HasCooldown(otherShield) || !p.HasCategoryCooldown("shield") {
+			t.Fatalf("expected items in the same category to share their cooldown")
+		}
+		if p.HasCooldown(horn) {
+			t.Fatalf("expected items in other categories not to have a cooldown")
+		}
+		if p.HasCooldown(nil) {
+			t.Fatalf("expected nil item never to have a cooldown")
+		}
+		// Items without a category use their name without namespace as category.
+		p.SetCategoryCooldown(item.CooldownCategory(item.EnderPearl{}), time.Second)
+		if !p.HasCooldown(item.EnderPearl{}) || !p.HasCategoryCooldown("ender_pearl") {
+			t.Fatalf("expected ender pearl to use its name as category")
+		}
+
+		p.SetCooldown(otherShield, 0)
+		if p.HasCooldown(shield) {
+			t.Fatalf("expected cooldown removed through an item to be removed for its category")
+		}
+	})
+}
+
+func TestCooldownsConfig(t *testing.T) {
+	cooldowns := map[string]time.Duration{"shield": time.Second, "horn": time.Millisecond, "expired": 0}
+	runPlayer(t, Config{Cooldowns: cooldowns}, func(p *Player) {
+		want := map[string]time.Duration{"shield": time.Second, "horn": time.Second / 20}
+		if got := p.Cooldowns(); !maps.Equal(got, want) {
+			t.Fatalf("expected cooldowns %v, got %v", want, got)
+		}
+		p.tickCooldowns()
+		if got := p.Data().Cooldowns; !maps.Equal(got, map[string]time.Duration{"shield": time.Second - time.Second/20}) {
+			t.Fatalf("expected saved cooldowns to hold the time left, got %v", got)
+		}
+	})
+}
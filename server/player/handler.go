@@ -19,6 +19,11 @@ type Handler interface {
 	// HandleMove handles the movement of a player. ctx.Cancel() may be called to cancel the movement event.
 	// The new position, yaw and pitch are passed.
 	HandleMove(ctx *Context, newPos mgl64.Vec3, newRot cube.Rotation)
+	// HandleMovementViolation handles movement of the player that deviated from the movement predicted by the
+	// server, if movement validation is enabled. By default, the player is teleported back to the position it
+	// moved from. ctx.Cancel() may be called to accept the movement anyway. Further punishment, such as
+	// kicking the player, is left to the Handler.
+	HandleMovementViolation(ctx *Context, v session.MovementViolation)
 	// HandleJump handles the player jumping.
 	HandleJump(p *Player)
 	// HandleTeleport handles the teleportation of a player. ctx.Cancel() may be called to cancel it.
@@ -114,6 +119,11 @@ type Handler interface {
 	// spawn critical hit particles around the target entity. These particles will not be displayed
 	// if no damage is dealt.
 	HandleAttackEntity(ctx *Context, e world.Entity, force, height *float64, critical *bool)
+	// HandleInteractionViolation handles an attack, block interaction or block break of the player that failed
+	// validation, if interaction validation is enabled. By default, the interaction is cancelled. ctx.Cancel()
+	// may be called to allow the interaction anyway. Further punishment, such as kicking the player, is left
+	// to the Handler.
+	HandleInteractionViolation(ctx *Context, v session.InteractionViolation)
 	// HandleExperienceGain handles the player gaining experience. ctx.Cancel() may be called to cancel
 	// the gain.
 	// The amount is also provided which can be modified.
@@ -133,6 +143,13 @@ type Handler interface {
 	// The type of the item may be checked to determine whether it was armour or a tool used. The damage to
 	// the item is passed.
 	HandleItemDamage(ctx *Context, i item.Stack, damage *int)
+	// HandleCooldownStart handles a cooldown being started for the cooldown category passed, for example when
+	// the player throws an ender pearl. ctx.Cancel() may be called to prevent the cooldown from starting. The
+	// duration of the cooldown may be changed by assigning to *duration.
+	HandleCooldownStart(ctx *Context, category string, duration *time.Duration)
+	// HandleCooldownEnd handles the end of the cooldown of the cooldown category passed, either because it
+	// expired or because it was removed.
+	HandleCooldownEnd(p *Player, category string)
 	// HandleItemPickup handles the player picking up an item from the ground. The item stack laying on the
 	// ground is passed. ctx.Cancel() may be called to prevent the player from picking up the item.
 	HandleItemPickup(ctx *Context, i *item.Stack)
@@ -167,6 +184,7 @@ var _ Handler = NopHandler{}
 func (NopHandler) HandleItemDrop(*Context, item.Stack)                                     {}
 func (NopHandler) HandleHeldSlotChange(*Context, int, int)                                 {}
 func (NopHandler) HandleMove(*Context, mgl64.Vec3, cube.Rotation)                          {}
+func (NopHandler) HandleMovementViolation(*Context, session.MovementViolation)             {}
 func (NopHandler) HandleJump(*Player)                                                      {}
 func (NopHandler) HandleTeleport(*Context, mgl64.Vec3)                                     {}
 func (NopHandler) HandleChangeWorld(*Player, *world.World, *world.World)                   {}
@@ -191,7 +209,10 @@ func (NopHandler) HandleItemUseOnEntity(*Context, world.Entity)
 func (NopHandler) HandleItemRelease(ctx *Context, item item.Stack, dur time.Duration)      {}
 func (NopHandler) HandleItemConsume(*Context, item.Stack)                                  {}
 func (NopHandler) HandleItemDamage(*Context, item.Stack, *int)                             {}
+func (NopHandler) HandleCooldownStart(*Context, string, *time.Duration)                    {}
+func (NopHandler) HandleCooldownEnd(*Player, string)                                       {}
 func (NopHandler) HandleAttackEntity(*Context, world.Entity, *float64, *float64, *bool)    {}
+func (NopHandler) HandleInteractionViolation(*Context, session.InteractionViolation)       {}
 func (NopHandler) HandleExperienceGain(*Context, *int)                                     {}
 func (NopHandler) HandlePunchAir(*Context)                                                 {}
 func (NopHandler) HandleHurt(*Context, *float64, bool, *time.Duration, world.DamageSource) {}
@@ -0,0 +1,89 @@
+package menu
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+)
+
+// Menu is a window holding an inventory that may be opened by players without a container block existing in
+// the world. The window is shown using a block that only exists client-side, such as a chest, and the blocks
+// in the world are shown again once the Menu is closed. Items taken from or placed in the Menu are passed to
+// the inventory.Handler of its inventory, which may cancel them to build GUIs out of items.
+// A Menu may be opened by multiple players at the same time. Changes to its inventory are shown to all
+// players viewing it.
+type Menu struct {
+	t    Type
+	name string
+	inv  *inventory.Inventory
+
+	viewerMu sync.RWMutex
+	viewers  map[Viewer]struct{}
+}
+
+// Viewer is a viewer of a Menu, such as a player session, that is shown changes to the inventory of the Menu.
+type Viewer interface {
+	// ViewSlotChange views a change of the item in a slot of the Menu's inventory.
+	ViewSlotChange(slot int, newItem item.Stack)
+}
+
+// New creates a new Menu of the Type passed with a name that is shown at the top of the window. If inv is
+// nil, a new inventory with the size of the Type is created. Otherwise, inv must have the same size as the
+// Type, or an error is returned. A function is added to the SlotFunc of inv using Inventory.AddSlotFunc so
+// that changes to it are shown to the viewers of the Menu, while the SlotFunc previously set is still
+// called.
+func New(t Type, name string, inv *inventory.Inventory) (*Menu, error) {
+	if inv == nil {
+		inv = inventory.New(t.Size(), nil)
+	} else if inv.Size() != t.Size() {
+		return nil, fmt.Errorf("menu: inventory of size %v cannot be shown in a window of size %v", inv.Size(), t.Size())
+	}
+	m := &Menu{t: t, name: name, inv: inv, viewers: map[Viewer]struct{}{}}
+	m.inv.AddSlotFunc(func(slot int, _, after item.Stack) {
+		m.viewerMu.RLock()
+		defer m.viewerMu.RUnlock()
+		for v := range m.viewers {
+			v.ViewSlotChange(slot, after)
+		}
+	})
+	return m, nil
+}
+
+// Type returns the Type of the window that the Menu is shown as.
+func (m *Menu) Type() Type {
+	return m.t
+}
+
+// Name returns the name shown at the top of the window of the Menu.
+func (m *Menu) Name() string {
+	return m.name
+}
+
+// Inventory returns the inventory of the Menu. Its inventory.Handler may be set to handle items being taken
+// from or placed in the Menu.
+func (m *Menu) Inventory() *inventory.Inventory {
+	return m.inv
+}
+
+// AddViewer adds a Viewer to the Menu, so that changes to its inventory are shown to it.
+func (m *Menu) AddViewer(v Viewer) {
+	m.viewerMu.Lock()
+	defer m.viewerMu.Unlock()
+	m.viewers[v] = struct{}{}
+}
+
+// RemoveViewer removes a Viewer from the Menu.
+func (m *Menu) RemoveViewer(v Viewer) {
+	m.viewerMu.Lock()
+	defer m.viewerMu.Unlock()
+	delete(m.viewers, v)
+}
+
+// Viewers returns the number of viewers that currently have the Menu opened.
+func (m *Menu) Viewers() int {
+	m.viewerMu.RLock()
+	defer m.viewerMu.RUnlock()
+	return len(m.viewers)
+}
@@ -0,0 +1,78 @@
+package menu
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+)
+
+// slotRecorder is a Viewer that records the slot changes viewed.
+type slotRecorder struct {
+	slots []int
+}
+
+func (r *slotRecorder) ViewSlotChange(slot int, _ item.Stack) {
+	r.slots = append(r.slots, slot)
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		t    Type
+		size int
+	}{
+		{t: Chest(), size: 27},
+		{t: DoubleChest(), size: 54},
+		{t: Hopper(), size: 5},
+		{t: Dispenser(), size: 9},
+		{t: Dropper(), size: 9},
+	}
+	for _, test := range tests {
+		t.Run(test.t.String(), func(t *testing.T) {
+			m, err := New(test.t, "name", nil)
+			if err != nil {
+				t.Fatalf("new menu: %v", err)
+			}
+			if m.Inventory().Size() != test.size || m.Type() != test.t || m.Name() != "name" {
+				t.Fatalf("expected %v menu of size %v, got %v menu of size %v", test.t, test.size, m.Type(), m.Inventory().Size())
+			}
+			if _, err := New(test.t, "", inventory.New(test.size, nil)); err != nil {
+				t.Fatalf("new menu with inventory of size %v: %v", test.size, err)
+			}
+			if _, err := New(test.t, "", inventory.New(test.size+1, nil)); err == nil {
+				t.Fatalf("expected error creating menu with inventory of size %v", test.size+1)
+			}
+		})
+	}
+}
+
+func TestMenuSlotChanges(t *testing.T) {
+	var previous []int
+	inv := inventory.New(27, func(slot int, _, _ item.Stack) {
+		previous = append(previous, slot)
+	})
+	m, err := New(Chest(), "", inv)
+	if err != nil {
+		t.Fatalf("new menu: %v", err)
+	}
+	a, b := &slotRecorder{}, &slotRecorder{}
+	m.AddViewer(a)
+	m.AddViewer(b)
+	if m.Viewers() != 2 {
+		t.Fatalf("expected 2 viewers, got %v", m.Viewers())
+	}
+
+	_ = inv.SetItem(3, item.NewStack(item.Stick{}, 1))
+	m.RemoveViewer(b)
+	_ = inv.SetItem(5, item.NewStack(item.Stick{}, 1))
+
+	if len(a.slots) != 2 || a.slots[0] != 3 || a.slots[1] != 5 {
+		t.Errorf("expected viewer to view slots 3 and 5, got %v", a.slots)
+	}
+	if len(b.slots) != 1 || b.slots[0] != 3 {
+		t.Errorf("expected removed viewer to only view slot 3, got %v", b.slots)
+	}
+	if len(previous) != 2 {
+		t.Errorf("expected previous slot function to still be called, got %v", previous)
+	}
+}
@@ -0,0 +1,67 @@
+package menu
+
+// Type is the type of window that a Menu is shown as. It decides the number of slots of the Menu and the
+// block used to open it.
+type Type struct {
+	menuType
+}
+
+type menuType uint8
+
+// Chest is a window with 27 slots, shown using a chest.
+func Chest() Type {
+	return Type{0}
+}
+
+// DoubleChest is a window with 54 slots, shown using two paired chests.
+func DoubleChest() Type {
+	return Type{1}
+}
+
+// Hopper is a window with 5 slots, shown using a hopper.
+func Hopper() Type {
+	return Type{2}
+}
+
+// Dispenser is a window with 9 slots in a 3x3 grid, shown using a dispenser.
+func Dispenser() Type {
+	return Type{3}
+}
+
+// Dropper is a window with 9 slots in a 3x3 grid, shown using a dropper.
+func Dropper() Type {
+	return Type{4}
+}
+
+// Uint8 returns the Type as a uint8.
+func (t menuType) Uint8() uint8 {
+	return uint8(t)
+}
+
+// Size returns the number of slots in a window of the Type.
+func (t menuType) Size() int {
+	switch t {
+	case 1:
+		return 54
+	case 2:
+		return 5
+	case 3, 4:
+		return 9
+	}
+	return 27
+}
+
+// String returns the name of the Type.
+func (t menuType) String() string {
+	switch t {
+	case 1:
+		return "double_chest"
+	case 2:
+		return "hopper"
+	case 3:
+		return "dispenser"
+	case 4:
+		return "dropper"
+	}
+	return "chest"
+}
@@ -3,6 +3,7 @@ package player
 import (
 	"errors"
 	"fmt"
+	"maps"
 	"math"
 	"math/rand/v2"
 	"net"
@@ -21,6 +22,7 @@ import (
 	"github.com/df-mc/dragonfly/server/item"
 	"github.com/df-mc/dragonfly/server/item/enchantment"
 	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/item/recipe"
 	"github.com/df-mc/dragonfly/server/player/bossbar"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/debug"
@@ -28,6 +30,7 @@ import (
 	"github.com/df-mc/dragonfly/server/player/form"
 	"github.com/df-mc/dragonfly/server/player/hud"
 	"github.com/df-mc/dragonfly/server/player/input"
+	"github.com/df-mc/dragonfly/server/player/menu"
 	"github.com/df-mc/dragonfly/server/player/scoreboard"
 	"github.com/df-mc/dragonfly/server/player/skin"
 	"github.com/df-mc/dragonfly/server/player/title"
@@ -50,6 +53,7 @@ type playerData struct {
 
 	gameMode world.GameMode
 	skin     skin.Skin
+	disguise world.EntityType
 	s        *session.Session
 	h        Handler
 
@@ -73,7 +77,7 @@ type playerData struct {
 	airSupplyTicks    int
 	maxAirSupplyTicks int
 
-	cooldowns map[string]time.Time
+	cooldowns map[string]int64
 
 	speed               float64
 	flightSpeed         float64
@@ -93,6 +97,11 @@ type playerData struct {
 
 	enchantSeed int64
 
+	recipes map[string]struct{}
+
+	tags   map[string]struct{}
+	scores map[string]int
+
 	mc           *entity.MovementComputer
 	portalTravel *entity.PortalTravelComputer
 
@@ -210,6 +219,45 @@ func (p *Player) SetSkin(skin skin.Skin) {
 	}
 }
 
+// Disguise disguises the Player as an entity of the world.EntityType passed for all viewers. Other players see
+// the Player with the identifier and bounding box of the type, such as a mob, a custom entity or a block
+// through entity.BlockDisguise, while the Player itself is shown normally. Disguises for single viewers may
+// be set using ViewLayer.ViewDisguise, which take precedence over this disguise.
+func (p *Player) Disguise(t world.EntityType) {
+	p.disguise = t
+	p.respawnForViewers()
+}
+
+// RemoveDisguise removes the disguise of the Player set using Disguise, so that it is shown as a player again.
+func (p *Player) RemoveDisguise() {
+	if p.disguise == nil {
+		return
+	}
+	p.disguise = nil
+	p.respawnForViewers()
+}
+
+// Disguised returns the world.EntityType the Player is disguised as for all viewers and whether it is
+// disguised at all.
+func (p *Player) Disguised() (world.EntityType, bool) {
+	return p.disguise, p.disguise != nil
+}
+
+// respawnForViewers removes the Player for all of its viewers and spawns it again, so that changes in the way
+// it is shown, such as its disguise, are applied. Sessions only respawn the Player if it is visible to them.
+func (p *Player) respawnForViewers() {
+	for _, v := range p.viewers() {
+		if s, ok := v.(*session.Session); ok {
+			s.RespawnEntity(p)
+			continue
+		}
+		v.HideEntity(p)
+		v.ViewEntity(p)
+		v.ViewEntityItems(p)
+		v.ViewEntityArmour(p)
+	}
+}
+
 // Locale returns the language and locale of the Player, as selected in the Player's settings.
 func (p *Player) Locale() language.Tag {
 	return p.locale
@@ -729,6 +777,13 @@ func (p *Player) FinalDamageFrom(dmg float64, src world.DamageSource) float64 {
 	dmg = max(dmg, 0)
 
 	dmg -= p.Armour().DamageReduction(dmg, src)
+	for _, it := range p.Armour().Items() {
+		for _, e := range it.Enchantments() {
+			if h, ok := e.Type().(item.HurtEnchantment); ok {
+				dmg = max(h.Hurt(e.Level(), p.tx, p, dmg, src), 0)
+			}
+		}
+	}
 	if res, ok := p.Effect(effect.Resistance); ok {
 		dmg *= effect.Resistance.Multiplier(src, res.Level())
 	}
@@ -1546,32 +1601,75 @@ func (p *Player) GameMode() world.GameMode {
 	return p.gameMode
 }
 
-// HasCooldown returns true if the item passed has an active cooldown, meaning it currently cannot be used again. If the
+// HasCooldown returns true if the item passed has an active cooldown, meaning it currently cannot be used again. The
+// cooldown is shared by all items in the same cooldown category, as returned by item.CooldownCategory. If the
 // world.Item passed is nil, HasCooldown always returns false.
-func (p *Player) HasCooldown(item world.Item) bool {
-	if item == nil {
+func (p *Player) HasCooldown(it world.Item) bool {
+	if it == nil {
 		return false
 	}
-	name, _ := item.EncodeItem()
-	otherTime, ok := p.cooldowns[name]
-	if !ok {
-		return false
-	}
-	if time.Now().After(otherTime) {
-		delete(p.cooldowns, name)
-		return false
+	return p.HasCategoryCooldown(item.CooldownCategory(it))
+}
+
+// SetCooldown sets a cooldown for the cooldown category of an item, as returned by item.CooldownCategory. If the
+// world.Item passed is nil, nothing happens.
+func (p *Player) SetCooldown(it world.Item, cooldown time.Duration) {
+	if it == nil {
+		return
 	}
-	return true
+	p.SetCategoryCooldown(item.CooldownCategory(it), cooldown)
 }
 
-// SetCooldown sets a cooldown for an item. If the world.Item passed is nil, nothing happens.
-func (p *Player) SetCooldown(item world.Item, cooldown time.Duration) {
-	if item == nil {
+// HasCategoryCooldown returns true if the cooldown category passed has an active cooldown, meaning items in the
+// category currently cannot be used again.
+func (p *Player) HasCategoryCooldown(category string) bool {
+	_, ok := p.cooldowns[category]
+	return ok
+}
+
+// SetCategoryCooldown sets a cooldown for all items in the cooldown category passed. The cooldown is rounded up
+// to a whole number of ticks. Passing a cooldown of 0 or less removes the cooldown of the category.
+func (p *Player) SetCategoryCooldown(category string, cooldown time.Duration) {
+	if cooldown <= 0 {
+		if _, ok := p.cooldowns[category]; ok {
+			delete(p.cooldowns, category)
+			p.session().ViewItemCooldown(category, 0)
+			p.Handler().HandleCooldownEnd(p, category)
+		}
+		return
+	}
+	ctx := NewEventContext(p.tx, p)
+	if p.Handler().HandleCooldownStart(ctx, category, &cooldown); ctx.Cancelled() || cooldown <= 0 {
 		return
 	}
-	name, _ := item.EncodeItem()
-	p.cooldowns[name] = time.Now().Add(cooldown)
-	p.session().ViewItemCooldown(item, cooldown)
+	ticks := int64((cooldown + time.Second/20 - 1) / (time.Second / 20))
+	p.cooldowns[category] = ticks
+	p.session().ViewItemCooldown(category, time.Duration(ticks)*time.Second/20)
+}
+
+// CategoryCooldown returns the time left until the cooldown of the cooldown category passed ends. If the
+// category has no active cooldown, 0 is returned.
+func (p *Player) CategoryCooldown(category string) time.Duration {
+	return time.Duration(p.cooldowns[category]) * time.Second / 20
+}
+
+// Cooldowns returns the time left for all active cooldowns of the player, indexed by their cooldown category.
+func (p *Player) Cooldowns() map[string]time.Duration {
+	m := make(map[string]time.Duration, len(p.cooldowns))
+	for category := range p.cooldowns {
+		m[category] = p.CategoryCooldown(category)
+	}
+	return m
+}
+
+// tickCooldowns ticks down the active cooldowns of the player, removing cooldowns that have ended.
+func (p *Player) tickCooldowns() {
+	for _, category := range slices.Sorted(maps.Keys(p.cooldowns)) {
+		if p.cooldowns[category]--; p.cooldowns[category] <= 0 {
+			delete(p.cooldowns, category)
+			p.Handler().HandleCooldownEnd(p, category)
+		}
+	}
 }
 
 // UseItem uses the item currently held in the player's main hand in the air. Generally, nothing happens,
@@ -1905,6 +2003,11 @@ func (p *Player) AttackEntity(e world.Entity) bool {
 			v.ViewEntityAction(living, entity.EnchantedHitAction{})
 		}
 	}
+	for _, e := range i.Enchantments() {
+		if a, ok := e.Type().(item.AttackEnchantment); ok {
+			a.Attack(e.Level(), p.tx, p, living, &dmg)
+		}
+	}
 	if critical {
 		dmg *= 1.5
 	}
@@ -1985,15 +2088,15 @@ func (p *Player) StartBreaking(pos cube.Pos, face cube.Face) {
 	if p.GameMode().CreativeInventory() {
 		return
 	}
-	p.lastBreakDuration = p.breakTime(pos)
+	p.lastBreakDuration = p.BreakTime(pos)
 	for _, viewer := range p.viewers() {
 		viewer.ViewBlockAction(pos, block.StartCrackAction{BreakTime: p.lastBreakDuration})
 	}
 }
 
-// breakTime returns the time needed to break a block at the position passed, taking into account the item
+// BreakTime returns the time needed to break a block at the position passed, taking into account the item
 // held, if the player is on the ground/underwater and if the player has any effects.
-func (p *Player) breakTime(pos cube.Pos) time.Duration {
+func (p *Player) BreakTime(pos cube.Pos) time.Duration {
 	held, _ := p.HeldItems()
 	return block.BreakDuration(p.tx.Block(pos), held, p.breakContext())
 }
@@ -2063,7 +2166,7 @@ func (p *Player) ContinueBreaking(face cube.Face) {
 		// either. Every 5 ticks seems accurate.
 		p.tx.PlaySound(pos.Vec3(), sound.BlockBreaking{Block: b})
 	}
-	if breakTime := p.breakTime(pos); breakTime != p.lastBreakDuration {
+	if breakTime := p.BreakTime(pos); breakTime != p.lastBreakDuration {
 		for _, viewer := range p.viewers() {
 			viewer.ViewBlockAction(pos, block.ContinueCrackAction{BreakTime: breakTime})
 		}
@@ -2088,7 +2191,7 @@ func (p *Player) PlaceBlock(pos cube.Pos, b world.Block, ctx *item.UseContext) {
 // placeBlock makes the player place the block passed at the position passed, granted it is within the range
 // of the player. A bool is returned indicating if a block was placed successfully.
 func (p *Player) placeBlock(pos cube.Pos, b world.Block, ignoreBBox bool) bool {
-	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() {
+	if !p.canReach(pos.Vec3Centre()) || !p.GameMode().AllowsEditing() || !p.tx.World().Border().ContainsBlock(pos) {
 		p.resendNearbyBlocks(pos, cube.Faces()...)
 		return false
 	}
@@ -2168,6 +2271,11 @@ func (p *Player) BreakBlock(pos cube.Pos) {
 			xp = breakable.BreakInfo().XPDrops.RandomValue()
 		}
 	}
+	for _, e := range held.Enchantments() {
+		if be, ok := e.Type().(item.BreakEnchantment); ok {
+			be.BreakBlock(e.Level(), p.tx, p, pos, &drops, &xp)
+		}
+	}
 
 	ctx := NewEventContext(p.tx, p)
 	if p.Handler().HandleBlockBreak(ctx, pos, &drops, &xp); ctx.Cancelled() {
@@ -2323,6 +2431,12 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 		pos         = p.Position()
 		res, resRot = pos.Add(deltaPos), p.Rotation().Add(cube.Rotation{deltaYaw, deltaPitch})
 	)
+	if b := p.tx.World().Border(); !b.Contains(res) && b.Distance(res) < b.Distance(pos) {
+		// Players can't move out of the world border, but they may move back
+		// into it if the border shrunk past them.
+		p.teleport(pos)
+		return
+	}
 	ctx := NewEventContext(p.tx, p)
 	if p.Handler().HandleMove(ctx, res, resRot); ctx.Cancelled() {
 		if p.session() != session.Nop && pos.ApproxEqual(p.Position()) {
@@ -2376,6 +2490,24 @@ func (p *Player) Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64) {
 	}
 }
 
+// ReportMovementViolation reports movement claimed by the client of the player that deviated from the
+// movement predicted by the server. It returns true if the movement should be corrected, which is the case
+// unless the Handler of the player cancels the violation.
+func (p *Player) ReportMovementViolation(v session.MovementViolation) bool {
+	ctx := NewEventContext(p.tx, p)
+	p.Handler().HandleMovementViolation(ctx, v)
+	return !ctx.Cancelled()
+}
+
+// ReportInteractionViolation reports an attack, block interaction or block break of the player that failed
+// validation. It returns true if the interaction should be cancelled, which is the case unless the Handler of
+// the player cancels the violation.
+func (p *Player) ReportInteractionViolation(v session.InteractionViolation) bool {
+	ctx := NewEventContext(p.tx, p)
+	p.Handler().HandleInteractionViolation(ctx, v)
+	return !ctx.Cancelled()
+}
+
 // Displace moves the player by a server-authoritative relative delta, clipped against block collision boxes.
 func (p *Player) Displace(deltaPos mgl64.Vec3) {
 	if p.Dead() || deltaPos.ApproxEqual(mgl64.Vec3{}) {
@@ -2402,6 +2534,20 @@ func (p *Player) Position() mgl64.Vec3 {
 	return p.data.Pos
 }
 
+// tickBorder damages the player if it is too far outside the world.Border of
+// its world and shows the border to the player if it is close to it.
+func (p *Player) tickBorder() {
+	b := p.tx.World().Border()
+	if !b.Enabled() {
+		return
+	}
+	pos := p.Position()
+	if d := b.Distance(pos) + b.SafeZone; d < 0 && b.DamagePerBlock > 0 {
+		p.Hurt(math.Max(1, math.Floor(-d*b.DamagePerBlock)), entity.BorderDamageSource{})
+	}
+	p.session().ViewWorldBorder(b, pos, p.tx.World().Dimension())
+}
+
 // Velocity returns the players current velocity. If there is an attached session, this will be empty.
 func (p *Player) Velocity() mgl64.Vec3 {
 	return p.data.Vel
@@ -2462,6 +2608,101 @@ func (p *Player) ResetEnchantmentSeed() {
 	p.enchantSeed = rand.Int64()
 }
 
+// AddTag adds the tags passed to the player. Tags are matched by the 'tag' argument of command target
+// selectors, such as '@a[tag=red]'.
+func (p *Player) AddTag(tags ...string) {
+	for _, tag := range tags {
+		p.tags[tag] = struct{}{}
+	}
+}
+
+// RemoveTag removes the tags passed from the player.
+func (p *Player) RemoveTag(tags ...string) {
+	for _, tag := range tags {
+		delete(p.tags, tag)
+	}
+}
+
+// HasTag checks if the player has the tag passed.
+func (p *Player) HasTag(tag string) bool {
+	_, ok := p.tags[tag]
+	return ok
+}
+
+// Tags returns all tags of the player, sorted alphabetically.
+func (p *Player) Tags() []string {
+	return slices.Sorted(maps.Keys(p.tags))
+}
+
+// SetScore sets the score of the player for the objective passed. Scores are matched by the 'scores' argument
+// of command target selectors, such as '@a[scores={kills=5..}]'.
+func (p *Player) SetScore(objective string, score int) {
+	p.scores[objective] = score
+}
+
+// RemoveScore removes the score of the player for the objective passed.
+func (p *Player) RemoveScore(objective string) {
+	delete(p.scores, objective)
+}
+
+// Score returns the score of the player for the objective passed. If the player has no score for the
+// objective, false is returned.
+func (p *Player) Score(objective string) (int, bool) {
+	score, ok := p.scores[objective]
+	return score, ok
+}
+
+// Scores returns the scores of the player for all objectives that it has a score for.
+func (p *Player) Scores() map[string]int {
+	return maps.Clone(p.scores)
+}
+
+// UnlockRecipes unlocks the recipes with the names passed for the player, so that they are shown in the
+// recipe book of the player. The names of recipes are obtained using recipe.Name. Recipes that were
+// already unlocked are ignored.
+func (p *Player) UnlockRecipes(names ...string) {
+	unlocked := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := p.recipes[name]; !ok {
+			p.recipes[name] = struct{}{}
+			unlocked = append(unlocked, name)
+		}
+	}
+	p.session().SendRecipesUnlocked(unlocked)
+}
+
+// LockRecipes locks the recipes with the names passed for the player, so that they are no longer shown in the
+// recipe book of the player until they are unlocked again. Recipes that were not unlocked are ignored.
+func (p *Player) LockRecipes(names ...string) {
+	locked := make([]string, 0, len(names))
+	for _, name := range names {
+		if _, ok := p.recipes[name]; ok {
+			delete(p.recipes, name)
+			locked = append(locked, name)
+		}
+	}
+	p.session().SendRecipesLocked(locked)
+}
+
+// RecipeUnlocked checks if the recipe with the name passed is unlocked for the player.
+func (p *Player) RecipeUnlocked(name string) bool {
+	_, ok := p.recipes[name]
+	return ok
+}
+
+// UnlockedRecipes returns the names of all recipes unlocked for the player, sorted alphabetically.
+func (p *Player) UnlockedRecipes() []string {
+	return slices.Sorted(maps.Keys(p.recipes))
+}
+
+// DiscoverRecipes unlocks all recipes that use the item passed as one of their inputs. It is called
+// automatically when the player obtains an item.
+func (p *Player) DiscoverRecipes(it item.Stack) {
+	if !it.Empty() {
+		p.UnlockRecipes(recipe.ByIngredient(it)...)
+	}
+}
+
 // AddExperience adds experience to the player.
 func (p *Player) AddExperience(amount int) int {
 	ctx := NewEventContext(p.tx, p)
@@ -2603,6 +2844,26 @@ func (p *Player) OpenBlockContainer(pos cube.Pos, tx *world.Tx) {
 	}
 }
 
+// OpenMenu opens a menu.Menu for the Player. Unlike OpenBlockContainer, no container needs to exist in the
+// world: the block needed to open the window of the menu is shown to the Player only, just above its head,
+// and the blocks of the world are shown again once the menu is closed.
+// OpenMenu does nothing if the player has no session connected to it.
+func (p *Player) OpenMenu(m *menu.Menu) {
+	if p.session() == session.Nop {
+		return
+	}
+	pos := cube.PosFromVec3(p.Position()).Add(cube.Pos{0, 2})
+	if r := p.tx.Range(); pos[1] > r[1] {
+		pos[1] = r[1]
+	}
+	p.session().OpenMenu(m, pos, p.tx)
+}
+
+// CloseMenu closes the menu.Menu or block container currently opened by the Player, if any.
+func (p *Player) CloseMenu() {
+	p.session().CloseContainer(p.tx)
+}
+
 // HideEntity hides a world.Entity from the Player so that it can under no circumstance see it. Hidden entities can be
 // made visible again through a call to ShowEntity.
 func (p *Player) HideEntity(e world.Entity) {
@@ -2657,6 +2918,7 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	p.checkEntitySteppers()
 
 	p.effects.Tick(p, p.tx)
+	p.tickEnchantments(current)
 
 	p.tickFood()
 	p.tickAirSupply()
@@ -2667,6 +2929,9 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 	if p.insideOfSolid() {
 		p.Hurt(1, entity.SuffocationDamageSource{})
 	}
+	if current%10 == 0 {
+		p.tickBorder()
+	}
 
 	if p.OnFireDuration() > 0 {
 		p.fireTicks -= 1
@@ -2697,17 +2962,17 @@ func (p *Player) Tick(tx *world.Tx, current int64) {
 		p.ContinueBreaking(p.breakingFace)
 	}
 
-	for it, ti := range p.cooldowns {
-		if time.Now().After(ti) {
-			delete(p.cooldowns, it)
-		}
-	}
+	p.tickCooldowns()
 
 	p.session().SendDebugShapes(tx.World().Dimension())
 	p.session().SendHudUpdates()
 
 	if p.prevWorld != tx.World() && p.prevWorld != nil {
 		p.Handler().HandleChangeWorld(p, p.prevWorld, tx.World())
+		// Cooldowns are sent again so that the client keeps displaying them in the new world.
+		for category, d := range p.Cooldowns() {
+			p.session().ViewItemCooldown(category, d)
+		}
 	}
 	p.prevWorld = tx.World()
 
@@ -2773,11 +3038,94 @@ func (p *Player) ViewVisibility(entity world.Entity, level world.VisibilityLevel
 	p.session().ViewVisibility(entity, level)
 }
 
+// ViewScale overrides the public scale of the entity for this player.
+func (p *Player) ViewScale(entity world.Entity, scale float64) {
+	p.session().ViewScale(entity, scale)
+}
+
+// ViewPublicScale removes the scale override of the entity for this player.
+func (p *Player) ViewPublicScale(entity world.Entity) {
+	p.session().ViewPublicScale(entity)
+}
+
+// ViewSkin overrides the public skin of the entity for this player.
+func (p *Player) ViewSkin(entity world.Entity, s skin.Skin) {
+	p.session().ViewEntitySkin(entity, s)
+}
+
+// ViewPublicSkin removes the skin override of the entity for this player.
+func (p *Player) ViewPublicSkin(entity world.Entity) {
+	p.session().ViewPublicEntitySkin(entity)
+}
+
+// ViewHeldItems overrides the items held by the entity for this player.
+func (p *Player) ViewHeldItems(entity world.Entity, mainHand, offHand item.Stack) {
+	p.session().ViewHeldItems(entity, mainHand, offHand)
+}
+
+// ViewPublicHeldItems removes the held items override of the entity for this player.
+func (p *Player) ViewPublicHeldItems(entity world.Entity) {
+	p.session().ViewPublicHeldItems(entity)
+}
+
+// ViewArmour overrides the armour worn by the entity for this player.
+func (p *Player) ViewArmour(entity world.Entity, helmet, chestplate, leggings, boots item.Stack) {
+	p.session().ViewArmour(entity, helmet, chestplate, leggings, boots)
+}
+
+// ViewPublicArmour removes the armour override of the entity for this player.
+func (p *Player) ViewPublicArmour(entity world.Entity) {
+	p.session().ViewPublicArmour(entity)
+}
+
+// ViewActions sets a function that decides which actions of the entity, such as swinging its arm or being
+// hurt, are shown to this player. Passing nil shows all actions again.
+func (p *Player) ViewActions(entity world.Entity, f func(a world.EntityAction) bool) {
+	p.session().ViewActions(entity, f)
+}
+
+// ViewDisguise disguises the entity as an entity of the world.EntityType passed for this player only.
+func (p *Player) ViewDisguise(entity world.Entity, t world.EntityType) {
+	p.session().ViewDisguise(entity, t)
+}
+
+// ViewPublicDisguise removes the disguise override of the entity for this player.
+func (p *Player) ViewPublicDisguise(entity world.Entity) {
+	p.session().ViewPublicDisguise(entity)
+}
+
 // RemoveViewLayer removes all view-layer overrides of the entity for this player.
 func (p *Player) RemoveViewLayer(entity world.Entity) {
 	p.session().RemoveViewLayer(entity)
 }
 
+// ViewBlock overrides the block at a position for this player only, for example to show hidden walls or
+// previews of builds. The block in the world is left unchanged. The override is kept when the chunk is sent
+// again and is removed when the chunk is unloaded for the player, such as when moving away or changing
+// worlds.
+func (p *Player) ViewBlock(pos cube.Pos, b world.Block) {
+	p.session().ViewBlock(pos, b)
+}
+
+// ViewPublicBlock removes the block override at a position for this player, so that the block in the world
+// is shown again.
+func (p *Player) ViewPublicBlock(pos cube.Pos) {
+	p.session().ViewPublicBlock(pos)
+}
+
+// tickEnchantments calls the TickEnchantment behaviour of the enchantments on the items held and the armour
+// worn by the player.
+func (p *Player) tickEnchantments(current int64) {
+	held, offHand := p.HeldItems()
+	for _, it := range append([]item.Stack{held, offHand}, p.Armour().Items()...) {
+		for _, e := range it.Enchantments() {
+			if t, ok := e.Type().(item.TickEnchantment); ok {
+				t.Tick(e.Level(), p.tx, p, current)
+			}
+		}
+	}
+}
+
 // tickAirSupply tick's the player's air supply, consuming it when underwater, and replenishing it when out of water.
 func (p *Player) tickAirSupply() {
 	if !p.canBreathe() {
@@ -3409,6 +3757,10 @@ func (p *Player) Data() Config {
 		FireTicks:           p.fireTicks,
 		FallDistance:        p.fallDistance,
 		Effects:             p.Effects(),
+		UnlockedRecipes:     p.UnlockedRecipes(),
+		Cooldowns:           p.Cooldowns(),
+		Tags:                p.Tags(),
+		Scores:              p.Scores(),
 	}
 }
 
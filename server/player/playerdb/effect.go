@@ -9,8 +9,15 @@ func effectsToData(effects []effect.Effect) []jsonEffect {
 		if !ok {
 			continue
 		}
+		// Custom effects are saved using their identifier, as their ID may change if effects are registered
+		// in a different order.
+		var name string
+		if c, ok := eff.Type().(effect.CustomType); ok {
+			name = c.Identifier()
+		}
 		data[key] = jsonEffect{
 			ID:              id,
+			Name:            name,
 			Duration:        eff.Duration(),
 			Level:           eff.Level(),
 			Ambient:         eff.Ambient(),
@@ -25,6 +32,9 @@ func dataToEffects(data []jsonEffect) []effect.Effect {
 	effects := make([]effect.Effect, len(data))
 	for i, d := range data {
 		e, ok := effect.ByID(d.ID)
+		if d.Name != "" {
+			e, ok = effect.ByName(d.Name)
+		}
 		if !ok {
 			continue
 		}
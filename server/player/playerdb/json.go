@@ -35,6 +35,10 @@ func (p *Provider) fromJson(d jsonData, lookupWorld func(world.Dimension) *world
 		Effects:             dataToEffects(d.Effects),
 		FireTicks:           d.FireTicks,
 		FallDistance:        d.FallDistance,
+		UnlockedRecipes:     d.UnlockedRecipes,
+		Cooldowns:           d.Cooldowns,
+		Tags:                d.Tags,
+		Scores:              d.Scores,
 		Inventory:           inventory.New(36, nil),
 		EnderChestInventory: inventory.New(27, nil),
 		OffHand:             inventory.New(1, nil),
@@ -82,6 +86,10 @@ func (p *Provider) toJson(d player.Config, w *world.World) jsonData {
 		Effects:         effectsToData(d.Effects),
 		FireTicks:       d.FireTicks,
 		FallDistance:    d.FallDistance,
+		UnlockedRecipes: d.UnlockedRecipes,
+		Cooldowns:       d.Cooldowns,
+		Tags:            d.Tags,
+		Scores:          d.Scores,
 		Inventory: invToData(InventoryData{
 			Items:        d.Inventory.Slots(),
 			Boots:        d.Armour.Boots(),
@@ -116,6 +124,10 @@ type jsonData struct {
 	FireTicks                        int64
 	FallDistance                     float64
 	Dimension                        int32
+	UnlockedRecipes                  []string
+	Cooldowns                        map[string]time.Duration
+	Tags                             []string
+	Scores                           map[string]int
 }
 
 type jsonInventoryData struct {
@@ -135,6 +147,7 @@ type jsonSlot struct {
 
 type jsonEffect struct {
 	ID              int
+	Name            string `json:",omitempty"`
 	Level           int
 	Duration        time.Duration
 	Ambient         bool
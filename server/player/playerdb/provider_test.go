@@ -0,0 +1,54 @@
+package playerdb
+
+import (
+	"maps"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/google/uuid"
+)
+
+func TestProviderCooldowns(t *testing.T) {
+	p, err := NewProvider(t.TempDir())
+	if err != nil {
+		t.Fatalf("open provider: %v", err)
+	}
+	t.Cleanup(func() { _ = p.Close() })
+	w := world.Config{Synchronous: true}.New()
+	t.Cleanup(func() { _ = w.Close() })
+
+	tests := []struct {
+		name      string
+		cooldowns map[string]time.Duration
+	}{
+		{name: "none"},
+		{name: "single", cooldowns: map[string]time.Duration{"ender_pearl": time.Second}},
+		{name: "multiple", cooldowns: map[string]time.Duration{"ender_pearl": time.Second / 20, "shield": 5 * time.Second, "example:horn": time.Minute + time.Second/2}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			id := uuid.New()
+			conf := player.Config{
+				UUID:                id,
+				Cooldowns:           test.cooldowns,
+				Inventory:           inventory.New(36, nil),
+				EnderChestInventory: inventory.New(27, nil),
+				OffHand:             inventory.New(1, nil),
+				Armour:              inventory.NewArmour(nil),
+			}
+			if err := p.Save(id, conf, w); err != nil {
+				t.Fatalf("save: %v", err)
+			}
+			loaded, _, err := p.Load(id, func(world.Dimension) *world.World { return w })
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if !maps.Equal(loaded.Cooldowns, test.cooldowns) {
+				t.Fatalf("expected cooldowns %v after round trip, got %v", test.cooldowns, loaded.Cooldowns)
+			}
+		})
+	}
+}
@@ -54,6 +54,10 @@ type Server struct {
 	listeners []Listener
 	incoming  chan incoming
 
+	// stopMetrics stops serving metrics over HTTP. It is nil if
+	// Config.MetricsAddress is empty.
+	stopMetrics context.CancelFunc
+
 	pmu sync.RWMutex
 	// p holds a map of all players currently connected to the server. When they
 	// leave, they are removed from the map.
@@ -111,9 +115,26 @@ func (srv *Server) Listen() {
 
 	srv.conf.Log.Info("Dragonfly server started.", "mc-version", protocol.CurrentVersion, "go-version", info.GoVersion, "commit", revision)
 	srv.startListening()
+	srv.serveMetrics()
 	go srv.wait()
 }
 
+// serveMetrics starts serving the metrics recorded by the Server if
+// Config.MetricsAddress is set.
+func (srv *Server) serveMetrics() {
+	if srv.conf.MetricsAddress == "" {
+		return
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	if err := srv.conf.Metrics.Serve(ctx, srv.conf.MetricsAddress); err != nil {
+		cancel()
+		srv.conf.Log.Error("serve metrics: " + err.Error())
+		return
+	}
+	srv.stopMetrics = cancel
+	srv.conf.Log.Info("Serving metrics.", "addr", srv.conf.MetricsAddress)
+}
+
 // Accept accepts incoming players into the server, returning an iterator that
 // yields players that join the server while blocking otherwise. The iterator
 // returned ends when the Server is closed using a call to Close. The loop body
@@ -346,6 +367,9 @@ func (srv *Server) close() {
 			srv.conf.Log.Error("Close listener: " + err.Error())
 		}
 	}
+	if srv.stopMetrics != nil {
+		srv.stopMetrics()
+	}
 }
 
 // listen makes the Server listen for new connections from the Listener passed.
@@ -575,6 +599,17 @@ func (srv *Server) createPlayer(id uuid.UUID, conn session.Conn, conf player.Con
 		QuitMessage:    srv.conf.QuitMessage,
 		HandleStop:     srv.handleSessionClose,
 		BlockRegistry:  w.BlockRegistry(),
+
+		ValidateMovement:  srv.conf.ValidateMovement,
+		MovementTolerance: srv.conf.MovementTolerance,
+
+		ValidateInteractions: srv.conf.ValidateInteractions,
+		ReachTolerance:       srv.conf.ReachTolerance,
+		LineOfSightTolerance: srv.conf.LineOfSightTolerance,
+		MaxClicksPerSecond:   srv.conf.MaxClicksPerSecond,
+		BreakTimeTolerance:   srv.conf.BreakTimeTolerance,
+
+		Metrics: srv.conf.Metrics,
 	}.New(conn)
 
 	conf.Name = conn.IdentityData().DisplayName
@@ -608,6 +643,7 @@ func (srv *Server) createWorld(dim world.Dimension, nether, end **world.World) *
 		ChunkLoadWorkers:    srv.conf.ChunkLoadWorkers,
 		Entities:            srv.conf.Entities,
 		Blocks:              srv.conf.Blocks,
+		Metrics:             srv.conf.Metrics,
 		PortalDestination: func(dim world.Dimension) *world.World {
 			switch dim {
 			case world.Nether:
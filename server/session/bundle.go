@@ -0,0 +1,160 @@
+package session
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+const (
+	// windowIDContainerRegistry is the window ID used to send the contents of dynamic containers, such as
+	// bundles, to the client.
+	windowIDContainerRegistry = 125
+	// bundleSlots is the amount of slots in the dynamic container of a bundle.
+	bundleSlots = 64
+)
+
+// bundleHolder holds a bundle item.Stack found in one of the inventories of a Session, together with a
+// function that replaces the stack in the place that it was found.
+type bundleHolder struct {
+	stack item.Stack
+	set   func(it item.Stack)
+}
+
+// sendBundleContents sends the contents of the bundle passed to the client, so that they are displayed in
+// the bundle. The stack network ID of the bundle, which is also sent as bundle_id in the NBT of the bundle,
+// is used as the dynamic container ID. Nothing happens if the stack passed is not a bundle.
+func (s *Session) sendBundleContents(it item.Stack) {
+	b, ok := it.Item().(item.Bundle)
+	if !ok {
+		return
+	}
+	id := item_id(it)
+
+	// The client now holds the contents as they are in the bundle, so any inventory created earlier for the
+	// bundle is outdated.
+	s.bundleMu.Lock()
+	delete(s.bundles, id)
+	s.bundleMu.Unlock()
+
+	content := make([]protocol.ItemInstance, bundleSlots)
+	for i, c := range b.Contents {
+		if i < bundleSlots {
+			content[i] = instanceFromItem(s.br, c)
+		}
+	}
+	s.writePacket(&packet.InventoryContent{
+		WindowID: windowIDContainerRegistry,
+		Content:  content,
+		Container: protocol.FullContainerName{
+			ContainerID:        protocol.ContainerDynamic,
+			DynamicContainerID: protocol.Option(uint32(id)),
+		},
+		StorageItem: instanceFromItem(s.br, it),
+	})
+	for _, c := range b.Contents {
+		s.sendBundleContents(c)
+	}
+}
+
+// bundleInventory returns an inventory holding the contents of the bundle with the dynamic container ID
+// passed. Changes made to the inventory are written back to the bundle. False is returned if the bundle
+// could not be found in any of the inventories of the Session.
+func (s *Session) bundleInventory(id uint32) (*inventory.Inventory, bool) {
+	s.bundleMu.Lock()
+	defer s.bundleMu.Unlock()
+
+	h, ok := s.findBundle(int32(id))
+	if !ok {
+		delete(s.bundles, int32(id))
+		return nil, false
+	}
+	if inv, ok := s.bundles[int32(id)]; ok {
+		return inv, true
+	}
+	inv := inventory.New(bundleSlots, nil)
+	for i, c := range h.stack.Item().(item.Bundle).Contents {
+		if i < bundleSlots {
+			_ = inv.SetItem(i, c)
+		}
+	}
+	inv.SlotFunc(func(int, item.Stack, item.Stack) {
+		if h, ok := s.findBundle(int32(id)); ok {
+			b := h.stack.Item().(item.Bundle)
+			b.Contents = inv.Items()
+			h.set(item_withItem(h.stack, b))
+		}
+	})
+	s.bundles[int32(id)] = inv
+	return inv, true
+}
+
+// verifyBundleSlot checks if the item.Stack passed may be put in the slot of the container passed. If the
+// container is the dynamic container of a bundle, the bundle must be able to store the stack, and the total
+// weight of the contents of the bundle must not exceed its capacity.
+func (s *Session) verifyBundleSlot(container protocol.FullContainerName, slot int, it item.Stack) error {
+	if container.ContainerID != protocol.ContainerDynamic || it.Empty() {
+		return nil
+	}
+	id, _ := container.DynamicContainerID.Value()
+	inv, ok := s.bundleInventory(id)
+	if !ok {
+		return fmt.Errorf("could not find bundle with id %v", id)
+	}
+	h, _ := s.findBundle(int32(id))
+	b := h.stack.Item().(item.Bundle)
+	b.Contents = nil
+	for i, c := range inv.Slots() {
+		if i != slot && !c.Empty() {
+			b.Contents = append(b.Contents, c)
+		}
+	}
+	if _, left := b.Insert(it); !left.Empty() {
+		return fmt.Errorf("bundle cannot hold %v", it)
+	}
+	return nil
+}
+
+// findBundle looks for the bundle with the stack network ID passed in the inventories of the Session,
+// including bundles that are stored in other bundles.
+func (s *Session) findBundle(id int32) (bundleHolder, bool) {
+	invs := []*inventory.Inventory{s.inv, s.offHand, s.ui}
+	if w := s.openedWindow.Load(); w != nil && s.containerOpened.Load() {
+		invs = append(invs, w)
+	}
+	for _, inv := range invs {
+		for slot, it := range inv.Slots() {
+			if h, ok := findBundleIn(id, it, func(it item.Stack) { _ = inv.SetItem(slot, it) }); ok {
+				return h, true
+			}
+		}
+	}
+	return bundleHolder{}, false
+}
+
+// findBundleIn looks for the bundle with the stack network ID passed in the item.Stack passed and the
+// bundles it holds. The set function passed is used to replace the item.Stack passed.
+func findBundleIn(id int32, it item.Stack, set func(it item.Stack)) (bundleHolder, bool) {
+	b, ok := it.Item().(item.Bundle)
+	if !ok {
+		return bundleHolder{}, false
+	}
+	if item_id(it) == id {
+		return bundleHolder{stack: it, set: set}, true
+	}
+	for i, c := range b.Contents {
+		h, ok := findBundleIn(id, c, func(c item.Stack) {
+			contents := slices.Clone(b.Contents)
+			contents[i] = c
+			set(item_withItem(it, item.Bundle{Colour: b.Colour, Dyed: b.Dyed, Contents: contents}))
+		})
+		if ok {
+			return h, true
+		}
+	}
+	return bundleHolder{}, false
+}
@@ -2,6 +2,7 @@ package session
 
 import (
 	"bytes"
+	"maps"
 
 	"github.com/cespare/xxhash/v2"
 	"github.com/df-mc/dragonfly/server/block/cube"
@@ -18,6 +19,7 @@ const subChunkRequests = true
 
 // ViewChunk ...
 func (s *Session) ViewChunk(pos world.ChunkPos, dim world.Dimension, blockEntities map[cube.Pos]world.Block, c *chunk.Chunk) {
+	c, blockEntities = s.viewLayerChunk(pos, c, blockEntities)
 	if !s.conn.ClientCacheEnabled() {
 		s.sendNetworkChunk(pos, dim, c, blockEntities)
 		return
@@ -31,21 +33,26 @@ func (s *Session) ViewSubChunks(centre world.SubChunkPos, offsets []protocol.Sub
 
 	entries := make([]protocol.SubChunkEntry, 0, len(offsets))
 	transaction := make(map[uint64]struct{})
+	chunks := make(map[world.ChunkPos]*chunk.Chunk)
+	blockEntities := make(map[world.ChunkPos]map[cube.Pos]world.Block)
 	for _, offset := range offsets {
 		ind := int16(centre.Y()) + int16(offset[1]) - int16(r[0])>>4
 		if ind < 0 || ind > int16(r.Height()>>4) {
 			entries = append(entries, protocol.SubChunkEntry{Result: protocol.SubChunkResultIndexOutOfBounds, Offset: offset})
 			continue
 		}
-		col, ok := s.chunkLoader.Chunk(world.ChunkPos{
-			centre.X() + int32(offset[0]),
-			centre.Z() + int32(offset[2]),
-		})
+		pos := world.ChunkPos{centre.X() + int32(offset[0]), centre.Z() + int32(offset[2])}
+		col, ok := s.chunkLoader.Chunk(pos)
 		if !ok {
 			entries = append(entries, protocol.SubChunkEntry{Result: protocol.SubChunkResultChunkNotFound, Offset: offset})
 			continue
 		}
-		entries = append(entries, s.subChunkEntry(offset, ind, col, transaction))
+		if _, ok := chunks[pos]; !ok {
+			// Block overrides of the view layer are applied once for every chunk, as multiple sub chunks of
+			// the same chunk are typically requested at once.
+			chunks[pos], blockEntities[pos] = s.viewLayerChunk(pos, col.Chunk, col.BlockEntities)
+		}
+		entries = append(entries, s.subChunkEntry(offset, ind, chunks[pos], blockEntities[pos], transaction))
 	}
 	if s.conn.ClientCacheEnabled() && len(transaction) > 0 {
 		s.blobMu.Lock()
@@ -61,7 +68,7 @@ func (s *Session) ViewSubChunks(centre world.SubChunkPos, offsets []protocol.Sub
 	})
 }
 
-func (s *Session) subChunkEntry(offset protocol.SubChunkOffset, ind int16, col *world.Column, transaction map[uint64]struct{}) protocol.SubChunkEntry {
+func (s *Session) subChunkEntry(offset protocol.SubChunkOffset, ind int16, col *chunk.Chunk, blockEntities map[cube.Pos]world.Block, transaction map[uint64]struct{}) protocol.SubChunkEntry {
 	chunkMap := col.HeightMap()
 	subMapType, subMap := byte(protocol.HeightMapDataHasData), make([]int8, 256)
 	higher, lower := true, true
@@ -101,11 +108,11 @@ func (s *Session) subChunkEntry(offset protocol.SubChunkOffset, ind int16, col *
 		}
 	}
 
-	serialisedSubChunk := chunk.EncodeSubChunk(col.Chunk, chunk.NetworkEncoding, int(ind))
+	serialisedSubChunk := chunk.EncodeSubChunk(col, chunk.NetworkEncoding, int(ind))
 
 	blockEntityBuf := bytes.NewBuffer(nil)
 	enc := nbt.NewEncoderWithEncoding(blockEntityBuf, nbt.NetworkLittleEndian)
-	for pos, b := range col.BlockEntities {
+	for pos, b := range blockEntities {
 		if n, ok := b.(world.NBTer); ok && col.SubIndex(int16(pos.Y())) == ind {
 			d := n.EncodeNBT()
 			d["x"], d["y"], d["z"] = int32(pos[0]), int32(pos[1]), int32(pos[2])
@@ -133,6 +140,34 @@ func (s *Session) subChunkEntry(offset protocol.SubChunkOffset, ind int16, col *
 	return entry
 }
 
+// viewLayerChunk applies the block overrides of the session's view layer in the chunk at the position passed
+// to the chunk and its block entities. If any overrides are present, a fork of the chunk is returned so that
+// the chunk of the world remains unchanged.
+func (s *Session) viewLayerChunk(pos world.ChunkPos, c *chunk.Chunk, blockEntities map[cube.Pos]world.Block) (*chunk.Chunk, map[cube.Pos]world.Block) {
+	if s.viewLayer == nil {
+		return c, blockEntities
+	}
+	overrides := s.viewLayer.ChunkBlocks(pos)
+	if len(overrides) == 0 {
+		return c, blockEntities
+	}
+	c, blockEntities = c.Fork(), maps.Clone(blockEntities)
+	if blockEntities == nil {
+		blockEntities = make(map[cube.Pos]world.Block)
+	}
+	for bp, b := range overrides {
+		if bp.OutOfBounds(c.Range()) {
+			continue
+		}
+		c.SetBlock(uint8(bp[0]), int16(bp[1]), uint8(bp[2]), 0, s.br.BlockRuntimeID(b))
+		delete(blockEntities, bp)
+		if _, ok := b.(world.NBTer); ok {
+			blockEntities[bp] = b
+		}
+	}
+	return c, blockEntities
+}
+
 // dimensionID returns the dimension ID of the world that the session is in.
 func (s *Session) dimensionID(dim world.Dimension) int32 {
 	d, _ := world.DimensionID(dim)
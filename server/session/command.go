@@ -2,8 +2,12 @@ package session
 
 import (
 	"math"
+	"slices"
+	"strings"
+	"sync"
 
 	"github.com/df-mc/dragonfly/server/cmd"
+	"github.com/df-mc/dragonfly/server/world"
 	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
@@ -105,7 +109,7 @@ func BuildAvailableCommands(
 					t |= protocol.CommandArgValid
 					if len(enum.Options) > 0 || enum.Type != "" {
 						_, dynamic := softEnums[enum.Type]
-						if !dynamic {
+						if !dynamic && !enum.Dynamic {
 							index, ok := enumIndices[enum.Type]
 							if !ok {
 								index = uint32(len(enums))
@@ -178,6 +182,8 @@ func (s *Session) sendAvailableCommands(co Controllable, softEnums map[string]st
 type commandEnum struct {
 	Type    string
 	Options []string
+	// Dynamic specifies if the enum is sent as a soft enum, regardless of whether its options changed.
+	Dynamic bool
 }
 
 // valueToParamType finds the command argument type of the value passed and returns it, in addition to creating
@@ -199,8 +205,18 @@ func valueToParamType(i cmd.ParamInfo, source cmd.Source) (t uint32, enum comman
 			Type:    "Boolean",
 			Options: []string{"true", "false"},
 		}
-	case mgl64.Vec3:
+	case mgl64.Vec3, cmd.Position:
 		return protocol.CommandArgTypePosition, enum
+	case cmd.Rotation:
+		return protocol.CommandArgTypeRValue, enum
+	case cmd.JSON:
+		return protocol.CommandArgTypeJSON, enum
+	case cmd.Duration:
+		return protocol.CommandArgTypeString, enum
+	case cmd.Block:
+		return 0, commandEnum{Type: "Block", Options: blockNames()}
+	case cmd.Item:
+		return 0, commandEnum{Type: "Item", Options: itemNames()}
 	case cmd.SubCommand:
 		return 0, commandEnum{
 			Type:    "SubCommand" + i.Name,
@@ -208,14 +224,40 @@ func valueToParamType(i cmd.ParamInfo, source cmd.Source) (t uint32, enum comman
 		}
 	}
 	if enum, ok := i.Value.(cmd.Enum); ok {
+		_, soft := enum.(cmd.SoftEnum)
 		return 0, commandEnum{
 			Type:    enum.Type(),
 			Options: enum.Options(source),
+			Dynamic: soft,
 		}
 	}
 	return protocol.CommandArgTypeRValue, enum
 }
 
+// blockNames returns the sorted names of all registered blocks without the 'minecraft:' prefix. The names
+// are computed once, after which the same slice is returned.
+var blockNames = sync.OnceValue(func() []string {
+	names := make([]string, 0, 1024)
+	for _, b := range world.Blocks() {
+		name, _ := b.EncodeBlock()
+		names = append(names, strings.TrimPrefix(name, "minecraft:"))
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+})
+
+// itemNames returns the sorted names of all registered items without the 'minecraft:' prefix. The names are
+// computed once, after which the same slice is returned.
+var itemNames = sync.OnceValue(func() []string {
+	names := make([]string, 0, 1024)
+	for _, it := range world.Items() {
+		name, _ := it.EncodeItem()
+		names = append(names, strings.TrimPrefix(name, "minecraft:"))
+	}
+	slices.Sort(names)
+	return slices.Compact(names)
+})
+
 // resendCommands resends all commands that a Session has access to if the map of runnable commands passed does not
 // match with the commands that the Session is currently allowed to execute.
 // True is returned if the commands were resent.
@@ -304,7 +346,8 @@ func (s *Session) resendEnums(
 			continue
 		}
 
-		if _, dynamic := softEnums[name]; dynamic {
+		_, dynamic := softEnums[name]
+		if _, soft := enum.(cmd.SoftEnum); dynamic || soft {
 			s.writePacket(&packet.UpdateSoftEnum{EnumType: name, Options: values, ActionType: packet.SoftEnumActionSet})
 		} else {
 			softEnums[name] = struct{}{}
@@ -1,6 +1,8 @@
 package session
 
 import (
+	"time"
+
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/entity/effect"
@@ -40,6 +42,9 @@ type Controllable interface {
 	SetHeldSlot(slot int) error
 
 	Move(deltaPos mgl64.Vec3, deltaYaw, deltaPitch float64)
+	// ReportMovementViolation reports movement claimed by the client that deviated from the movement
+	// predicted by the server. It returns true if the movement should be corrected.
+	ReportMovementViolation(v MovementViolation) bool
 
 	Speed() float64
 	FlightSpeed() float64
@@ -77,6 +82,11 @@ type Controllable interface {
 	EnchantmentSeed() int64
 	ResetEnchantmentSeed()
 
+	UnlockedRecipes() []string
+	DiscoverRecipes(it item.Stack)
+
+	Cooldowns() map[string]time.Duration
+
 	Respawn() *world.EntityHandle
 	Dead() bool
 
@@ -104,6 +114,12 @@ type Controllable interface {
 	ContinueBreaking(face cube.Face)
 	FinishBreaking()
 	AbortBreaking()
+	// BreakTime returns the time needed to break the block at the position passed with the item currently
+	// held.
+	BreakTime(pos cube.Pos) time.Duration
+	// ReportInteractionViolation reports an attack or block interaction of the client that failed validation.
+	// It returns true if the interaction should be cancelled.
+	ReportInteractionViolation(v InteractionViolation) bool
 
 	Exhaust(points float64)
 
@@ -0,0 +1,67 @@
+package session
+
+import (
+	"slices"
+	"strconv"
+
+	"github.com/df-mc/dragonfly/server/item"
+)
+
+// maxVanillaEnchantmentID is the highest enchantment ID known to the client. Enchantments registered with a
+// higher ID are custom enchantments, which the client is unable to display by itself.
+const maxVanillaEnchantmentID = 40
+
+// customEnchantment checks if the item.Enchantment passed has a type that is unknown to the client.
+func customEnchantment(e item.Enchantment) bool {
+	id, ok := item.EnchantmentID(e.Type())
+	return !ok || id > maxVanillaEnchantmentID
+}
+
+// enchantmentName returns the name of the item.Enchantment passed as displayed to the client, such as
+// 'Sharpness III'.
+func enchantmentName(e item.Enchantment) string {
+	if e.Type().MaxLevel() == 1 && e.Level() == 1 {
+		return e.Type().Name()
+	}
+	return e.Type().Name() + " " + romanNumeral(e.Level())
+}
+
+// customEnchantmentLore returns the lore lines that are added in front of the lore of the item.Stack passed
+// to display its custom enchantments to the client.
+func customEnchantmentLore(it item.Stack) []string {
+	var lore []string
+	for _, e := range it.Enchantments() {
+		if customEnchantment(e) {
+			lore = append(lore, "§r§7"+enchantmentName(e))
+		}
+	}
+	return lore
+}
+
+// stripCustomEnchantmentLore removes the lore lines added by customEnchantmentLore from an item.Stack
+// received from the client.
+func stripCustomEnchantmentLore(it item.Stack) item.Stack {
+	custom, lore := customEnchantmentLore(it), it.Lore()
+	if len(custom) == 0 || len(lore) < len(custom) || !slices.Equal(lore[:len(custom)], custom) {
+		return it
+	}
+	return it.WithLore(lore[len(custom):]...)
+}
+
+// romanNumeral returns the roman numeral representation of the number passed, as used for enchantment levels.
+// Numbers outside the range 1-39 are returned as regular numbers.
+func romanNumeral(n int) string {
+	if n < 1 || n >= 40 {
+		return strconv.Itoa(n)
+	}
+	s := ""
+	for _, v := range []struct {
+		value  int
+		symbol string
+	}{{10, "X"}, {9, "IX"}, {5, "V"}, {4, "IV"}, {1, "I"}} {
+		for ; n >= v.value; n -= v.value {
+			s += v.symbol
+		}
+	}
+	return s
+}
@@ -0,0 +1,100 @@
+package session
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/enchantment"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// testEnchantment is a custom enchantment type unknown to the client.
+type testEnchantment struct {
+	name     string
+	maxLevel int
+}
+
+func (e testEnchantment) Name() string                                      { return e.name }
+func (e testEnchantment) MaxLevel() int                                     { return e.maxLevel }
+func (testEnchantment) Cost(int) (int, int)                                 { return 1, 50 }
+func (testEnchantment) Rarity() item.EnchantmentRarity                      { return item.EnchantmentRarityCommon }
+func (testEnchantment) CompatibleWithEnchantment(item.EnchantmentType) bool { return true }
+func (testEnchantment) CompatibleWithItem(world.Item) bool                  { return true }
+
+var (
+	testVenom     = testEnchantment{name: "Venom", maxLevel: 5}
+	testSoulbound = testEnchantment{name: "Soulbound", maxLevel: 1}
+)
+
+func init() {
+	item.RegisterEnchantment(100, testVenom)
+	item.RegisterEnchantment(101, testSoulbound)
+}
+
+func TestCustomEnchantmentLore(t *testing.T) {
+	sword := item.NewStack(item.Sword{Tier: item.ToolTierDiamond}, 1)
+	tests := []struct {
+		name     string
+		stack    item.Stack
+		wantLore []string
+	}{
+		{name: "vanilla enchantment", stack: sword.WithEnchantments(item.NewEnchantment(enchantment.Sharpness, 3)).WithLore("lore")},
+		{name: "custom enchantment", stack: sword.WithEnchantments(item.NewEnchantment(testVenom, 3)), wantLore: []string{"§r§7Venom III"}},
+		{name: "custom enchantment with lore", stack: sword.WithEnchantments(item.NewEnchantment(testVenom, 4)).WithLore("first", "second"), wantLore: []string{"§r§7Venom IV"}},
+		{name: "single level", stack: sword.WithEnchantments(item.NewEnchantment(testSoulbound, 1)), wantLore: []string{"§r§7Soulbound"}},
+		{name: "mixed enchantments", stack: sword.WithEnchantments(item.NewEnchantment(enchantment.Sharpness, 5), item.NewEnchantment(testVenom, 1), item.NewEnchantment(testSoulbound, 1)).WithLore("lore"), wantLore: []string{"§r§7Venom I", "§r§7Soulbound"}},
+		{name: "level above maximum", stack: sword.WithEnchantments(item.NewEnchantment(testVenom, 40)), wantLore: []string{"§r§7Venom 40"}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			sent := stackFromItem(world.DefaultBlockRegistry, test.stack)
+			var lore []string
+			if display, ok := sent.NBTData["display"].(map[string]any); ok {
+				lore, _ = display["Lore"].([]string)
+			}
+			if want := append(slices.Clone(test.wantLore), test.stack.Lore()...); !slices.Equal(lore, want) {
+				t.Fatalf("expected lore %q to be sent, got %q", want, lore)
+			}
+
+			// The NBT is encoded and decoded like it is when sent over the network.
+			b, err := nbt.MarshalEncoding(sent.NBTData, nbt.NetworkLittleEndian)
+			if err != nil {
+				t.Fatalf("encode nbt: %v", err)
+			}
+			sent.NBTData = nil
+			if err := nbt.UnmarshalEncoding(b, &sent.NBTData, nbt.NetworkLittleEndian); err != nil {
+				t.Fatalf("decode nbt: %v", err)
+			}
+			received := stackToItem(world.DefaultBlockRegistry, sent)
+			if !slices.Equal(received.Lore(), test.stack.Lore()) {
+				t.Fatalf("expected lore %q after round trip, got %q", test.stack.Lore(), received.Lore())
+			}
+			if !received.Equal(test.stack) {
+				t.Fatalf("expected %v after round trip, got %v", test.stack, received)
+			}
+		})
+	}
+}
+
+func TestRomanNumeral(t *testing.T) {
+	tests := []struct {
+		n    int
+		want string
+	}{
+		{n: 0, want: "0"},
+		{n: 1, want: "I"},
+		{n: 4, want: "IV"},
+		{n: 9, want: "IX"},
+		{n: 14, want: "XIV"},
+		{n: 39, want: "XXXIX"},
+		{n: 40, want: "40"},
+		{n: -1, want: "-1"},
+	}
+	for _, test := range tests {
+		if got := romanNumeral(test.n); got != test.want {
+			t.Errorf("expected %v to be %q, got %q", test.n, test.want, got)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package session
 
 import (
+	"image/color"
 	"math"
 	"time"
 
@@ -139,8 +140,8 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 		m[protocol.EntityDataKeyDataChangeOnPickup] = float32(math.SmallestNonzeroFloat32)
 		m[protocol.EntityDataKeyDataChangeRate] = float32(math.SmallestNonzeroFloat32)
 
-		colour, am := effect.ResultingColour(c.Effects())
-		m[protocol.EntityDataKeyEffectColor] = nbtconv.Int32FromRGBA(colour)
+		_, am := effect.ResultingColour(c.Effects())
+		m[protocol.EntityDataKeyEffectColor] = nbtconv.Int32FromRGBA(c.Colour())
 		if am {
 			m[protocol.EntityDataKeyEffectAmbience] = byte(1)
 		} else {
@@ -158,8 +159,8 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 		m[protocol.EntityDataKeyPlayerHasDied] = boolByte(died)
 	}
 	if p, ok := e.(splash); ok {
-		m[protocol.EntityDataKeyAuxValueData] = int16(p.Potion().Uint8())
-		if tip := p.Potion().Uint8(); tip > 4 {
+		m[protocol.EntityDataKeyAuxValueData] = int16(p.Potion().Base().Uint8())
+		if tip := p.Potion().Base().Uint8(); tip > 4 {
 			m[protocol.EntityDataKeyCustomDisplay] = tip + 1
 		}
 	}
@@ -167,7 +168,7 @@ func (s *Session) addSpecificMetadata(e any, m protocol.EntityMetadata) {
 		var packedEffects int64
 
 		for _, ef := range eff.Effects() {
-			if !ef.ParticlesHidden() {
+			if _, ok := ef.Type().(effect.CustomType); !ok && !ef.ParticlesHidden() {
 				id, found := effect.ID(ef.Type())
 				if !found {
 					continue
@@ -296,6 +297,7 @@ type glint interface {
 type areaEffectCloud interface {
 	effectBearer
 	Radius() float64
+	Colour() color.RGBA
 }
 
 type onFire interface {
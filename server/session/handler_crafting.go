@@ -21,18 +21,23 @@ func (h *ItemStackRequestHandler) handleCraft(a *protocol.CraftRecipeStackReques
 	}
 	_, shaped := craft.(recipe.Shaped)
 	_, shapeless := craft.(recipe.Shapeless)
-	if !shaped && !shapeless {
+	_, userData := craft.(recipe.UserDataShapeless)
+	if !shaped && !shapeless && !userData {
 		return fmt.Errorf("recipe with network id %v is not a shaped or shapeless recipe", a.RecipeNetworkID)
 	}
 	if craft.Block() != "crafting_table" {
 		return fmt.Errorf("recipe with network id %v is not a crafting table recipe", a.RecipeNetworkID)
 	}
+	if _, bundle := craft.Output()[0].Item().(item.Bundle); userData && !bundle {
+		return fmt.Errorf("recipe with network id %v has unsupported user data output %v", a.RecipeNetworkID, craft.Output()[0])
+	}
 
 	timesCrafted := int(a.NumberOfCrafts)
 	if timesCrafted < 1 {
 		return fmt.Errorf("times crafted must be at least 1")
 	}
 
+	var inputs []item.Stack
 	size := s.craftingSize()
 	offset := s.craftingOffset()
 	consumed := make([]bool, size)
@@ -48,11 +53,12 @@ func (h *ItemStackRequestHandler) handleCraft(a *protocol.CraftRecipeStackReques
 				// We can't process this item, as it's not a part of the recipe.
 				continue
 			}
-			if !matchingStacks(has, expected) {
+			if !recipe.MatchItem(has, expected) {
 				// Not the same item.
 				continue
 			}
 			processed, consumed[slot-offset] = true, true
+			inputs = append(inputs, has)
 			st := has.Grow(-expected.Count() * timesCrafted)
 			h.setItemInSlot(protocol.StackRequestSlotInfo{
 				Container: protocol.FullContainerName{ContainerID: protocol.ContainerCraftingInput},
@@ -64,9 +70,25 @@ func (h *ItemStackRequestHandler) handleCraft(a *protocol.CraftRecipeStackReques
 			return fmt.Errorf("recipe %v: could not consume expected item: %v", a.RecipeNetworkID, expected)
 		}
 	}
+	if userData {
+		return h.createResults(s, tx, bundleOutput(craft.Output()[0], inputs))
+	}
 	return h.createResults(s, tx, repeatStacks(craft.Output(), timesCrafted)...)
 }
 
+// bundleOutput returns the bundle output of a user data shapeless recipe, such as a recipe dyeing a bundle.
+// The contents, name and other data of the bundle consumed by the recipe are retained in the output.
+func bundleOutput(output item.Stack, inputs []item.Stack) item.Stack {
+	b := output.Item().(item.Bundle)
+	for _, in := range inputs {
+		if old, ok := in.Item().(item.Bundle); ok {
+			b.Contents = old.Contents
+			return in.Grow(output.Count() - in.Count()).WithItem(b)
+		}
+	}
+	return output
+}
+
 // handleAutoCraft handles the AutoCraftRecipe request action.
 func (h *ItemStackRequestHandler) handleAutoCraft(a *protocol.AutoCraftRecipeStackRequestAction, s *Session, tx *world.Tx) error {
 	craft, ok := s.recipes[a.RecipeNetworkID]
@@ -96,7 +118,7 @@ func (h *ItemStackRequestHandler) handleAutoCraft(a *protocol.AutoCraftRecipeSta
 		}
 
 		if ind := slices.IndexFunc(flattenedInputs, func(it recipe.Item) bool {
-			return matchingStacks(it, i)
+			return recipe.MatchItem(it, i)
 		}); ind >= 0 {
 			flattenedInputs[ind] = grow(i, flattenedInputs[ind].Count())
 			continue
@@ -116,7 +138,7 @@ func (h *ItemStackRequestHandler) handleAutoCraft(a *protocol.AutoCraftRecipeSta
 					// We don't have this item, skip it.
 					continue
 				}
-				if !matchingStacks(has, expected) {
+				if !recipe.MatchItem(has, expected) {
 					// Not the same item.
 					continue
 				}
@@ -180,37 +202,6 @@ func (s *Session) craftingOffset() uint32 {
 	return craftingGridSmallOffset
 }
 
-// matchingStacks returns true if the two stacks are the same in a crafting scenario.
-func matchingStacks(has, expected recipe.Item) bool {
-	switch expected := expected.(type) {
-	case item.Stack:
-		switch has := has.(type) {
-		case recipe.ItemTag:
-			name, _ := expected.Item().EncodeItem()
-			return has.Contains(name)
-		case item.Stack:
-			_, variants := expected.Value("variants")
-			if !variants {
-				return has.Comparable(expected)
-			}
-			nameOne, _ := has.Item().EncodeItem()
-			nameTwo, _ := expected.Item().EncodeItem()
-			return nameOne == nameTwo
-		}
-		panic(fmt.Errorf("client has unexpected recipe item %T", has))
-	case recipe.ItemTag:
-		switch has := has.(type) {
-		case item.Stack:
-			name, _ := has.Item().EncodeItem()
-			return expected.Contains(name)
-		case recipe.ItemTag:
-			return has.Tag() == expected.Tag()
-		}
-		panic(fmt.Errorf("client has unexpected recipe item %T", has))
-	}
-	panic(fmt.Errorf("tried to match with unexpected recipe item %T", expected))
-}
-
 // repeatStacks multiplies the count of all item stacks provided by the number of repetitions provided. Item
 // stacks where the new count would exceed the item's max count are split into multiple item stacks.
 func repeatStacks(items []item.Stack, repetitions int) []item.Stack {
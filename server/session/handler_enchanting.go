@@ -5,6 +5,7 @@ import (
 	"math"
 	"math/rand/v2"
 	"slices"
+	"strings"
 
 	"github.com/df-mc/dragonfly/server/block"
 	"github.com/df-mc/dragonfly/server/block/cube"
@@ -107,8 +108,15 @@ func (s *Session) sendEnchantmentOptions(tx *world.Tx, c Controllable, pos cube.
 	options := make([]protocol.EnchantmentOption, 0, 3)
 	for i := 0; i < 3; i++ {
 		// First build the enchantment instances for each selected enchantment.
+		// Custom enchantments cannot be displayed by the client, so their names are shown as the name of the
+		// option instead.
 		enchants := make([]protocol.EnchantmentInstance, 0, len(selectedEnchants[i]))
+		var custom []string
 		for _, enchant := range selectedEnchants[i] {
+			if customEnchantment(enchant) {
+				custom = append(custom, enchantmentName(enchant))
+				continue
+			}
 			id, _ := item.EnchantmentID(enchant.Type())
 			enchants = append(enchants, protocol.EnchantmentInstance{
 				Type:  byte(id),
@@ -119,8 +127,12 @@ func (s *Session) sendEnchantmentOptions(tx *world.Tx, c Controllable, pos cube.
 		// Then build the enchantment option. We can use the slot as the RecipeNetworkID, since the IDs seem to be unique
 		// to enchanting tables only. We also only need to set the middle index of Enchantments. The other two serve
 		// an unknown purpose and can cause various unexpected issues.
+		name := enchantNames[rand.IntN(len(enchantNames))]
+		if len(custom) != 0 {
+			name = strings.Join(custom, ", ")
+		}
 		options = append(options, protocol.EnchantmentOption{
-			Name:            enchantNames[rand.IntN(len(enchantNames))],
+			Name:            name,
 			Cost:            uint8(selectedCosts[i]),
 			RecipeNetworkID: uint32(i),
 			Enchantments: protocol.ItemEnchantments{
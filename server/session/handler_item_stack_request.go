@@ -19,7 +19,7 @@ import (
 type ItemStackRequestHandler struct {
 	currentRequest int32
 
-	changes         map[byte]map[byte]changeInfo
+	changes         map[protocol.FullContainerName]map[byte]changeInfo
 	responseChanges map[int32]map[*inventory.Inventory]map[byte]responseChange
 
 	pendingResults []item.Stack
@@ -159,9 +159,14 @@ func (h *ItemStackRequestHandler) handleTransfer(from, to protocol.StackRequestS
 	if dest.Empty() {
 		dest = i.Grow(-math.MaxInt32)
 	}
+	if from.Container != to.Container {
+		if err := s.verifyBundleSlot(to.Container, int(to.Slot), dest.Grow(int(count))); err != nil {
+			return err
+		}
+	}
 
-	invA, _ := s.invByID(int32(from.Container.ContainerID), tx)
-	invB, _ := s.invByID(int32(to.Container.ContainerID), tx)
+	invA, _ := s.invByContainer(from.Container, tx)
+	invB, _ := s.invByContainer(to.Container, tx)
 
 	ctx := event.C(inventory.Holder(c))
 	_ = call(ctx, int(from.Slot), i.Grow(int(count)-i.Count()), invA.Handler().HandleTake)
@@ -184,8 +189,16 @@ func (h *ItemStackRequestHandler) handleSwap(a *protocol.SwapStackRequestAction,
 	i, _ := h.itemInSlot(a.Source, s, tx)
 	dest, _ := h.itemInSlot(a.Destination, s, tx)
 
-	invA, _ := s.invByID(int32(a.Source.Container.ContainerID), tx)
-	invB, _ := s.invByID(int32(a.Destination.Container.ContainerID), tx)
+	if a.Source.Container != a.Destination.Container {
+		if err := s.verifyBundleSlot(a.Source.Container, int(a.Source.Slot), dest); err != nil {
+			return err
+		}
+		if err := s.verifyBundleSlot(a.Destination.Container, int(a.Destination.Slot), i); err != nil {
+			return err
+		}
+	}
+	invA, _ := s.invByContainer(a.Source.Container, tx)
+	invB, _ := s.invByContainer(a.Destination.Container, tx)
 
 	ctx := event.C(inventory.Holder(c))
 	_ = call(ctx, int(a.Source.Slot), i, invA.Handler().HandleTake)
@@ -246,7 +259,7 @@ func (h *ItemStackRequestHandler) handleDrop(a *protocol.DropStackRequestAction,
 		return fmt.Errorf("client attempted to drop %v items, but only %v present", a.Count, i.Count())
 	}
 
-	inv, _ := s.invByID(int32(a.Source.Container.ContainerID), tx)
+	inv, _ := s.invByContainer(a.Source.Container, tx)
 	if err := call(event.C(inventory.Holder(c)), int(a.Source.Slot), i.Grow(int(a.Count)-i.Count()), inv.Handler().HandleDrop); err != nil {
 		return err
 	}
@@ -327,7 +340,7 @@ func (h *ItemStackRequestHandler) verifySlot(slot protocol.StackRequestSlotInfo,
 	if len(h.responseChanges) > 256 {
 		return fmt.Errorf("too many unacknowledged request slot changes")
 	}
-	inv, _ := s.invByID(int32(slot.Container.ContainerID), tx)
+	inv, _ := s.invByContainer(slot.Container, tx)
 
 	i, err := h.itemInSlot(slot, s, tx)
 	if err != nil {
@@ -371,7 +384,7 @@ func (h *ItemStackRequestHandler) resolveID(inv *inventory.Inventory, slot proto
 // info passed from the client has the right stack network ID in any of the stored slots. If this is the case,
 // that entry is removed, so that the maps are cleaned up eventually.
 func (h *ItemStackRequestHandler) tryAcknowledgeChanges(s *Session, tx *world.Tx, slot protocol.StackRequestSlotInfo) error {
-	inv, ok := s.invByID(int32(slot.Container.ContainerID), tx)
+	inv, ok := s.invByContainer(slot.Container, tx)
 	if !ok {
 		return fmt.Errorf("could not find container with id %v", slot.Container.ContainerID)
 	}
@@ -396,7 +409,7 @@ func (h *ItemStackRequestHandler) tryAcknowledgeChanges(s *Session, tx *world.Tx
 
 // itemInSlot looks for the item in the slot as indicated by the slot info passed.
 func (h *ItemStackRequestHandler) itemInSlot(slot protocol.StackRequestSlotInfo, s *Session, tx *world.Tx) (item.Stack, error) {
-	inv, ok := s.invByID(int32(slot.Container.ContainerID), tx)
+	inv, ok := s.invByContainer(slot.Container, tx)
 	if !ok {
 		return item.Stack{}, fmt.Errorf("unable to find container with ID %v", slot.Container.ContainerID)
 	}
@@ -415,7 +428,7 @@ func (h *ItemStackRequestHandler) itemInSlot(slot protocol.StackRequestSlotInfo,
 
 // setItemInSlot sets an item stack in the slot of a container present in the slot info.
 func (h *ItemStackRequestHandler) setItemInSlot(slot protocol.StackRequestSlotInfo, i item.Stack, s *Session, tx *world.Tx) {
-	inv, _ := s.invByID(int32(slot.Container.ContainerID), tx)
+	inv, _ := s.invByContainer(slot.Container, tx)
 
 	sl := int(slot.Slot)
 	if inv == s.offHand {
@@ -433,10 +446,10 @@ func (h *ItemStackRequestHandler) setItemInSlot(slot protocol.StackRequestSlotIn
 		DurabilityCorrection: int32(i.MaxDurability() - i.Durability()),
 	}
 
-	if h.changes[slot.Container.ContainerID] == nil {
-		h.changes[slot.Container.ContainerID] = map[byte]changeInfo{}
+	if h.changes[slot.Container] == nil {
+		h.changes[slot.Container] = map[byte]changeInfo{}
 	}
-	h.changes[slot.Container.ContainerID][slot.Slot] = changeInfo{
+	h.changes[slot.Container][slot.Slot] = changeInfo{
 		after:  respSlot,
 		before: before,
 	}
@@ -462,7 +475,7 @@ func (h *ItemStackRequestHandler) resolve(id int32, s *Session) {
 			slots = append(slots, slot.after)
 		}
 		info = append(info, protocol.StackResponseContainerInfo{
-			Container: protocol.FullContainerName{ContainerID: container},
+			Container: container,
 			SlotInfo:  slots,
 		})
 	}
@@ -472,7 +485,7 @@ func (h *ItemStackRequestHandler) resolve(id int32, s *Session) {
 		ContainerInfo: info,
 	}}})
 
-	h.changes = map[byte]map[byte]changeInfo{}
+	h.changes = map[protocol.FullContainerName]map[byte]changeInfo{}
 	h.pendingResults = nil
 }
 
@@ -488,12 +501,12 @@ func (h *ItemStackRequestHandler) reject(id int32, s *Session, tx *world.Tx) {
 	// Revert changes that we already made for valid actions.
 	for container, slots := range h.changes {
 		for slot, info := range slots {
-			inv, _ := s.invByID(int32(container), tx)
+			inv, _ := s.invByContainer(container, tx)
 			_ = inv.SetItem(int(slot), info.before)
 		}
 	}
 
-	h.changes = map[byte]map[byte]changeInfo{}
+	h.changes = map[protocol.FullContainerName]map[byte]changeInfo{}
 	h.pendingResults = nil
 }
 
@@ -0,0 +1,16 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// NetworkStackLatencyHandler handles the NetworkStackLatency packet.
+type NetworkStackLatencyHandler struct{}
+
+// Handle ...
+func (*NetworkStackLatencyHandler) Handle(p packet.Packet, s *Session, _ *world.Tx, _ Controllable) error {
+	pk := p.(*packet.NetworkStackLatency)
+	s.acknowledgeMenu(pk.Timestamp)
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
@@ -13,14 +14,14 @@ import (
 type PlayerActionHandler struct{}
 
 // Handle ...
-func (*PlayerActionHandler) Handle(p packet.Packet, s *Session, _ *world.Tx, c Controllable) error {
+func (*PlayerActionHandler) Handle(p packet.Packet, s *Session, tx *world.Tx, c Controllable) error {
 	pk := p.(*packet.PlayerAction)
 
-	return handlePlayerAction(pk.ActionType, pk.BlockFace, pk.BlockPosition, pk.EntityRuntimeID, s, c)
+	return handlePlayerAction(pk.ActionType, pk.BlockFace, pk.BlockPosition, pk.EntityRuntimeID, s, tx, c)
 }
 
 // handlePlayerAction handles an action performed by a player, found in packet.PlayerAction and packet.PlayerAuthInput.
-func handlePlayerAction(action int32, face int32, pos protocol.BlockPos, entityRuntimeID uint64, s *Session, c Controllable) error {
+func handlePlayerAction(action int32, face int32, pos protocol.BlockPos, entityRuntimeID uint64, s *Session, tx *world.Tx, c Controllable) error {
 	if entityRuntimeID != selfEntityRuntimeID {
 		return errSelfRuntimeID
 	}
@@ -34,12 +35,31 @@ func handlePlayerAction(action int32, face int32, pos protocol.BlockPos, entityR
 		defer s.swingingArm.Store(false)
 
 		s.breakingPos = cube.Pos{int(pos[0]), int(pos[1]), int(pos[2])}
+		if !s.allowBlockInteraction(tx, c, s.breakingPos, mgl64.Vec3{0.5, 0.5, 0.5}) {
+			c.AbortBreaking()
+			s.resendBlocks(tx, c, s.breakingPos, cube.Face(face))
+			return nil
+		}
+		if s.interaction != nil {
+			s.interaction.startBreaking(s.breakingPos)
+		}
 		c.StartBreaking(s.breakingPos, cube.Face(face))
-	case protocol.PlayerActionAbortBreak:
+	case protocol.PlayerActionAbortBreak, protocol.PlayerActionStopBreak:
+		// The client sends StopBreak when it releases the mouse button, which
+		// may be before the block is broken. Blocks are only broken on
+		// PredictDestroyBlock.
+		if s.interaction != nil {
+			s.interaction.abortBreaking()
+		}
 		c.AbortBreaking()
-	case protocol.PlayerActionPredictDestroyBlock, protocol.PlayerActionStopBreak:
+	case protocol.PlayerActionPredictDestroyBlock:
 		s.swingingArm.Store(true)
 		defer s.swingingArm.Store(false)
+		if !s.allowBreak(tx, c, s.breakingPos) {
+			c.AbortBreaking()
+			s.resendBlocks(tx, c, s.breakingPos)
+			return nil
+		}
 		c.FinishBreaking()
 	case protocol.PlayerActionCrackBreak:
 		// Don't do anything for this action. It is no longer used. Block
@@ -55,6 +75,9 @@ func handlePlayerAction(action int32, face int32, pos protocol.BlockPos, entityR
 	case protocol.PlayerActionMissedSwing:
 		s.swingingArm.Store(true)
 		defer s.swingingArm.Store(false)
+		if !s.allowClick(c) {
+			return nil
+		}
 		c.PunchAir()
 	default:
 		return fmt.Errorf("unhandled ActionType %v", action)
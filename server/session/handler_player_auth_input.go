@@ -18,14 +18,15 @@ type PlayerAuthInputHandler struct{}
 // Handle ...
 func (h PlayerAuthInputHandler) Handle(p packet.Packet, s *Session, tx *world.Tx, c Controllable) error {
 	pk := p.(*packet.PlayerAuthInput)
-	if err := h.handleMovement(pk, s, c); err != nil {
+	s.tickMenu()
+	if err := h.handleMovement(pk, s, tx, c); err != nil {
 		return err
 	}
 	return h.handleActions(pk, s, tx, c)
 }
 
 // handleMovement handles the movement part of the packet.PlayerAuthInput.
-func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Session, c Controllable) error {
+func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Session, tx *world.Tx, c Controllable) error {
 	yaw, pitch := c.Rotation().Elem()
 	pos := c.Position()
 
@@ -58,6 +59,19 @@ func (h PlayerAuthInputHandler) handleMovement(pk *packet.PlayerAuthInput, s *Se
 				return nil
 			}
 			s.teleportPos.Store(nil)
+			if s.movement != nil {
+				// The player was moved by the server, so the velocity it had before is no longer relevant.
+				s.movement.setVelocity(mgl64.Vec3{})
+			}
+		}
+	}
+	if s.movement != nil {
+		if v, ok := s.movement.validate(tx, c, newPos); ok && c.ReportMovementViolation(v) {
+			// Correct the player by teleporting it back to its last valid position. Movement is ignored until
+			// the client has received the teleport.
+			s.movement.setVelocity(mgl64.Vec3{})
+			s.ViewEntityTeleport(c, pos)
+			return nil
 		}
 	}
 
@@ -73,7 +87,7 @@ func (h PlayerAuthInputHandler) handleActions(pk *packet.PlayerAuthInput, s *Ses
 		if !ok {
 			return fmt.Errorf("item interaction flag set without item interaction data")
 		}
-		if err := h.handleUseItemData(data, s, c); err != nil {
+		if err := h.handleUseItemData(data, s, tx, c); err != nil {
 			return err
 		}
 	}
@@ -82,7 +96,7 @@ func (h PlayerAuthInputHandler) handleActions(pk *packet.PlayerAuthInput, s *Ses
 		if !ok {
 			return fmt.Errorf("block actions flag set without block actions")
 		}
-		if err := h.handleBlockActions(actions, s, c); err != nil {
+		if err := h.handleBlockActions(actions, s, tx, c); err != nil {
 			return err
 		}
 	}
@@ -162,7 +176,7 @@ func (h PlayerAuthInputHandler) handleInputFlags(flags protocol.InputFlags, s *S
 }
 
 // handleUseItemData handles the protocol.UseItemTransactionData found in a packet.PlayerAuthInput.
-func (h PlayerAuthInputHandler) handleUseItemData(data protocol.UseItemTransactionData, s *Session, c Controllable) error {
+func (h PlayerAuthInputHandler) handleUseItemData(data protocol.UseItemTransactionData, s *Session, tx *world.Tx, c Controllable) error {
 	s.swingingArm.Store(true)
 	defer s.swingingArm.Store(false)
 
@@ -176,6 +190,10 @@ func (h PlayerAuthInputHandler) handleUseItemData(data protocol.UseItemTransacti
 	// Seems like this is only used for breaking blocks at the moment.
 	switch data.ActionType {
 	case protocol.UseItemActionBreakBlock:
+		if !s.allowBreak(tx, c, pos) {
+			s.resendBlocks(tx, c, pos)
+			return nil
+		}
 		c.BreakBlock(pos)
 	default:
 		return fmt.Errorf("unhandled UseItem ActionType for PlayerAuthInput packet %v", data.ActionType)
@@ -184,9 +202,9 @@ func (h PlayerAuthInputHandler) handleUseItemData(data protocol.UseItemTransacti
 }
 
 // handleBlockActions handles a slice of protocol.PlayerBlockAction present in a PlayerAuthInput packet.
-func (h PlayerAuthInputHandler) handleBlockActions(a []protocol.PlayerBlockAction, s *Session, c Controllable) error {
+func (h PlayerAuthInputHandler) handleBlockActions(a []protocol.PlayerBlockAction, s *Session, tx *world.Tx, c Controllable) error {
 	for _, action := range a {
-		if err := handlePlayerAction(action.Action, action.Face, action.BlockPos, selfEntityRuntimeID, s, c); err != nil {
+		if err := handlePlayerAction(action.Action, action.Face, action.BlockPos, selfEntityRuntimeID, s, tx, c); err != nil {
 			return err
 		}
 	}
@@ -0,0 +1,27 @@
+package session
+
+import (
+	"fmt"
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// PlayerToggleCrafterSlotRequestHandler handles the PlayerToggleCrafterSlotRequest packet, sent when a player
+// disables or enables a slot of a crafter.
+type PlayerToggleCrafterSlotRequestHandler struct{}
+
+// Handle ...
+func (PlayerToggleCrafterSlotRequestHandler) Handle(p packet.Packet, _ *Session, tx *world.Tx, c Controllable) error {
+	pk := p.(*packet.PlayerToggleCrafterSlotRequest)
+	pos := cube.Pos{int(pk.PosX), int(pk.PosY), int(pk.PosZ)}
+	if !canReach(c, pos.Vec3Middle()) {
+		return fmt.Errorf("block at %v is not within reach", pos)
+	}
+	crafter, ok := tx.Block(pos).(block.Crafter)
+	if !ok {
+		return fmt.Errorf("block at %v is not a crafter", pos)
+	}
+	return crafter.ToggleSlot(pos, tx, int(pk.Slot), pk.Disabled)
+}
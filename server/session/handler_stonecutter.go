@@ -36,7 +36,7 @@ func (h *ItemStackRequestHandler) handleStonecutting(a *protocol.CraftRecipeStac
 	if input.Count() < timesCrafted {
 		return fmt.Errorf("input item count is less than number of crafts")
 	}
-	if !matchingStacks(input, expectedInputs[0]) {
+	if !recipe.MatchItem(input, expectedInputs[0]) {
 		return fmt.Errorf("input item is not the same as expected input")
 	}
 
@@ -0,0 +1,287 @@
+package session
+
+import (
+	"math"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/cube/trace"
+	"github.com/df-mc/dragonfly/server/entity"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// InteractionViolationType is the type of InteractionViolation, describing which check an interaction of a
+// client with the world failed.
+type InteractionViolationType int
+
+const (
+	// InteractionViolationReach is a violation where the client attacked an entity or clicked a block that is
+	// further away than it could reach.
+	InteractionViolationReach InteractionViolationType = iota
+	// InteractionViolationLineOfSight is a violation where the client attacked an entity or clicked a block
+	// while blocks obstructed its view of the target.
+	InteractionViolationLineOfSight
+	// InteractionViolationClickRate is a violation where the client attacked or swung its arm more often per
+	// second than allowed.
+	InteractionViolationClickRate
+	// InteractionViolationBreakTime is a violation where the client finished breaking a block faster than the
+	// block could be broken with the item held.
+	InteractionViolationBreakTime
+)
+
+// String ...
+func (t InteractionViolationType) String() string {
+	switch t {
+	case InteractionViolationReach:
+		return "reach"
+	case InteractionViolationLineOfSight:
+		return "line of sight"
+	case InteractionViolationClickRate:
+		return "click rate"
+	case InteractionViolationBreakTime:
+		return "break time"
+	}
+	return "unknown"
+}
+
+// InteractionViolation holds the details of an interaction of a client with the world that failed one of the
+// checks enabled in the Config of the Session.
+type InteractionViolation struct {
+	// Type is the type of the violation.
+	Type InteractionViolationType
+	// Target is the position of the entity attacked or the block interacted with. For violations of the type
+	// InteractionViolationClickRate caused by swinging at air, Target is the position of the player.
+	Target mgl64.Vec3
+	// Deviation is the amount by which the interaction exceeded the tolerance of the check. It is measured in
+	// blocks for reach and line of sight violations, in clicks for click rate violations and in seconds for
+	// break time violations.
+	Deviation float64
+}
+
+const (
+	// survivalEntityReach and creativeEntityReach are the distances in blocks from which players may attack
+	// entities in survival and creative mode respectively.
+	survivalEntityReach, creativeEntityReach = 3.0, 5.0
+	// survivalBlockReach and creativeBlockReach are the distances in blocks from which players may interact
+	// with blocks in survival and creative mode respectively.
+	survivalBlockReach, creativeBlockReach = 6.0, 12.0
+
+	// defaultReachTolerance is the tolerance used if Config.ReachTolerance is 0.
+	defaultReachTolerance = 0.5
+	// defaultLineOfSightTolerance is the tolerance used if Config.LineOfSightTolerance is 0.
+	defaultLineOfSightTolerance = 0.4
+	// defaultMaxClicksPerSecond is the maximum used if Config.MaxClicksPerSecond is 0.
+	defaultMaxClicksPerSecond = 20
+	// defaultBreakTimeTolerance is the tolerance used if Config.BreakTimeTolerance is 0.
+	defaultBreakTimeTolerance = time.Millisecond * 150
+)
+
+// interactionValidator validates the attacks, block interactions and block breaking of a Controllable. Its
+// state is only used by the goroutine handling packets.
+type interactionValidator struct {
+	reachTolerance, lineOfSightTolerance float64
+	maxClicks                            int
+	breakTolerance                       time.Duration
+
+	// clicks holds the times of the clicks of the last second.
+	clicks []time.Time
+
+	breaking   bool
+	breakPos   cube.Pos
+	breakStart time.Time
+}
+
+// validateAttack validates an attack of c on the entity e. If the attack could not have been performed, an
+// InteractionViolation is returned along with true.
+func (v *interactionValidator) validateAttack(tx *world.Tx, c Controllable, e world.Entity) (InteractionViolation, bool) {
+	if violation, ok := v.validateClick(c); ok {
+		return violation, true
+	}
+	box := e.H().Type().BBox(e).Translate(e.Position())
+	eye := entity.EyePosition(c)
+	closest := mgl64.Vec3{
+		mgl64.Clamp(eye[0], box.Min()[0], box.Max()[0]),
+		mgl64.Clamp(eye[1], box.Min()[1], box.Max()[1]),
+		mgl64.Clamp(eye[2], box.Min()[2], box.Max()[2]),
+	}
+	reach := survivalEntityReach
+	if c.GameMode().CreativeInventory() {
+		reach = creativeEntityReach
+	}
+	if dev := eye.Sub(closest).Len() - reach; dev > v.reachTolerance {
+		return InteractionViolation{Type: InteractionViolationReach, Target: e.Position(), Deviation: dev}, true
+	}
+	// The entity is visible if any of the points checked is not obstructed. The closest point alone is not
+	// enough, as it is often just behind the corner of a block.
+	centre := box.Min().Add(box.Max()).Mul(0.5)
+	top := mgl64.Vec3{centre[0], box.Max()[1] - 0.1, centre[2]}
+	dev := math.MaxFloat64
+	for _, point := range []mgl64.Vec3{closest, centre, top} {
+		if dev = min(dev, v.obstruction(tx, eye, point, cube.Pos{}, false)); dev <= v.lineOfSightTolerance {
+			return InteractionViolation{}, false
+		}
+	}
+	return InteractionViolation{Type: InteractionViolationLineOfSight, Target: e.Position(), Deviation: dev}, true
+}
+
+// validateClick validates a click of c, such as an attack or a swing at air. If c clicked more often than
+// allowed in the last second, an InteractionViolation is returned along with true.
+func (v *interactionValidator) validateClick(c Controllable) (InteractionViolation, bool) {
+	now := time.Now()
+	n := 0
+	for _, t := range v.clicks {
+		if now.Sub(t) < time.Second {
+			v.clicks[n] = t
+			n++
+		}
+	}
+	v.clicks = append(v.clicks[:n], now)
+	if len(v.clicks) > v.maxClicks {
+		return InteractionViolation{Type: InteractionViolationClickRate, Target: c.Position(), Deviation: float64(len(v.clicks) - v.maxClicks)}, true
+	}
+	return InteractionViolation{}, false
+}
+
+// validateBlock validates an interaction of c with the block at the position passed, such as clicking it or
+// starting to break it. clickPos is the position clicked, relative to the block. If the block could not have
+// been interacted with, an InteractionViolation is returned along with true.
+func (v *interactionValidator) validateBlock(tx *world.Tx, c Controllable, pos cube.Pos, clickPos mgl64.Vec3) (InteractionViolation, bool) {
+	eye := entity.EyePosition(c)
+	target := pos.Vec3().Add(clickPos)
+	reach := survivalBlockReach
+	if c.GameMode().CreativeInventory() {
+		reach = creativeBlockReach
+	}
+	if dev := eye.Sub(target).Len() - reach; dev > v.reachTolerance {
+		return InteractionViolation{Type: InteractionViolationReach, Target: target, Deviation: dev}, true
+	}
+	if dev := v.obstruction(tx, eye, target, pos, true); dev > v.lineOfSightTolerance {
+		return InteractionViolation{Type: InteractionViolationLineOfSight, Target: target, Deviation: dev}, true
+	}
+	return InteractionViolation{}, false
+}
+
+// startBreaking records c starting to break the block at the position passed, so that validateBreak can
+// check the time it took to break it.
+func (v *interactionValidator) startBreaking(pos cube.Pos) {
+	if v.breaking && v.breakPos == pos {
+		// The client sends the start of breaking again when continuing to break the same block.
+		return
+	}
+	v.breaking, v.breakPos, v.breakStart = true, pos, time.Now()
+}
+
+// abortBreaking records c stopping to break the block it was breaking.
+func (v *interactionValidator) abortBreaking() {
+	v.breaking = false
+}
+
+// validateBreak validates c finishing to break the block at the position passed. If the block was broken
+// faster than possible, an InteractionViolation is returned along with true.
+func (v *interactionValidator) validateBreak(tx *world.Tx, c Controllable, pos cube.Pos) (InteractionViolation, bool) {
+	var elapsed time.Duration
+	if v.breaking && v.breakPos == pos {
+		elapsed = time.Since(v.breakStart)
+	}
+	v.breaking = false
+	if c.GameMode().CreativeInventory() {
+		return InteractionViolation{}, false
+	}
+	if _, air := tx.Block(pos).(block.Air); air {
+		return InteractionViolation{}, false
+	}
+	if expected := c.BreakTime(pos); elapsed+v.breakTolerance < expected {
+		return InteractionViolation{Type: InteractionViolationBreakTime, Target: pos.Vec3Centre(), Deviation: (expected - elapsed).Seconds()}, true
+	}
+	return InteractionViolation{}, false
+}
+
+// obstruction returns the distance in blocks between the first block obstructing the line from start to end
+// and the end point. If nothing obstructs the line, 0 is returned. If skipTarget is true, the block at target
+// is not considered an obstruction.
+func (v *interactionValidator) obstruction(tx *world.Tx, start, end mgl64.Vec3, target cube.Pos, skipTarget bool) float64 {
+	if start.ApproxEqual(end) {
+		return 0
+	}
+	startPos := cube.PosFromVec3(start)
+	dev := 0.0
+	trace.TraverseBlocks(start, end, func(pos cube.Pos) bool {
+		if pos == startPos || (skipTarget && pos == target) {
+			return true
+		}
+		if res, ok := trace.BlockIntercept(pos, tx, tx.Block(pos), start, end); ok {
+			dev = res.Position().Sub(end).Len()
+			return false
+		}
+		return true
+	})
+	return dev
+}
+
+// resendBlocks resends the block at the position passed and the blocks on the sides of the faces passed, if
+// they are within the chunk radius of the Session, to undo changes predicted by the client.
+func (s *Session) resendBlocks(tx *world.Tx, c Controllable, pos cube.Pos, faces ...cube.Face) {
+	positions := []cube.Pos{pos}
+	for _, f := range faces {
+		positions = append(positions, pos.Side(f))
+	}
+	for _, p := range positions {
+		if p.Vec3Centre().Sub(c.Position()).Len() > float64(s.chunkRadius<<4) || p.OutOfBounds(tx.Range()) {
+			continue
+		}
+		b := tx.Block(p)
+		s.ViewBlockUpdate(p, b, 0)
+		if _, ok := b.(world.LiquidDisplacer); ok {
+			liq, _ := tx.Liquid(p)
+			s.ViewBlockUpdate(p, liq, 1)
+		}
+	}
+}
+
+// allowAttack checks if c may attack the entity e. If interaction validation is enabled and the attack fails
+// validation, the violation is reported and false is returned unless it is ignored.
+func (s *Session) allowAttack(tx *world.Tx, c Controllable, e world.Entity) bool {
+	if s.interaction == nil {
+		return true
+	}
+	v, ok := s.interaction.validateAttack(tx, c, e)
+	return !ok || !c.ReportInteractionViolation(v)
+}
+
+// allowClick checks if c may swing at air. If interaction validation is enabled and c clicked too often, the
+// violation is reported and false is returned unless it is ignored.
+func (s *Session) allowClick(c Controllable) bool {
+	if s.interaction == nil {
+		return true
+	}
+	v, ok := s.interaction.validateClick(c)
+	return !ok || !c.ReportInteractionViolation(v)
+}
+
+// allowBlockInteraction checks if c may interact with the block at the position passed by clicking it at
+// clickPos. If interaction validation is enabled and the interaction fails validation, the violation is
+// reported and false is returned unless it is ignored.
+func (s *Session) allowBlockInteraction(tx *world.Tx, c Controllable, pos cube.Pos, clickPos mgl64.Vec3) bool {
+	if s.interaction == nil {
+		return true
+	}
+	v, ok := s.interaction.validateBlock(tx, c, pos, clickPos)
+	return !ok || !c.ReportInteractionViolation(v)
+}
+
+// allowBreak checks if c may finish breaking the block at the position passed. If interaction validation is
+// enabled and breaking the block fails validation, the violation is reported and false is returned unless it
+// is ignored.
+func (s *Session) allowBreak(tx *world.Tx, c Controllable, pos cube.Pos) bool {
+	if s.interaction == nil {
+		return true
+	}
+	if !s.allowBlockInteraction(tx, c, pos, mgl64.Vec3{0.5, 0.5, 0.5}) {
+		s.interaction.abortBreaking()
+		return false
+	}
+	v, ok := s.interaction.validateBreak(tx, c, pos)
+	return !ok || !c.ReportInteractionViolation(v)
+}
@@ -0,0 +1,266 @@
+package session
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+)
+
+// testInteractor is a Controllable that only implements the methods used to validate interactions. It
+// records the violations reported and the breaking actions performed, and ignores none of the violations.
+type testInteractor struct {
+	Controllable
+	h         *world.EntityHandle
+	pos       mgl64.Vec3
+	mode      world.GameMode
+	breakTime time.Duration
+
+	violations      []InteractionViolation
+	finished, abort int
+}
+
+func (c *testInteractor) H() *world.EntityHandle            { return c.h }
+func (c *testInteractor) Position() mgl64.Vec3              { return c.pos }
+func (c *testInteractor) GameMode() world.GameMode          { return c.mode }
+func (c *testInteractor) BreakTime(cube.Pos) time.Duration  { return c.breakTime }
+func (c *testInteractor) StartBreaking(cube.Pos, cube.Face) {}
+func (c *testInteractor) FinishBreaking()                   { c.finished++ }
+func (c *testInteractor) AbortBreaking()                    { c.abort++ }
+func (c *testInteractor) ReportInteractionViolation(v InteractionViolation) bool {
+	c.violations = append(c.violations, v)
+	return false
+}
+
+// newTestInteractor returns a survival mode testInteractor at the position passed.
+func newTestInteractor(pos mgl64.Vec3) *testInteractor {
+	return &testInteractor{h: world.NewEntity(testMoverType{}, testMoverConfig{}), pos: pos, mode: world.GameModeSurvival}
+}
+
+// newTestValidator returns an interactionValidator with the default tolerances.
+func newTestValidator() *interactionValidator {
+	return &interactionValidator{
+		reachTolerance:       defaultReachTolerance,
+		lineOfSightTolerance: defaultLineOfSightTolerance,
+		maxClicks:            defaultMaxClicksPerSecond,
+		breakTolerance:       defaultBreakTimeTolerance,
+	}
+}
+
+func TestInteractionValidateBlock(t *testing.T) {
+	w := world.Config{}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	w.Do(func(tx *world.Tx) {
+		// A wall at x=3 between the player at x=0 and the block at x=5.
+		for y := 69; y <= 72; y++ {
+			tx.SetBlock(cube.Pos{3, y, 0}, block.Stone{}, nil)
+		}
+		tx.SetBlock(cube.Pos{5, 70, 0}, block.Stone{}, nil)
+		tx.SetBlock(cube.Pos{0, 70, 5}, block.Stone{}, nil)
+	}).Wait(context.Background())
+
+	tests := []struct {
+		name      string
+		mode      world.GameMode
+		pos       cube.Pos
+		violation bool
+		typ       InteractionViolationType
+	}{
+		{name: "close", mode: world.GameModeSurvival, pos: cube.Pos{0, 70, 5}},
+		{name: "survival reach", mode: world.GameModeSurvival, pos: cube.Pos{0, 70, -10}, violation: true, typ: InteractionViolationReach},
+		{name: "creative reach", mode: world.GameModeCreative, pos: cube.Pos{0, 70, -10}},
+		{name: "obstructed", mode: world.GameModeSurvival, pos: cube.Pos{5, 70, 0}, violation: true, typ: InteractionViolationLineOfSight},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestInteractor(mgl64.Vec3{0.5, 70.5, 0.5})
+			c.mode = test.mode
+			var (
+				v        InteractionViolation
+				violated bool
+			)
+			w.Do(func(tx *world.Tx) {
+				v, violated = newTestValidator().validateBlock(tx, c, test.pos, mgl64.Vec3{0.5, 0.5, 0.5})
+			}).Wait(context.Background())
+			if violated != test.violation {
+				t.Fatalf("expected violation %v, got %v (%+v)", test.violation, violated, v)
+			}
+			if violated && v.Type != test.typ {
+				t.Fatalf("expected %v violation, got %v", test.typ, v.Type)
+			}
+		})
+	}
+}
+
+func TestInteractionValidateAttack(t *testing.T) {
+	w := world.Config{}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	w.Do(func(tx *world.Tx) {
+		for y := 69; y <= 73; y++ {
+			for z := -1; z <= 1; z++ {
+				tx.SetBlock(cube.Pos{-2, y, z}, block.Stone{}, nil)
+			}
+		}
+	}).Wait(context.Background())
+
+	tests := []struct {
+		name      string
+		mode      world.GameMode
+		target    mgl64.Vec3
+		violation bool
+		typ       InteractionViolationType
+	}{
+		{name: "close", mode: world.GameModeSurvival, target: mgl64.Vec3{3.5, 70, 0.5}},
+		{name: "survival reach", mode: world.GameModeSurvival, target: mgl64.Vec3{5.5, 70, 0.5}, violation: true, typ: InteractionViolationReach},
+		{name: "creative reach", mode: world.GameModeCreative, target: mgl64.Vec3{5.5, 70, 0.5}},
+		{name: "obstructed", mode: world.GameModeSurvival, target: mgl64.Vec3{-2.5, 70, 0.5}, violation: true, typ: InteractionViolationLineOfSight},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestInteractor(mgl64.Vec3{0.5, 70.5, 0.5})
+			c.mode = test.mode
+			e := newTestInteractor(test.target)
+			var (
+				v        InteractionViolation
+				violated bool
+			)
+			w.Do(func(tx *world.Tx) {
+				v, violated = newTestValidator().validateAttack(tx, c, e)
+			}).Wait(context.Background())
+			if violated != test.violation {
+				t.Fatalf("expected violation %v, got %v (%+v)", test.violation, violated, v)
+			}
+			if violated && v.Type != test.typ {
+				t.Fatalf("expected %v violation, got %v", test.typ, v.Type)
+			}
+		})
+	}
+}
+
+func TestInteractionValidateClick(t *testing.T) {
+	tests := []struct {
+		name      string
+		clicks    int
+		deviation float64
+	}{
+		{name: "below maximum", clicks: 19},
+		{name: "at maximum", clicks: 20},
+		{name: "above maximum", clicks: 22, deviation: 2},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			v, c := newTestValidator(), newTestInteractor(mgl64.Vec3{})
+			var (
+				violation InteractionViolation
+				violated  bool
+			)
+			for range test.clicks {
+				violation, violated = v.validateClick(c)
+			}
+			if violated != (test.deviation > 0) {
+				t.Fatalf("expected violation %v, got %v", test.deviation > 0, violated)
+			}
+			if violated && (violation.Type != InteractionViolationClickRate || violation.Deviation != test.deviation) {
+				t.Fatalf("expected click rate violation with deviation %v, got %+v", test.deviation, violation)
+			}
+		})
+	}
+	// Clicks older than a second are forgotten.
+	v, c := newTestValidator(), newTestInteractor(mgl64.Vec3{})
+	for range 20 {
+		v.clicks = append(v.clicks, time.Now().Add(-time.Second*2))
+	}
+	if _, violated := v.validateClick(c); violated {
+		t.Fatalf("expected clicks older than a second to be forgotten")
+	}
+}
+
+func TestInteractionBreaking(t *testing.T) {
+	w := world.Config{}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	target := cube.Pos{1, 70, 0}
+	w.Do(func(tx *world.Tx) {
+		tx.SetBlock(target, block.Stone{}, nil)
+	}).Wait(context.Background())
+
+	tests := []struct {
+		name      string
+		mode      world.GameMode
+		breakTime time.Duration
+		actions   []int32
+		violation bool
+		finished  int
+		aborted   int
+	}{
+		{
+			name:     "instant block",
+			mode:     world.GameModeSurvival,
+			actions:  []int32{protocol.PlayerActionStartBreak, protocol.PlayerActionPredictDestroyBlock},
+			finished: 1,
+		},
+		{
+			name:      "too fast",
+			mode:      world.GameModeSurvival,
+			breakTime: time.Second,
+			actions:   []int32{protocol.PlayerActionStartBreak, protocol.PlayerActionPredictDestroyBlock},
+			violation: true,
+			finished:  1,
+		},
+		{
+			name:      "creative",
+			mode:      world.GameModeCreative,
+			breakTime: time.Second,
+			actions:   []int32{protocol.PlayerActionStartBreak, protocol.PlayerActionPredictDestroyBlock},
+			finished:  1,
+		},
+		{
+			name:      "early release",
+			mode:      world.GameModeSurvival,
+			breakTime: time.Second,
+			actions:   []int32{protocol.PlayerActionStartBreak, protocol.PlayerActionStopBreak},
+			aborted:   1,
+		},
+		{
+			name:      "abort",
+			mode:      world.GameModeSurvival,
+			breakTime: time.Second,
+			actions:   []int32{protocol.PlayerActionStartBreak, protocol.PlayerActionAbortBreak},
+			aborted:   1,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s := &Session{interaction: newTestValidator()}
+			c := newTestInteractor(mgl64.Vec3{0.5, 70, 0.5})
+			c.mode, c.breakTime = test.mode, test.breakTime
+			w.Do(func(tx *world.Tx) {
+				for _, action := range test.actions {
+					if err := handlePlayerAction(action, int32(cube.FaceUp), protocol.BlockPos{1, 70, 0}, selfEntityRuntimeID, s, tx, c); err != nil {
+						t.Errorf("handle action %v: %v", action, err)
+						return
+					}
+				}
+			}).Wait(context.Background())
+			if violated := len(c.violations) != 0; violated != test.violation {
+				t.Fatalf("expected violation %v, got %v", test.violation, c.violations)
+			}
+			if test.violation && c.violations[0].Type != InteractionViolationBreakTime {
+				t.Fatalf("expected break time violation, got %v", c.violations[0].Type)
+			}
+			if c.finished != test.finished {
+				t.Fatalf("expected block to be finished breaking %v times, got %v", test.finished, c.finished)
+			}
+			if c.abort != test.aborted {
+				t.Fatalf("expected breaking to be aborted %v times, got %v", test.aborted, c.abort)
+			}
+			if test.aborted > 0 && s.interaction.breaking {
+				t.Fatalf("expected validator to stop tracking the block after aborting")
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package session
+
+import (
+	"maps"
+	"math"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/block/model"
+	"github.com/df-mc/dragonfly/server/player/menu"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/sandertv/gophertunnel/minecraft/protocol"
+	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
+)
+
+// openedMenu is a menu.Menu currently opened by a session, together with the positions of the blocks shown
+// client-side to open it.
+type openedMenu struct {
+	menu      *menu.Menu
+	positions []cube.Pos
+
+	// open sends the window of the menu to the client. For double chests, it is set until the window is
+	// opened by tickMenu, and it is nil otherwise.
+	open func()
+	// ack is the timestamp of the packet.NetworkStackLatency sent after the blocks of a double chest, and
+	// acked is true once the client responded to it.
+	ack   int64
+	acked bool
+}
+
+// OpenMenu opens a menu.Menu for the session. The blocks needed to open the window of the menu are shown at
+// the position passed for the session only, and the blocks of the world are shown again once the menu is
+// closed.
+func (s *Session) OpenMenu(m *menu.Menu, pos cube.Pos, tx *world.Tx) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.closeCurrentContainer(tx, false)
+
+	blocks := menuBlocks(m, pos)
+	om := &openedMenu{menu: m, positions: make([]cube.Pos, 0, len(blocks))}
+	for _, b := range blocks {
+		s.viewLayer.ViewBlock(b.pos, b)
+		om.positions = append(om.positions, b.pos)
+	}
+
+	nextID := s.nextWindowID()
+	s.containerOpened.Store(true)
+	s.openedWindow.Store(m.Inventory())
+	s.openedPos.Store(&pos)
+	s.openedMenu.Store(om)
+	m.AddViewer(s)
+
+	var containerType byte
+	switch m.Type() {
+	case menu.Hopper():
+		containerType = protocol.ContainerTypeHopper
+	case menu.Dispenser():
+		containerType = protocol.ContainerTypeDispenser
+	case menu.Dropper():
+		containerType = protocol.ContainerTypeDropper
+	}
+	s.openedContainerID.Store(uint32(containerType))
+
+	open := func() {
+		s.writePacket(&packet.ContainerOpen{
+			WindowID:                nextID,
+			ContainerType:           containerType,
+			ContainerPosition:       protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])},
+			ContainerEntityUniqueID: -1,
+		})
+		s.sendInv(m.Inventory(), uint32(nextID))
+	}
+	if m.Type() != menu.DoubleChest() {
+		open()
+		return
+	}
+	// The client pairs the two chests of a double chest in the first tick after it received both of them. If
+	// the window is opened before that, a single chest is shown instead. The client responds to the
+	// NetworkStackLatency only after handling the blocks sent before it, so the window is opened in the first
+	// tick after the response, regardless of the latency of the connection.
+	// Some clients respond with the timestamp divided by 1000, so the timestamp is a multiple of 1000.
+	om.open, om.ack = open, time.Now().UnixMilli()*1000
+	s.writePacket(&packet.NetworkStackLatency{Timestamp: om.ack, NeedsResponse: true})
+}
+
+// acknowledgeMenu handles a response of the client to a packet.NetworkStackLatency with the timestamp
+// passed. If it acknowledges the blocks of the double chest menu opened, the window is opened in the next tick.
+func (s *Session) acknowledgeMenu(timestamp int64) {
+	if om := s.openedMenu.Load(); om != nil && om.open != nil && (timestamp == om.ack || timestamp*1000 == om.ack) {
+		om.acked = true
+	}
+}
+
+// tickMenu is called every tick of the client. It opens the window of the double chest menu opened if the
+// client acknowledged its blocks.
+func (s *Session) tickMenu() {
+	if om := s.openedMenu.Load(); om != nil && om.acked && om.open != nil {
+		om.open()
+		om.open = nil
+	}
+}
+
+// closeMenu closes the menu.Menu currently opened by the session, if any, and shows the blocks of the world
+// at the positions of the blocks used to open it again. It returns false if no menu was opened.
+func (s *Session) closeMenu() bool {
+	om := s.openedMenu.Swap(nil)
+	if om == nil {
+		return false
+	}
+	om.menu.RemoveViewer(s)
+	for _, pos := range om.positions {
+		s.viewLayer.ViewPublicBlock(pos)
+	}
+	return true
+}
+
+// menuBlock is a block shown client-side to open the window of a menu.Menu. It is never placed in the world.
+type menuBlock struct {
+	pos        cube.Pos
+	name       string
+	properties map[string]any
+	nbt        map[string]any
+}
+
+// menuBlocks returns the blocks that need to be shown at the position passed to open the window of a
+// menu.Menu.
+func menuBlocks(m *menu.Menu, pos cube.Pos) []menuBlock {
+	nbt := func(id string) map[string]any {
+		d := map[string]any{"id": id}
+		if m.Name() != "" {
+			d["CustomName"] = m.Name()
+		}
+		return d
+	}
+	chest := func(pos cube.Pos) menuBlock {
+		return menuBlock{pos: pos, name: "minecraft:chest", properties: map[string]any{"minecraft:cardinal_direction": "north"}, nbt: nbt("Chest")}
+	}
+	switch m.Type() {
+	case menu.DoubleChest():
+		a, b := chest(pos), chest(pos.Side(cube.FaceEast))
+		a.nbt["pairx"], a.nbt["pairz"], a.nbt["pairlead"] = int32(b.pos[0]), int32(b.pos[2]), uint8(1)
+		b.nbt["pairx"], b.nbt["pairz"], b.nbt["pairlead"] = int32(a.pos[0]), int32(a.pos[2]), uint8(0)
+		return []menuBlock{a, b}
+	case menu.Hopper():
+		return []menuBlock{{pos: pos, name: "minecraft:hopper", properties: map[string]any{"facing_direction": int32(0), "toggle_bit": false}, nbt: nbt("Hopper")}}
+	case menu.Dispenser():
+		return []menuBlock{{pos: pos, name: "minecraft:dispenser", properties: map[string]any{"facing_direction": int32(1), "triggered_bit": uint8(0)}, nbt: nbt("Dispenser")}}
+	case menu.Dropper():
+		return []menuBlock{{pos: pos, name: "minecraft:dropper", properties: map[string]any{"facing_direction": int32(1), "triggered_bit": uint8(0)}, nbt: nbt("Dropper")}}
+	}
+	return []menuBlock{chest(pos)}
+}
+
+// EncodeBlock ...
+func (b menuBlock) EncodeBlock() (string, map[string]any) {
+	return b.name, b.properties
+}
+
+// Hash returns a hash that causes the runtime ID of the block to be looked up by its name and properties.
+func (menuBlock) Hash() (uint64, uint64) {
+	return 0, math.MaxUint64
+}
+
+// Model ...
+func (menuBlock) Model() world.BlockModel {
+	return model.Solid{}
+}
+
+// EncodeNBT returns a copy of the block entity data of the block, so that position fields may be added.
+func (b menuBlock) EncodeNBT() map[string]any {
+	return maps.Clone(b.nbt)
+}
+
+// DecodeNBT ...
+func (b menuBlock) DecodeNBT(map[string]any) any {
+	return b
+}
@@ -0,0 +1,77 @@
+package session
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/player/menu"
+)
+
+func TestMenuBlocks(t *testing.T) {
+	m, _ := menu.New(menu.DoubleChest(), "name", nil)
+	blocks := menuBlocks(m, cube.Pos{1, 2, 3})
+	if len(blocks) != 2 {
+		t.Fatalf("expected 2 blocks for double chest, got %v", len(blocks))
+	}
+	a, b := blocks[0], blocks[1]
+	if a.nbt["pairx"] != int32(b.pos[0]) || a.nbt["pairz"] != int32(b.pos[2]) || b.nbt["pairx"] != int32(a.pos[0]) || b.nbt["pairz"] != int32(a.pos[2]) {
+		t.Errorf("expected chests to be paired with each other, got %v and %v", a.nbt, b.nbt)
+	}
+	if a.nbt["pairlead"] == b.nbt["pairlead"] {
+		t.Errorf("expected exactly one chest to lead the pair")
+	}
+	if a.nbt["CustomName"] != "name" || b.nbt["CustomName"] != "name" {
+		t.Errorf("expected both chests to have the name of the menu")
+	}
+
+	for _, typ := range []menu.Type{menu.Chest(), menu.Hopper(), menu.Dispenser(), menu.Dropper()} {
+		m, _ := menu.New(typ, "", nil)
+		if blocks := menuBlocks(m, cube.Pos{}); len(blocks) != 1 {
+			t.Errorf("expected 1 block for %v, got %v", typ, len(blocks))
+		} else if _, ok := blocks[0].nbt["CustomName"]; ok {
+			t.Errorf("expected no custom name for menu without name")
+		}
+	}
+}
+
+func TestMenuDoubleChestOpening(t *testing.T) {
+	tests := []struct {
+		name    string
+		respond func(ack int64) int64
+		opened  bool
+	}{
+		{name: "acknowledged", respond: func(ack int64) int64 { return ack }, opened: true},
+		{name: "acknowledged divided", respond: func(ack int64) int64 { return ack / 1000 }, opened: true},
+		{name: "other timestamp", respond: func(ack int64) int64 { return ack + 1000 }},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			s, opened := &Session{}, 0
+			om := &openedMenu{open: func() { opened++ }, ack: 1_700_000_000_000_000}
+			s.openedMenu.Store(om)
+
+			s.tickMenu()
+			if opened != 0 {
+				t.Fatalf("expected window not to be opened before the client responded")
+			}
+			s.acknowledgeMenu(test.respond(om.ack))
+			if opened != 0 {
+				t.Fatalf("expected window to be opened in the tick after the response")
+			}
+			s.tickMenu()
+			s.tickMenu()
+			if want := map[bool]int{true: 1}[test.opened]; opened != want {
+				t.Fatalf("expected window to be opened %v times, got %v", want, opened)
+			}
+		})
+	}
+
+	// A response for a menu that was closed in the meantime does not open the next menu.
+	s, opened := &Session{}, 0
+	s.openedMenu.Store(&openedMenu{open: func() { opened++ }, ack: 2000})
+	s.acknowledgeMenu(1000)
+	s.tickMenu()
+	if opened != 0 {
+		t.Fatalf("expected response to previous menu to be ignored")
+	}
+}
@@ -0,0 +1,92 @@
+package session
+
+import (
+	"github.com/df-mc/dragonfly/server/metrics"
+)
+
+// sessionMetrics holds the metrics recorded by a Session. If Config.Metrics is nil, all metrics are nil and
+// values recorded are discarded.
+type sessionMetrics struct {
+	r    *metrics.Registry
+	name string
+
+	sessions                     *metrics.Gauge
+	packetsReceived, packetsSent *metrics.Counter
+	bytesReceived, bytesSent     *metrics.Counter
+}
+
+// newSessionMetrics creates the metrics of the Session of the player with the name passed in the
+// metrics.Registry r.
+func newSessionMetrics(r *metrics.Registry, name string) sessionMetrics {
+	m := sessionMetrics{r: r, name: name}
+	if r == nil {
+		return m
+	}
+	m.sessions = sessionCount(r).With()
+	m.packetsReceived = sessionPackets(r).With(name, "received")
+	m.packetsSent = sessionPackets(r).With(name, "sent")
+	m.bytesReceived = sessionBytes(r).With(name, "received")
+	m.bytesSent = sessionBytes(r).With(name, "sent")
+	m.sessions.Add(1)
+	return m
+}
+
+// byteCounter is implemented by a Conn that knows the encoded size of the packets read from and written to it.
+// The bytes sent and received by a Session are only recorded if its Conn implements byteCounter, so that
+// packets do not need to be encoded a second time to measure them.
+type byteCounter interface {
+	// CountBytes makes the Conn call f with the encoded size of every packet read from it (sent is false) or
+	// written to it (sent is true).
+	CountBytes(f func(n int, sent bool))
+}
+
+// received records a packet received from the client.
+func (m sessionMetrics) received() {
+	if m.r != nil {
+		m.packetsReceived.Inc()
+	}
+}
+
+// sent records a packet sent to the client.
+func (m sessionMetrics) sent() {
+	if m.r != nil {
+		m.packetsSent.Inc()
+	}
+}
+
+// countBytes records n bytes sent to or received from the client.
+func (m sessionMetrics) countBytes(n int, sent bool) {
+	if sent {
+		m.bytesSent.Add(float64(n))
+		return
+	}
+	m.bytesReceived.Add(float64(n))
+}
+
+// remove removes the metrics of the Session from the metrics.Registry, so that they are no longer exposed after
+// the Session is closed.
+func (m sessionMetrics) remove() {
+	if m.r == nil {
+		return
+	}
+	m.sessions.Add(-1)
+	for _, direction := range []string{"received", "sent"} {
+		sessionPackets(m.r).Delete(m.name, direction)
+		sessionBytes(m.r).Delete(m.name, direction)
+	}
+}
+
+// sessionCount returns the gauge of open sessions.
+func sessionCount(r *metrics.Registry) *metrics.GaugeVec {
+	return r.Gauge("dragonfly_sessions", "Number of sessions open.")
+}
+
+// sessionPackets returns the counter of packets sent and received by sessions.
+func sessionPackets(r *metrics.Registry) *metrics.CounterVec {
+	return r.Counter("dragonfly_session_packets_total", "Number of packets sent to and received from a player.", "player", "direction")
+}
+
+// sessionBytes returns the counter of bytes sent and received by sessions.
+func sessionBytes(r *metrics.Registry) *metrics.CounterVec {
+	return r.Counter("dragonfly_session_bytes_total", "Encoded size in bytes of the packets sent to and received from a player, before compression.", "player", "direction")
+}
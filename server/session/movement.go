@@ -0,0 +1,262 @@
+package session
+
+import (
+	"math"
+	"sync/atomic"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// MovementViolationType is the type of MovementViolation, describing how the movement claimed by a client
+// deviated from the movement predicted by the server.
+type MovementViolationType int
+
+const (
+	// MovementViolationSpeed is a violation where the client moved horizontally further than it could have.
+	MovementViolationSpeed MovementViolationType = iota
+	// MovementViolationVertical is a violation where the client moved upwards further than it could have, or
+	// did not fall while it should have, such as when flying without being allowed to.
+	MovementViolationVertical
+	// MovementViolationCollision is a violation where the client moved into a block that it collides with.
+	MovementViolationCollision
+)
+
+// String ...
+func (t MovementViolationType) String() string {
+	switch t {
+	case MovementViolationSpeed:
+		return "speed"
+	case MovementViolationVertical:
+		return "vertical"
+	case MovementViolationCollision:
+		return "collision"
+	}
+	return "unknown"
+}
+
+// MovementViolation holds the details of a movement claimed by a client that deviated from the movement
+// predicted by the server by more than the tolerance set in the Config of the Session.
+type MovementViolation struct {
+	// Type is the type of the violation.
+	Type MovementViolationType
+	// From is the position of the player before the movement, which is the position that the player is
+	// corrected to if the violation is not ignored.
+	From mgl64.Vec3
+	// Claimed is the position that the client claimed to have moved to.
+	Claimed mgl64.Vec3
+	// Deviation is the distance in blocks by which the claimed movement exceeded the movement predicted by the
+	// server.
+	Deviation float64
+}
+
+const (
+	// gravity is the downward acceleration of players per tick.
+	gravity = 0.08
+	// verticalDrag is the factor that the vertical velocity of players is multiplied with every tick.
+	verticalDrag = 0.98
+	// airFriction is the factor that the horizontal velocity of players in the air is multiplied with every
+	// tick.
+	airFriction = 0.91
+	// liquidFriction is the factor that the horizontal velocity of players in liquids is multiplied with every
+	// tick.
+	liquidFriction = 0.8
+	// jumpVelocity is the initial vertical velocity of a jump without jump boost.
+	jumpVelocity = 0.42
+	// sprintJumpBoost is the horizontal velocity added when jumping while sprinting.
+	sprintJumpBoost = 0.2
+	// stepHeight is the height of blocks that players automatically step up on.
+	stepHeight = 0.6
+	// defaultMovementTolerance is the tolerance used if Config.MovementTolerance is 0.
+	defaultMovementTolerance = 0.1
+)
+
+// movementSimulator predicts the movement of a Controllable to validate the movement that its client claims.
+// Apart from the velocity set by the server, its state is only used by the goroutine handling packets.
+type movementSimulator struct {
+	tolerance float64
+
+	vel mgl64.Vec3
+	// serverVel is the velocity last set by the server, such as through knock-back. It replaces the velocity
+	// of the player when validating the next movement.
+	serverVel atomic.Pointer[mgl64.Vec3]
+}
+
+// setVelocity sets the velocity of the player, as done by the server when sending its velocity to the client.
+func (m *movementSimulator) setVelocity(vel mgl64.Vec3) {
+	m.serverVel.Store(&vel)
+}
+
+// validate validates the movement of c from its current position to the position claimed. If the movement
+// could not have been made, a MovementViolation is returned along with true.
+func (m *movementSimulator) validate(tx *world.Tx, c Controllable, claimed mgl64.Vec3) (MovementViolation, bool) {
+	from := c.Position()
+	delta := claimed.Sub(from)
+	mode := c.GameMode()
+	box := c.H().Type().BBox(c)
+
+	prevVel := m.vel
+	if vel := m.serverVel.Swap(nil); vel != nil {
+		prevVel = *vel
+	}
+	m.vel = delta
+	wasOnGround := collides(tx, box.Translate(from).Extend(mgl64.Vec3{0, -0.05}))
+
+	if !mode.HasCollision() || c.Gliding() {
+		// Spectators move freely and gliding movement is too complex to predict reliably.
+		return MovementViolation{}, false
+	}
+	violation := MovementViolation{From: from, Claimed: claimed}
+	if !collides(tx, box.Translate(from)) && collides(tx, box.Translate(claimed).Grow(-0.05)) {
+		violation.Type, violation.Deviation = MovementViolationCollision, delta.Len()
+		return violation, true
+	}
+
+	var jumpBoost, levitating, slowFalling int
+	for _, e := range c.Effects() {
+		switch e.Type() {
+		case effect.JumpBoost:
+			jumpBoost = e.Level()
+		case effect.Levitation:
+			levitating = e.Level()
+		case effect.SlowFalling:
+			slowFalling = e.Level()
+		}
+	}
+	flying := c.Flying() && mode.AllowsFlying()
+	_, inLiquid := tx.Liquid(cube.PosFromVec3(from))
+	below := tx.Block(cube.PosFromVec3(from.Sub(mgl64.Vec3{0, 0.5})))
+
+	// Predict the maximum horizontal distance that the player could have moved this tick.
+	prevHorizontal := math.Hypot(prevVel[0], prevVel[2])
+	var maxHorizontal float64
+	switch {
+	case flying:
+		accel := c.FlightSpeed()
+		if c.Sprinting() {
+			accel *= 2
+		}
+		maxHorizontal = prevHorizontal*airFriction + accel
+	case inLiquid:
+		accel := 0.02
+		if c.Swimming() {
+			accel = c.Speed() * 0.4
+		}
+		maxHorizontal = prevHorizontal*liquidFriction + accel
+	case wasOnGround:
+		friction := 0.6
+		if f, ok := below.(interface{ Friction() float64 }); ok {
+			friction = f.Friction()
+		}
+		friction *= airFriction
+		maxHorizontal = prevHorizontal*friction + c.Speed()*0.16277136/(friction*friction*friction)
+		if delta[1] > 0 && c.Sprinting() {
+			maxHorizontal += sprintJumpBoost
+		}
+	default:
+		accel := 0.02
+		if c.Sprinting() {
+			accel = 0.026
+		}
+		maxHorizontal = prevHorizontal*airFriction + accel
+	}
+	if horizontal := math.Hypot(delta[0], delta[2]); horizontal > maxHorizontal {
+		// Never predict based on a velocity higher than the predicted one, so that small deviations every tick
+		// add up rather than being accepted one by one.
+		m.vel[0], m.vel[2] = m.vel[0]*maxHorizontal/horizontal, m.vel[2]*maxHorizontal/horizontal
+		if dev := horizontal - maxHorizontal; dev > m.tolerance {
+			violation.Type, violation.Deviation = MovementViolationSpeed, dev
+			return violation, true
+		}
+	}
+
+	if flying || inLiquid || levitating > 0 || climbable(tx.Block(cube.PosFromVec3(from))) {
+		// Vertical movement is not validated in these cases, as the player may move upwards freely.
+		return MovementViolation{}, false
+	}
+	if slowed(tx, box.Translate(from)) || slowed(tx, box.Translate(claimed)) {
+		// Blocks such as cobwebs change the velocity of players inside them, so their vertical movement is
+		// not predicted by gravity.
+		return MovementViolation{}, false
+	}
+	// Predict the maximum vertical distance that the player could have moved this tick.
+	var maxVertical float64
+	if wasOnGround {
+		maxVertical = max(jumpVelocity+0.1*float64(jumpBoost), stepHeight)
+		if bouncy(below) {
+			maxVertical = max(maxVertical, -prevVel[1])
+		}
+	} else {
+		maxVertical = (prevVel[1] - gravity) * verticalDrag
+		if slowFalling > 0 {
+			maxVertical = max(maxVertical, -0.01)
+		}
+	}
+	if delta[1] <= 0 && collides(tx, box.Translate(claimed).Extend(mgl64.Vec3{0, -0.05})) {
+		// The player landed on a block, which stopped it from falling any further.
+		maxVertical = max(maxVertical, 0)
+	}
+	m.vel[1] = math.Min(delta[1], maxVertical)
+	if dev := delta[1] - maxVertical; dev > m.tolerance {
+		violation.Type, violation.Deviation = MovementViolationVertical, dev
+		return violation, true
+	}
+	return MovementViolation{}, false
+}
+
+// collides checks if the cube.BBox passed intersects with the collision box of any block.
+func collides(tx *world.Tx, box cube.BBox) bool {
+	epsilon := mgl64.Vec3{mgl64.Epsilon, mgl64.Epsilon, mgl64.Epsilon}
+	low, high := cube.PosFromVec3(box.Min().Add(epsilon)), cube.PosFromVec3(box.Max().Sub(epsilon))
+	for x := low[0]; x <= high[0]; x++ {
+		for z := low[2]; z <= high[2]; z++ {
+			for y := low[1]; y <= high[1]; y++ {
+				pos := cube.Pos{x, y, z}
+				for _, bb := range tx.Block(pos).Model().BBox(pos, tx) {
+					if bb.Translate(pos.Vec3()).IntersectsWith(box) {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+// slowed checks if the cube.BBox passed intersects with a block that changes the velocity of entities inside
+// it, such as a cobweb.
+func slowed(tx *world.Tx, box cube.BBox) bool {
+	low, high := cube.PosFromVec3(box.Min()), cube.PosFromVec3(box.Max())
+	for x := low[0]; x <= high[0]; x++ {
+		for z := low[2]; z <= high[2]; z++ {
+			for y := low[1]; y <= high[1]; y++ {
+				switch tx.Block(cube.Pos{x, y, z}).(type) {
+				case block.Cobweb:
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
+
+// climbable checks if a world.Block may be climbed by players.
+func climbable(b world.Block) bool {
+	switch b.(type) {
+	case block.Ladder, block.Vines:
+		return true
+	}
+	return false
+}
+
+// bouncy checks if a world.Block makes players bounce when they land on it.
+func bouncy(b world.Block) bool {
+	switch b.(type) {
+	case block.Slime, block.Bed:
+		return true
+	}
+	return false
+}
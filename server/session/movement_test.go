@@ -0,0 +1,79 @@
+package session
+
+import (
+	"context"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/entity/effect"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// testMoverType is a world.EntityType with the bounding box of a player.
+type testMoverType struct{ world.EntityType }
+
+func (testMoverType) BBox(world.Entity) cube.BBox { return cube.Box(-0.3, 0, -0.3, 0.3, 1.8, 0.3) }
+
+type testMoverConfig struct{}
+
+func (testMoverConfig) Apply(*world.EntityData) {}
+
+// testMover is a survival mode Controllable that only implements the methods used to validate movement.
+type testMover struct {
+	Controllable
+	h   *world.EntityHandle
+	pos mgl64.Vec3
+}
+
+func (m *testMover) H() *world.EntityHandle   { return m.h }
+func (m *testMover) Position() mgl64.Vec3     { return m.pos }
+func (m *testMover) GameMode() world.GameMode { return world.GameModeSurvival }
+func (m *testMover) Effects() []effect.Effect { return nil }
+func (m *testMover) Gliding() bool            { return false }
+func (m *testMover) Flying() bool             { return false }
+func (m *testMover) Sprinting() bool          { return false }
+func (m *testMover) Swimming() bool           { return false }
+func (m *testMover) Speed() float64           { return 0.1 }
+func (m *testMover) FlightSpeed() float64     { return 0.05 }
+
+// fall validates the movement of a player falling from the position passed, where its vertical velocity is
+// multiplied by 0.05 every tick that it is inside a cobweb, and returns the first violation found.
+func fall(w *world.World, from mgl64.Vec3, ticks int) (v MovementViolation, violated bool) {
+	c := &testMover{h: world.NewEntity(testMoverType{}, testMoverConfig{}), pos: from}
+	m := &movementSimulator{tolerance: defaultMovementTolerance}
+	w.Do(func(tx *world.Tx) {
+		var vel float64
+		for range ticks {
+			vel = (vel - gravity) * verticalDrag
+			if _, ok := tx.Block(cube.PosFromVec3(c.pos)).(block.Cobweb); ok {
+				vel *= 0.05
+			}
+			claimed := c.pos.Add(mgl64.Vec3{0, vel})
+			if v, violated = m.validate(tx, c, claimed); violated {
+				return
+			}
+			c.pos = claimed
+		}
+	}).Wait(context.Background())
+	return v, violated
+}
+
+func TestMovementFallThroughCobweb(t *testing.T) {
+	w := world.Config{}.New()
+	t.Cleanup(func() { _ = w.Close() })
+	from := mgl64.Vec3{0.5, 70, 0.5}
+
+	if _, violated := fall(w, from, 40); violated {
+		t.Fatalf("falling without cobwebs was reported as a violation")
+	}
+	w.Do(func(tx *world.Tx) {
+		for y := 60; y <= 66; y++ {
+			tx.SetBlock(cube.Pos{0, y, 0}, block.Cobweb{}, nil)
+		}
+	}).Wait(context.Background())
+	if v, violated := fall(w, from, 40); violated {
+		t.Fatalf("falling through cobwebs was reported as a %v violation at %v", v.Type, v.Claimed)
+	}
+}
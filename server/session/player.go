@@ -28,7 +28,6 @@ import (
 	"github.com/df-mc/dragonfly/server/world/sound"
 	"github.com/go-gl/mathgl/mgl32"
 	"github.com/go-gl/mathgl/mgl64"
-	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 	"github.com/sandertv/gophertunnel/minecraft/protocol/packet"
 )
@@ -65,11 +64,19 @@ func (s *Session) StartShowingEntity(e world.Entity) {
 	}
 }
 
+// CloseContainer closes the container or menu.Menu currently opened by the session, if any.
+func (s *Session) CloseContainer(tx *world.Tx) {
+	s.closeCurrentContainer(tx, false)
+}
+
 // closeCurrentContainer closes the container the player might currently have open.
 func (s *Session) closeCurrentContainer(tx *world.Tx, clientRequested bool) {
 	if !s.closeWindow(clientRequested) {
 		return
 	}
+	if s.closeMenu() {
+		return
+	}
 
 	pos := *s.openedPos.Load()
 	b := tx.Block(pos)
@@ -130,7 +137,7 @@ func (s *Session) sendRecipes() {
 		switch i := i.(type) {
 		case recipe.Shapeless:
 			shapelessRecipes = append(shapelessRecipes, protocol.ShapelessRecipe{
-				RecipeID:        uuid.New().String(),
+				RecipeID:        recipe.Name(i),
 				Priority:        int32(i.Priority()),
 				Input:           stacksToIngredientItems(s.br, i.Input()),
 				Output:          stacksToRecipeStacks(s.br, i.Output()),
@@ -139,7 +146,7 @@ func (s *Session) sendRecipes() {
 			})
 		case recipe.UserDataShapeless:
 			userDataShapelessRecipes = append(userDataShapelessRecipes, protocol.UserDataShapelessRecipe{ShapelessRecipe: protocol.ShapelessRecipe{
-				RecipeID:        uuid.New().String(),
+				RecipeID:        recipe.Name(i),
 				Priority:        int32(i.Priority()),
 				Input:           stacksToIngredientItems(s.br, i.Input()),
 				Output:          stacksToRecipeStacks(s.br, i.Output()),
@@ -153,7 +160,7 @@ func (s *Session) sendRecipes() {
 			})
 		case recipe.Shaped:
 			shapedRecipes = append(shapedRecipes, protocol.ShapedRecipe{
-				RecipeID:        uuid.New().String(),
+				RecipeID:        recipe.Name(i),
 				Priority:        int32(i.Priority()),
 				Width:           int32(i.Shape().Width()),
 				Height:          int32(i.Shape().Height()),
@@ -166,7 +173,7 @@ func (s *Session) sendRecipes() {
 		case recipe.SmithingTransform:
 			input, output := stacksToIngredientItems(s.br, i.Input()), stacksToRecipeStacks(s.br, i.Output())
 			smithingTransformRecipes = append(smithingTransformRecipes, protocol.SmithingTransformRecipe{
-				RecipeID:        uuid.New().String(),
+				RecipeID:        recipe.Name(i),
 				Base:            input[0],
 				Addition:        input[1],
 				Template:        input[2],
@@ -177,7 +184,7 @@ func (s *Session) sendRecipes() {
 		case recipe.SmithingTrim:
 			input := stacksToIngredientItems(s.br, i.Input())
 			smithingTrimRecipes = append(smithingTrimRecipes, protocol.SmithingTrimRecipe{
-				RecipeID:        uuid.New().String(),
+				RecipeID:        recipe.Name(i),
 				Base:            input[0],
 				Addition:        input[1],
 				Template:        input[2],
@@ -223,6 +230,39 @@ func (s *Session) sendRecipes() {
 	})
 }
 
+// sendUnlockedRecipes sends the names of the recipes unlocked by the player when joining. The client only shows
+// recipes in its recipe book after they have been unlocked.
+func (s *Session) sendUnlockedRecipes(names []string) {
+	s.writePacket(&packet.UnlockedRecipes{
+		UnlockType: packet.UnlockedRecipesTypeInitiallyUnlocked,
+		Recipes:    names,
+	})
+}
+
+// SendRecipesUnlocked sends the names of recipes newly unlocked by the player, so that they are shown in the
+// recipe book of the client.
+func (s *Session) SendRecipesUnlocked(names []string) {
+	if len(names) == 0 {
+		return
+	}
+	s.writePacket(&packet.UnlockedRecipes{
+		UnlockType: packet.UnlockedRecipesTypeNewlyUnlocked,
+		Recipes:    names,
+	})
+}
+
+// SendRecipesLocked sends the names of recipes that were locked again for the player, so that they are no
+// longer shown in the recipe book of the client.
+func (s *Session) SendRecipesLocked(names []string) {
+	if len(names) == 0 {
+		return
+	}
+	s.writePacket(&packet.UnlockedRecipes{
+		UnlockType: packet.UnlockedRecipesTypeRemoveUnlocked,
+		Recipes:    names,
+	})
+}
+
 // sendArmourTrimData sends the armour trim data.
 func (s *Session) sendArmourTrimData() {
 	var trimPatterns []protocol.TrimPattern
@@ -264,6 +304,9 @@ func (s *Session) sendInv(inv *inventory.Inventory, windowID uint32) {
 		pk.Content = append(pk.Content, instanceFromItem(s.br, i))
 	}
 	s.writePacket(pk)
+	for _, i := range inv.Slots() {
+		s.sendBundleContents(i)
+	}
 }
 
 // sendItem sends the item stack passed to the client with the window ID and slot passed.
@@ -273,6 +316,7 @@ func (s *Session) sendItem(item item.Stack, slot int, windowID uint32) {
 		Slot:     uint32(slot),
 		NewItem:  instanceFromItem(s.br, item),
 	})
+	s.sendBundleContents(item)
 }
 
 const (
@@ -289,6 +333,19 @@ type smelter interface {
 	ResetExperience() int
 }
 
+// invByContainer attempts to return an inventory by the full container name passed. Unlike invByID, it also
+// returns the inventories of dynamic containers, such as those of bundles.
+func (s *Session) invByContainer(c protocol.FullContainerName, tx *world.Tx) (*inventory.Inventory, bool) {
+	if c.ContainerID == protocol.ContainerDynamic {
+		id, ok := c.DynamicContainerID.Value()
+		if !ok {
+			return nil, false
+		}
+		return s.bundleInventory(id)
+	}
+	return s.invByID(int32(c.ContainerID), tx)
+}
+
 // invByID attempts to return an inventory by the ID passed. If found, the inventory is returned and the bool
 // returned is true.
 func (s *Session) invByID(id int32, tx *world.Tx) (*inventory.Inventory, bool) {
@@ -319,6 +376,10 @@ func (s *Session) invByID(id int32, tx *world.Tx) (*inventory.Inventory, bool) {
 			if _, barrel := tx.Block(*s.openedPos.Load()).(block.Barrel); barrel {
 				return s.openedWindow.Load(), true
 			}
+		case protocol.ContainerCrafterLevelEntity:
+			if _, crafter := tx.Block(*s.openedPos.Load()).(block.Crafter); crafter {
+				return s.openedWindow.Load(), true
+			}
 		case protocol.ContainerBeaconPayment:
 			if _, beacon := tx.Block(*s.openedPos.Load()).(block.Beacon); beacon {
 				return s.ui, true
@@ -586,7 +647,11 @@ func (s *Session) SendHealth(health, max, absorption float64) {
 }
 
 // SendEffect sends an effects passed to the player.
+// Custom effects are not known to the client and are not sent.
 func (s *Session) SendEffect(e effect.Effect) {
+	if _, ok := e.Type().(effect.CustomType); ok {
+		return
+	}
 	s.SendEffectRemoval(e.Type())
 	id, _ := effect.ID(e.Type())
 	dur := e.Duration() / (time.Second / 20)
@@ -606,6 +671,9 @@ func (s *Session) SendEffect(e effect.Effect) {
 
 // SendEffectRemoval sends the removal of an effect passed.
 func (s *Session) SendEffectRemoval(e effect.Type) {
+	if _, ok := e.(effect.CustomType); ok {
+		return
+	}
 	id, ok := effect.ID(e)
 	if !ok {
 		panic(fmt.Sprintf("unregistered effect type %T", e))
@@ -661,6 +729,7 @@ func (s *Session) broadcastInvFunc(tx *world.Tx, c Controllable) inventory.SlotF
 		if !s.inTransaction.Load() {
 			s.sendItem(after, slot, protocol.WindowIDInventory)
 		}
+		c.DiscoverRecipes(after)
 	}
 }
 
@@ -685,7 +754,9 @@ func (s *Session) broadcastOffHandFunc(tx *world.Tx, c Controllable) inventory.S
 				WindowID: protocol.WindowIDOffHand,
 				Content:  []protocol.ItemInstance{instanceFromItem(s.br, i)},
 			})
+			s.sendBundleContents(i)
 		}
+		c.DiscoverRecipes(after)
 	}
 }
 
@@ -842,6 +913,60 @@ func (s *Session) HudElementHidden(e hud.Element) bool {
 	return ok && !vis
 }
 
+const (
+	// borderRenderDistance is the distance in blocks from a world.Border at
+	// which the border is rendered to a player.
+	borderRenderDistance = 8.0
+	// borderParticle and borderWarningParticle are the particles that a
+	// world.Border is rendered with. The latter is used if the player is
+	// warned about the border.
+	borderParticle, borderWarningParticle = "minecraft:endrod", "minecraft:redstone_wire_dust_particle"
+)
+
+// ViewWorldBorder renders the sides of the world.Border passed that are close
+// to the player at the position passed. Bedrock Edition has no world border,
+// so the sides are drawn as a grid of particles around the player. The
+// particles are red if the player is warned about the border.
+func (s *Session) ViewWorldBorder(b world.Border, pos mgl64.Vec3, dim world.Dimension) {
+	if s == Nop || !b.Enabled() {
+		return
+	}
+	name := borderParticle
+	if b.Warns(pos) {
+		name = borderWarningParticle
+	}
+	minB, maxB := b.Bounds()
+	// Each side is described by the axis it is perpendicular to (0 for X, 1
+	// for Z) and the coordinate of the side on that axis.
+	sides := [4]struct {
+		axis  int
+		coord float64
+	}{{0, minB[0]}, {0, maxB[0]}, {1, minB[1]}, {1, maxB[1]}}
+
+	for _, side := range sides {
+		// along is the index of the horizontal axis that runs along the side and
+		// alongB the index of the same axis in the bounds of the border.
+		perp, along, alongB := [2]int{0, 2}[side.axis], [2]int{2, 0}[side.axis], [2]int{1, 0}[side.axis]
+		if math.Abs(pos[perp]-side.coord) > borderRenderDistance {
+			continue
+		}
+		from := math.Max(math.Floor(pos[along])-borderRenderDistance, minB[alongB])
+		to := math.Min(math.Floor(pos[along])+borderRenderDistance, maxB[alongB])
+		for h := from; h <= to; h += 2 {
+			for y := math.Floor(pos[1]) - 4; y <= pos[1]+6; y += 2 {
+				var p mgl64.Vec3
+				p[perp], p[along], p[1] = side.coord, h, y
+				s.writePacket(&packet.SpawnParticleEffect{
+					Dimension:      byte(s.dimensionID(dim)),
+					EntityUniqueID: -1,
+					Position:       vec64To32(p),
+					ParticleName:   name,
+				})
+			}
+		}
+	}
+}
+
 // SendHudUpdates sends any pending HUD updates to the player. The updates are batched to reduce the number
 // of packets being sent. Up to 2 packets will be sent, one for showing elements and one for hiding elements.
 func (s *Session) SendHudUpdates() {
@@ -1010,6 +1135,20 @@ func stackFromItem(br world.BlockRegistry, it item.Stack) protocol.ItemStack {
 
 	rid, meta, _ := world.ItemRuntimeID(it.Item())
 
+	nbt := item.WriteNBT(it, false)
+	if _, ok := it.Item().(item.Bundle); ok {
+		// The bundle ID links the bundle to the dynamic container that its contents are sent in.
+		nbt["bundle_id"] = item_id(it)
+	}
+	if lore := customEnchantmentLore(it); len(lore) != 0 {
+		// The client is unable to display custom enchantments, so their names are added to the lore instead.
+		display, ok := nbt["display"].(map[string]any)
+		if !ok {
+			display = map[string]any{}
+			nbt["display"] = display
+		}
+		display["Lore"] = append(lore, it.Lore()...)
+	}
 	return protocol.ItemStack{
 		ItemType: protocol.ItemType{
 			NetworkID:     rid,
@@ -1017,7 +1156,7 @@ func stackFromItem(br world.BlockRegistry, it item.Stack) protocol.ItemStack {
 		},
 		Count:          uint16(it.Count()),
 		BlockRuntimeID: int32(blockRuntimeID),
-		NBTData:        item.WriteNBT(it, false),
+		NBTData:        nbt,
 	}
 }
 
@@ -1041,7 +1180,7 @@ func stackToItem(br world.BlockRegistry, it protocol.ItemStack) item.Stack {
 		t = nbter.DecodeNBT(it.NBTData).(world.Item)
 	}
 	s := item.NewStack(t, int(it.Count))
-	return item.ReadNBT(it.NBTData, &s)
+	return stripCustomEnchantmentLore(item.ReadNBT(it.NBTData, &s))
 }
 
 // instanceFromItem converts an item.Stack to its network ItemInstance representation.
@@ -1337,3 +1476,8 @@ func gameTypeFromMode(mode world.GameMode) int32 {
 //
 //go:linkname item_id github.com/df-mc/dragonfly/server/item.id
 func item_id(s item.Stack) int32
+
+// noinspection ALL
+//
+//go:linkname item_withItem github.com/df-mc/dragonfly/server/item.withItem
+func item_withItem(s item.Stack, t world.Item) item.Stack
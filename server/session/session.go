@@ -1,6 +1,7 @@
 package session
 
 import (
+	"cmp"
 	"context"
 	"errors"
 	"fmt"
@@ -15,6 +16,7 @@ import (
 	"github.com/df-mc/dragonfly/server/cmd"
 	"github.com/df-mc/dragonfly/server/item/inventory"
 	"github.com/df-mc/dragonfly/server/item/recipe"
+	"github.com/df-mc/dragonfly/server/metrics"
 	"github.com/df-mc/dragonfly/server/player/chat"
 	"github.com/df-mc/dragonfly/server/player/debug"
 	"github.com/df-mc/dragonfly/server/player/form"
@@ -50,6 +52,12 @@ type Session struct {
 	emoteChatMuted bool
 
 	teleportPos atomic.Pointer[mgl64.Vec3]
+	// movement validates the movement of the Controllable. It is nil if Config.ValidateMovement is false.
+	movement *movementSimulator
+	// interaction validates the attacks and block interactions of the Controllable. It is nil if
+	// Config.ValidateInteractions is false.
+	interaction *interactionValidator
+	metrics     sessionMetrics
 
 	entityMutex sync.RWMutex
 	// currentEntityRuntimeID holds the runtime ID assigned to the last entity. It is incremented for every
@@ -77,6 +85,7 @@ type Session struct {
 	openedContainerID              atomic.Uint32
 	openedWindow                   atomic.Pointer[inventory.Inventory]
 	openedPos                      atomic.Pointer[cube.Pos]
+	openedMenu                     atomic.Pointer[openedMenu]
 	swingingArm                    atomic.Bool
 	changingSlot                   atomic.Bool
 	changingDimension              atomic.Bool
@@ -101,6 +110,11 @@ type Session struct {
 
 	viewLayer *world.ViewLayer
 
+	bundleMu sync.Mutex
+	// bundles holds the inventories of bundles that the client changed the contents of, indexed by the
+	// stack network ID of the bundle.
+	bundles map[int32]*inventory.Inventory
+
 	inputLocksMu sync.RWMutex
 	inputLocks   uint32
 
@@ -171,6 +185,39 @@ type Config struct {
 	HandleStop func(*world.Tx, Controllable)
 	// BlockRegistry overrides the registry used for network serialization. If nil, world.DefaultBlockRegistry is used.
 	BlockRegistry world.BlockRegistry
+
+	// ValidateMovement specifies if the movement claimed by the client should be validated by predicting it
+	// server-side, taking into account gravity, block collisions, effects and the abilities of the GameMode.
+	// If the claimed movement deviates from the prediction by more than MovementTolerance,
+	// Controllable.ReportMovementViolation is called and the player is teleported back, unless the violation is
+	// ignored.
+	ValidateMovement bool
+	// MovementTolerance is the distance in blocks that the claimed movement may deviate from the predicted
+	// movement in a single tick. If 0, a tolerance of 0.1 is used.
+	MovementTolerance float64
+
+	// ValidateInteractions specifies if attacks and block interactions of the client should be validated. Their
+	// reach, line of sight, click rate and the time taken to break blocks are checked against the tolerances
+	// below. If a check fails, Controllable.ReportInteractionViolation is called and the interaction is
+	// cancelled, unless the violation is ignored.
+	ValidateInteractions bool
+	// ReachTolerance is the distance in blocks that attacks and block interactions may exceed the reach of the
+	// player by. If 0, a tolerance of 0.5 is used.
+	ReachTolerance float64
+	// LineOfSightTolerance is the distance in blocks from the target within which blocks obstructing the view
+	// of the player are ignored. If 0, a tolerance of 0.4 is used.
+	LineOfSightTolerance float64
+	// MaxClicksPerSecond is the maximum number of attacks and swings at air that the client may perform within
+	// a second. If 0, a maximum of 20 is used.
+	MaxClicksPerSecond int
+	// BreakTimeTolerance is the duration that breaking a block may be faster than the time needed to break it
+	// with the item held. If 0, a tolerance of 150ms is used.
+	BreakTimeTolerance time.Duration
+
+	// Metrics is the metrics.Registry that the number and size of packets sent and received by the Session are
+	// recorded in. If nil, no metrics are recorded. The size of packets is only recorded if the Conn passed to
+	// New has a CountBytes(func(n int, sent bool)) method, as the Conns of the default Listener do.
+	Metrics *metrics.Registry
 }
 
 func (conf Config) New(conn Conn) *Session {
@@ -201,6 +248,7 @@ func (conf Config) New(conn Conn) *Session {
 		currentEntityRuntimeID: 1,
 		heldSlot:               new(uint32),
 		recipes:                make(map[uint32]recipe.Recipe),
+		bundles:                make(map[int32]*inventory.Inventory),
 		conf:                   conf,
 		hudUpdates:             make(map[hud.Element]bool),
 		hiddenHud:              make(map[hud.Element]struct{}),
@@ -208,6 +256,21 @@ func (conf Config) New(conn Conn) *Session {
 		debugShapeUpdates:      make([]debugShapeUpdate, 0, 256),
 	}
 	s.viewLayer = world.NewViewLayer(s)
+	s.metrics = newSessionMetrics(conf.Metrics, conn.IdentityData().DisplayName)
+	if bc, ok := conn.(byteCounter); ok && conf.Metrics != nil {
+		bc.CountBytes(s.metrics.countBytes)
+	}
+	if conf.ValidateMovement {
+		s.movement = &movementSimulator{tolerance: cmp.Or(conf.MovementTolerance, defaultMovementTolerance)}
+	}
+	if conf.ValidateInteractions {
+		s.interaction = &interactionValidator{
+			reachTolerance:       cmp.Or(conf.ReachTolerance, defaultReachTolerance),
+			lineOfSightTolerance: cmp.Or(conf.LineOfSightTolerance, defaultLineOfSightTolerance),
+			maxClicks:            cmp.Or(conf.MaxClicksPerSecond, defaultMaxClicksPerSecond),
+			breakTolerance:       cmp.Or(conf.BreakTimeTolerance, defaultBreakTimeTolerance),
+		}
+	}
 	s.openedWindow.Store(inventory.New(1, nil))
 	s.openedPos.Store(&cube.Pos{})
 
@@ -235,6 +298,7 @@ func (conf Config) New(conn Conn) *Session {
 			case <-s.closeBackground:
 				return
 			case pk := <-s.packets:
+				s.metrics.sent()
 				_ = conn.WritePacket(pk)
 			}
 		}
@@ -281,6 +345,14 @@ func (s *Session) Spawn(c Controllable, tx *world.Tx) {
 	s.sendInv(s.offHand, protocol.WindowIDOffHand)
 	s.sendInv(s.armour.Inventory(), protocol.WindowIDArmour)
 
+	s.sendUnlockedRecipes(c.UnlockedRecipes())
+	for category, d := range c.Cooldowns() {
+		s.ViewItemCooldown(category, d)
+	}
+	for _, it := range append(s.inv.Items(), s.offHand.Items()...) {
+		c.DiscoverRecipes(it)
+	}
+
 	chat.Global.Subscribe(c)
 	if !s.conf.JoinMessage.Zero() {
 		chat.Global.Writet(s.conf.JoinMessage, s.conn.IdentityData().DisplayName)
@@ -338,6 +410,7 @@ func (s *Session) close(tx *world.Tx, c Controllable) {
 	// This should always be called last due to the timing of the removal of
 	// entity runtime IDs.
 	sessions.Remove(s, c)
+	s.metrics.remove()
 	s.entityMutex.Lock()
 	clear(s.entityRuntimeIDs)
 	clear(s.entities)
@@ -411,6 +484,7 @@ func (s *Session) handlePackets() {
 		if err != nil {
 			return
 		}
+		s.metrics.received()
 		err = s.withControllable(context.Background(), func(tx *world.Tx, c Controllable) error {
 			return s.handlePacket(pk, tx, c)
 		})
@@ -580,39 +654,41 @@ func (s *Session) handlePacket(pk packet.Packet, tx *world.Tx, c Controllable) (
 // registerHandlers registers all packet handlers found in the packetHandler package.
 func (s *Session) registerHandlers() {
 	s.handlers = map[uint32]packetHandler{
-		packet.IDActorEvent:                nil,
-		packet.IDAdventureSettings:         nil, // Deprecated, the client still sends this though.
-		packet.IDAnimate:                   nil,
-		packet.IDAnvilDamage:               nil,
-		packet.IDBlockActorData:            &BlockActorDataHandler{},
-		packet.IDBlockPickRequest:          &BlockPickRequestHandler{},
-		packet.IDBookEdit:                  &BookEditHandler{},
-		packet.IDBossEvent:                 nil,
-		packet.IDClientCacheBlobStatus:     &ClientCacheBlobStatusHandler{},
-		packet.IDCommandRequest:            &CommandRequestHandler{},
-		packet.IDContainerClose:            &ContainerCloseHandler{},
-		packet.IDEmote:                     &EmoteHandler{},
-		packet.IDEmoteList:                 nil,
-		packet.IDFilterText:                nil,
-		packet.IDInteract:                  &InteractHandler{},
-		packet.IDInventoryTransaction:      &InventoryTransactionHandler{},
-		packet.IDItemStackRequest:          &ItemStackRequestHandler{changes: map[byte]map[byte]changeInfo{}, responseChanges: map[int32]map[*inventory.Inventory]map[byte]responseChange{}},
-		packet.IDLecternUpdate:             &LecternUpdateHandler{},
-		packet.IDMobEquipment:              &MobEquipmentHandler{},
-		packet.IDModalFormResponse:         &ModalFormResponseHandler{forms: make(map[uint32]form.Form)},
-		packet.IDMovePlayer:                nil,
-		packet.IDNPCRequest:                &NPCRequestHandler{},
-		packet.IDPlayerAction:              &PlayerActionHandler{},
-		packet.IDPlayerAuthInput:           &PlayerAuthInputHandler{},
-		packet.IDPlayerSkin:                &PlayerSkinHandler{},
-		packet.IDRequestAbility:            &RequestAbilityHandler{},
-		packet.IDRequestChunkRadius:        &RequestChunkRadiusHandler{},
-		packet.IDRespawn:                   &RespawnHandler{},
-		packet.IDSetPlayerInventoryOptions: nil,
-		packet.IDSubChunkRequest:           &SubChunkRequestHandler{},
-		packet.IDText:                      &TextHandler{},
-		packet.IDServerBoundLoadingScreen:  &ServerBoundLoadingScreenHandler{},
-		packet.IDServerBoundDiagnostics:    &ServerBoundDiagnosticsHandler{},
+		packet.IDActorEvent:                     nil,
+		packet.IDAdventureSettings:              nil, // Deprecated, the client still sends this though.
+		packet.IDAnimate:                        nil,
+		packet.IDAnvilDamage:                    nil,
+		packet.IDBlockActorData:                 &BlockActorDataHandler{},
+		packet.IDBlockPickRequest:               &BlockPickRequestHandler{},
+		packet.IDBookEdit:                       &BookEditHandler{},
+		packet.IDBossEvent:                      nil,
+		packet.IDClientCacheBlobStatus:          &ClientCacheBlobStatusHandler{},
+		packet.IDCommandRequest:                 &CommandRequestHandler{},
+		packet.IDContainerClose:                 &ContainerCloseHandler{},
+		packet.IDEmote:                          &EmoteHandler{},
+		packet.IDEmoteList:                      nil,
+		packet.IDFilterText:                     nil,
+		packet.IDInteract:                       &InteractHandler{},
+		packet.IDInventoryTransaction:           &InventoryTransactionHandler{},
+		packet.IDItemStackRequest:               &ItemStackRequestHandler{changes: map[protocol.FullContainerName]map[byte]changeInfo{}, responseChanges: map[int32]map[*inventory.Inventory]map[byte]responseChange{}},
+		packet.IDLecternUpdate:                  &LecternUpdateHandler{},
+		packet.IDMobEquipment:                   &MobEquipmentHandler{},
+		packet.IDModalFormResponse:              &ModalFormResponseHandler{forms: make(map[uint32]form.Form)},
+		packet.IDMovePlayer:                     nil,
+		packet.IDNetworkStackLatency:            &NetworkStackLatencyHandler{},
+		packet.IDNPCRequest:                     &NPCRequestHandler{},
+		packet.IDPlayerAction:                   &PlayerActionHandler{},
+		packet.IDPlayerAuthInput:                &PlayerAuthInputHandler{},
+		packet.IDPlayerSkin:                     &PlayerSkinHandler{},
+		packet.IDPlayerToggleCrafterSlotRequest: &PlayerToggleCrafterSlotRequestHandler{},
+		packet.IDRequestAbility:                 &RequestAbilityHandler{},
+		packet.IDRequestChunkRadius:             &RequestChunkRadiusHandler{},
+		packet.IDRespawn:                        &RespawnHandler{},
+		packet.IDSetPlayerInventoryOptions:      nil,
+		packet.IDSubChunkRequest:                &SubChunkRequestHandler{},
+		packet.IDText:                           &TextHandler{},
+		packet.IDServerBoundLoadingScreen:       &ServerBoundLoadingScreenHandler{},
+		packet.IDServerBoundDiagnostics:         &ServerBoundDiagnosticsHandler{},
 	}
 }
 
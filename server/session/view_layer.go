@@ -1,9 +1,23 @@
 package session
 
-import "github.com/df-mc/dragonfly/server/world"
+import (
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/item/inventory"
+	"github.com/df-mc/dragonfly/server/player/skin"
+	"github.com/df-mc/dragonfly/server/world"
+)
 
-// ViewLayer returns the session's ViewLayer. The layer may be used to override how entities are viewed
-// by this session, such as with a different name tag or visibility state.
+// heldItemsKey, armourKey and skinKey are the keys of the world.ViewLayer values holding the held items,
+// armour and skin overrides of an entity.
+type (
+	heldItemsKey struct{}
+	armourKey    struct{}
+	skinKey      struct{}
+)
+
+// ViewLayer returns the session's ViewLayer. The layer may be used to override how entities and blocks are
+// viewed by this session, such as with a different name tag, visibility state or block.
 func (s *Session) ViewLayer() *world.ViewLayer {
 	return s.viewLayer
 }
@@ -64,6 +78,96 @@ func (s *Session) ViewVisibility(entity world.Entity, level world.VisibilityLeve
 	s.viewLayer.ViewVisibility(entity, level)
 }
 
+// ViewScale overwrites the public scale of the entity and immediately refreshes it for this session.
+func (s *Session) ViewScale(entity world.Entity, scale float64) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewScale(entity, scale)
+}
+
+// ViewPublicScale removes the scale override from the entity and immediately refreshes it for this session.
+func (s *Session) ViewPublicScale(entity world.Entity) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewPublicScale(entity)
+}
+
+// ViewEntitySkin overwrites the public skin of the entity and immediately refreshes it for this session.
+func (s *Session) ViewEntitySkin(entity world.Entity, sk skin.Skin) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewValue(entity, skinKey{}, sk)
+}
+
+// ViewPublicEntitySkin removes the skin override from the entity and immediately refreshes it for this session.
+func (s *Session) ViewPublicEntitySkin(entity world.Entity) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewPublicValue(entity, skinKey{})
+}
+
+// ViewActions sets a function that decides which actions of the entity are shown to this session. Passing nil
+// shows all actions again.
+func (s *Session) ViewActions(entity world.Entity, f func(a world.EntityAction) bool) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewActions(entity, f)
+}
+
+// ViewHeldItems overwrites the items held by the entity and immediately refreshes them for this session.
+func (s *Session) ViewHeldItems(entity world.Entity, mainHand, offHand item.Stack) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewValue(entity, heldItemsKey{}, [2]item.Stack{mainHand, offHand})
+}
+
+// ViewPublicHeldItems removes the held items override from the entity and immediately refreshes them for this
+// session.
+func (s *Session) ViewPublicHeldItems(entity world.Entity) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewPublicValue(entity, heldItemsKey{})
+}
+
+// ViewArmour overwrites the armour worn by the entity and immediately refreshes it for this session.
+func (s *Session) ViewArmour(entity world.Entity, helmet, chestplate, leggings, boots item.Stack) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewValue(entity, armourKey{}, [4]item.Stack{helmet, chestplate, leggings, boots})
+}
+
+// ViewPublicArmour removes the armour override from the entity and immediately refreshes it for this session.
+func (s *Session) ViewPublicArmour(entity world.Entity) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewPublicValue(entity, armourKey{})
+}
+
+// ViewDisguise disguises the entity as an entity of the world.EntityType passed for this session.
+func (s *Session) ViewDisguise(entity world.Entity, t world.EntityType) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewDisguise(entity, t)
+}
+
+// ViewPublicDisguise removes the disguise override from the entity for this session.
+func (s *Session) ViewPublicDisguise(entity world.Entity) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewPublicDisguise(entity)
+}
+
 // RemoveViewLayer removes all overrides for the entity and immediately refreshes it for this session.
 func (s *Session) RemoveViewLayer(entity world.Entity) {
 	if s.viewLayer == nil {
@@ -72,12 +176,142 @@ func (s *Session) RemoveViewLayer(entity world.Entity) {
 	s.viewLayer.Remove(entity)
 }
 
-// ViewLayerEntityChanged refreshes the entity metadata for this session if the entity is currently visible.
+// ViewBlock overwrites the block at a position for this session and immediately sends it.
+func (s *Session) ViewBlock(pos cube.Pos, b world.Block) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewBlock(pos, b)
+}
+
+// ViewPublicBlock removes the block override at a position for this session and immediately sends the block
+// in the world again.
+func (s *Session) ViewPublicBlock(pos cube.Pos) {
+	if s.viewLayer == nil {
+		return
+	}
+	s.viewLayer.ViewPublicBlock(pos)
+}
+
+// ViewLayerBlockChanged sends the block at a position to this session if the chunk containing it is loaded.
+// The block override of the view layer is sent if present, otherwise the block in the world is sent.
+func (s *Session) ViewLayerBlockChanged(pos cube.Pos) {
+	if s.chunkLoader == nil {
+		return
+	}
+	col, ok := s.chunkLoader.Chunk(world.ChunkPos{int32(pos[0] >> 4), int32(pos[2] >> 4)})
+	if !ok || pos.OutOfBounds(col.Range()) {
+		return
+	}
+	b, ok := col.BlockEntities[pos]
+	if !ok {
+		b = s.br.BlockByRuntimeIDOrAir(col.Block(uint8(pos[0]), int16(pos[1]), uint8(pos[2]), 0))
+	}
+	s.ViewBlockUpdate(pos, b, 0)
+}
+
+// ViewLayerEntityChanged refreshes the entity metadata and equipment for this session if the entity is
+// currently visible.
 func (s *Session) ViewLayerEntityChanged(e world.Entity) {
 	if s.entityHidden(e) || !s.viewingEntity(e.H()) {
 		return
 	}
 	s.ViewEntityState(e)
+	s.ViewEntityItems(e)
+	s.ViewEntityArmour(e)
+}
+
+// ViewLayerValueChanged refreshes the skin of the entity for this session if its skin override changed and
+// the entity is currently visible. Other values are refreshed by ViewLayerEntityChanged.
+func (s *Session) ViewLayerValueChanged(e world.Entity, key any) {
+	if _, ok := key.(skinKey); !ok || s.entityHidden(e) || !s.viewingEntity(e.H()) {
+		return
+	}
+	s.ViewSkin(e)
+}
+
+// ViewLayerDisguiseChanged spawns the entity again for this session if the entity is currently visible, so
+// that it is shown with its new disguise.
+func (s *Session) ViewLayerDisguiseChanged(e world.Entity) {
+	s.RespawnEntity(e)
+}
+
+// RespawnEntity removes the entity passed for the session and spawns it again with its current state and
+// equipment, so that changes in the way it is shown, such as its disguise, are applied. Nothing happens if
+// the entity is the entity of the session itself or if it is not currently visible to the session.
+func (s *Session) RespawnEntity(e world.Entity) {
+	if e.H() == s.ent || s.entityHidden(e) || !s.viewingEntity(e.H()) {
+		return
+	}
+	s.HideEntity(e)
+	s.ViewEntity(e)
+	s.ViewEntityItems(e)
+	s.ViewEntityArmour(e)
+}
+
+// disguised is an entity that may be disguised as another world.EntityType for all viewers.
+type disguised interface {
+	Disguised() (world.EntityType, bool)
+}
+
+// entityDisguise returns the world.EntityType that the entity is shown as to the session and whether the
+// entity is disguised. A disguise set in the view layer takes precedence over a disguise of the entity
+// itself. The session's own entity is never disguised for the session. If the entity is not disguised, its
+// own world.EntityType is returned.
+func (s *Session) entityDisguise(e world.Entity) (world.EntityType, bool) {
+	if e.H() == s.ent {
+		return e.H().Type(), false
+	}
+	if s.viewLayer != nil {
+		if t, ok := s.viewLayer.Disguise(e); ok {
+			return t, true
+		}
+	}
+	if d, ok := e.(disguised); ok {
+		if t, ok := d.Disguised(); ok {
+			return t, true
+		}
+	}
+	return e.H().Type(), false
+}
+
+// viewedSkin returns the skin of the entity as viewed by the session.
+func (s *Session) viewedSkin(e world.Entity, public skin.Skin) skin.Skin {
+	if s.viewLayer != nil {
+		if sk, ok := s.viewLayer.Value(e, skinKey{}); ok {
+			return sk.(skin.Skin)
+		}
+	}
+	return public
+}
+
+// viewedHeldItems returns the items held by the entity as viewed by the session.
+func (s *Session) viewedHeldItems(e world.Entity, c item.Carrier) (mainHand, offHand item.Stack) {
+	if s.viewLayer != nil {
+		if v, ok := s.viewLayer.Value(e, heldItemsKey{}); ok {
+			items := v.([2]item.Stack)
+			return items[0], items[1]
+		}
+	}
+	return c.HeldItems()
+}
+
+// viewedArmour returns the helmet, chestplate, leggings and boots worn by the entity as viewed by the session. If
+// the entity has no armour and no override was set, false is returned.
+func (s *Session) viewedArmour(e world.Entity) ([4]item.Stack, bool) {
+	if s.viewLayer != nil {
+		if v, ok := s.viewLayer.Value(e, armourKey{}); ok {
+			return v.([4]item.Stack), true
+		}
+	}
+	armoured, ok := e.(interface {
+		Armour() *inventory.Armour
+	})
+	if !ok || armoured.Armour() == nil {
+		return [4]item.Stack{}, false
+	}
+	inv := armoured.Armour()
+	return [4]item.Stack{inv.Helmet(), inv.Chestplate(), inv.Leggings(), inv.Boots()}, true
 }
 
 // viewingEntity checks if this session currently has a runtime ID assigned to the entity handle.
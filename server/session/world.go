@@ -5,7 +5,6 @@ import (
 	"fmt"
 	"image/color"
 	"math/rand/v2"
-	"strings"
 	"time"
 
 	"github.com/df-mc/dragonfly/server/block"
@@ -76,9 +75,13 @@ func (s *Session) ViewEntity(e world.Entity) {
 	yaw, pitch := e.Rotation().Elem()
 	metadata := s.entityMetadata(e)
 
-	id := e.H().Type().EncodeEntity()
+	t, disguised := s.entityDisguise(e)
+	id := t.EncodeEntity()
 	switch v := e.(type) {
 	case Controllable:
+		if disguised {
+			break
+		}
 		_, actualPlayer := sessions.Lookup(v.UUID())
 		if !actualPlayer {
 			s.writePacket(&packet.PlayerList{Entries: []protocol.PlayerListEntry{{
@@ -87,7 +90,7 @@ func (s *Session) ViewEntity(e world.Entity) {
 				EntityUniqueID: int64(runtimeID),
 				Username:       v.Name(),
 				BuildPlatform:  int32(protocol.DeviceUnknown),
-				Skin:           skinToProtocol(v.Skin()),
+				Skin:           skinToProtocol(s.viewedSkin(e, v.Skin())),
 			}}})
 		}
 
@@ -120,6 +123,9 @@ func (s *Session) ViewEntity(e world.Entity) {
 		}
 		return
 	case *entity.Ent:
+		if disguised {
+			break
+		}
 		switch e.H().Type() {
 		case entity.ItemType:
 			s.writePacket(&packet.AddItemActor{
@@ -137,7 +143,10 @@ func (s *Session) ViewEntity(e world.Entity) {
 			metadata[protocol.EntityDataKeyVariant] = int32(s.br.BlockRuntimeID(v.Behaviour().(*entity.FallingBlockBehaviour).Block()))
 		}
 	}
-	if v, ok := e.H().Type().(NetworkEncodeableEntity); ok {
+	if d, ok := t.(entity.BlockDisguise); ok {
+		metadata[protocol.EntityDataKeyVariant] = int32(s.br.BlockRuntimeID(d.Block))
+	}
+	if v, ok := t.(NetworkEncodeableEntity); ok {
 		id = v.NetworkEncodeEntity()
 	}
 
@@ -223,7 +232,7 @@ func (s *Session) viewEntityAbsoluteMovement(id uint64, e world.Entity, pos mgl6
 	}
 	s.writePacket(&packet.MoveActorAbsolute{
 		EntityRuntimeID: id,
-		Position:        vec64To32(pos.Add(entityOffset(e))),
+		Position:        vec64To32(pos.Add(s.viewedEntityOffset(e))),
 		Rotation:        vec64To32(mgl64.Vec3{rot.Pitch(), rot.Yaw(), rot.Yaw()}),
 		Flags:           flags,
 	})
@@ -234,6 +243,9 @@ func (s *Session) ViewEntityVelocity(e world.Entity, velocity mgl64.Vec3) {
 	if s.entityHidden(e) {
 		return
 	}
+	if s.movement != nil && s.entityRuntimeID(e) == selfEntityRuntimeID {
+		s.movement.setVelocity(velocity)
+	}
 	s.writePacket(&packet.SetActorMotion{
 		EntityRuntimeID: s.entityRuntimeID(e),
 		Velocity:        vec64To32(velocity),
@@ -242,12 +254,24 @@ func (s *Session) ViewEntityVelocity(e world.Entity, velocity mgl64.Vec3) {
 
 // entityOffset returns the offset that entities have client-side.
 func entityOffset(e world.Entity) mgl64.Vec3 {
-	if offset, ok := e.H().Type().(OffsetEntity); ok {
+	return typeOffset(e.H().Type())
+}
+
+// typeOffset returns the offset that entities of a world.EntityType have client-side.
+func typeOffset(t world.EntityType) mgl64.Vec3 {
+	if offset, ok := t.(OffsetEntity); ok {
 		return mgl64.Vec3{0, offset.NetworkOffset()}
 	}
 	return mgl64.Vec3{}
 }
 
+// viewedEntityOffset returns the offset that an entity has client-side for the session, taking into account
+// the type that the entity may be disguised as.
+func (s *Session) viewedEntityOffset(e world.Entity) mgl64.Vec3 {
+	t, _ := s.entityDisguise(e)
+	return typeOffset(t)
+}
+
 // ViewTime ...
 func (s *Session) ViewTime(time int) {
 	s.writePacket(&packet.SetTime{Time: int32(time)})
@@ -271,7 +295,8 @@ func (s *Session) ViewEntityTeleport(e world.Entity, position mgl64.Vec3) {
 	}
 
 	s.writePacket(&packet.SetActorMotion{EntityRuntimeID: id})
-	if _, ok := e.(Controllable); ok {
+	_, disguised := s.entityDisguise(e)
+	if _, ok := e.(Controllable); ok && !disguised {
 		s.writePacket(&packet.MovePlayer{
 			EntityRuntimeID: id,
 			Position:        vec64To32(position.Add(entityOffset(e))),
@@ -287,7 +312,7 @@ func (s *Session) ViewEntityTeleport(e world.Entity, position mgl64.Vec3) {
 	}
 	s.writePacket(&packet.MoveActorAbsolute{
 		EntityRuntimeID: id,
-		Position:        vec64To32(position.Add(entityOffset(e))),
+		Position:        vec64To32(position.Add(s.viewedEntityOffset(e))),
 		Rotation:        vec64To32(mgl64.Vec3{pitch, yaw, yaw}),
 		Flags:           packet.MoveFlagTeleport,
 	})
@@ -305,7 +330,7 @@ func (s *Session) ViewEntityItems(e world.Entity) {
 		return
 	}
 
-	mainHand, offHand := c.HeldItems()
+	mainHand, offHand := s.viewedHeldItems(e, c)
 
 	// Show the main hand item.
 	s.writePacket(&packet.MobEquipment{
@@ -327,33 +352,26 @@ func (s *Session) ViewEntityArmour(e world.Entity) {
 		// Don't view the items of the entity if the entity is the Controllable entity of the session.
 		return
 	}
-	armoured, ok := e.(interface {
-		Armour() *inventory.Armour
-	})
+	armour, ok := s.viewedArmour(e)
 	if !ok {
 		return
 	}
 
-	inv := armoured.Armour()
-	if inv == nil {
-		return
-	}
-
 	// Show the entity's armour
 	s.writePacket(&packet.MobArmourEquipment{
 		EntityRuntimeID: runtimeID,
-		Helmet:          instanceFromItem(s.br, inv.Helmet()),
-		Chestplate:      instanceFromItem(s.br, inv.Chestplate()),
-		Leggings:        instanceFromItem(s.br, inv.Leggings()),
-		Boots:           instanceFromItem(s.br, inv.Boots()),
+		Helmet:          instanceFromItem(s.br, armour[0]),
+		Chestplate:      instanceFromItem(s.br, armour[1]),
+		Leggings:        instanceFromItem(s.br, armour[2]),
+		Boots:           instanceFromItem(s.br, armour[3]),
 	})
 }
 
-// ViewItemCooldown ...
-func (s *Session) ViewItemCooldown(item world.Item, duration time.Duration) {
-	name, _ := item.EncodeItem()
+// ViewItemCooldown shows a cooldown for all items in the cooldown category passed. A duration of 0 removes the
+// cooldown.
+func (s *Session) ViewItemCooldown(category string, duration time.Duration) {
 	s.writePacket(&packet.ClientStartItemCooldown{
-		Category: strings.Split(name, ":")[1],
+		Category: category,
 		Duration: int32(duration.Milliseconds() / 50),
 	})
 }
@@ -746,6 +764,12 @@ func (s *Session) playSound(pos mgl64.Vec3, t world.Sound, disableRelative bool)
 		pk.SoundType = packet.SoundEventBarrelClose
 	case sound.BarrelOpen:
 		pk.SoundType = packet.SoundEventBarrelOpen
+	case sound.CrafterCraft:
+		pk.SoundType = packet.SoundEventCrafterCraft
+	case sound.CrafterFail:
+		pk.SoundType = packet.SoundEventCrafterFail
+	case sound.CrafterDisableSlot:
+		pk.SoundType = packet.SoundEventCrafterDisableSlot
 	case sound.BlockBreaking:
 		pk.SoundType, pk.ExtraData = packet.SoundEventHit, int32(s.br.BlockRuntimeID(so.Block))
 	case sound.ItemBreak:
@@ -1003,6 +1027,13 @@ func (s *Session) ViewBrewingUpdate(prevBrewTime, brewTime time.Duration, prevFu
 
 // ViewBlockUpdate ...
 func (s *Session) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
+	if s.viewLayer != nil && layer == 0 {
+		// Block overrides of the view layer take precedence over the block in the world, so that updates
+		// to the block do not reveal it.
+		if override, ok := s.viewLayer.Block(pos); ok {
+			b = override
+		}
+	}
 	blockPos := protocol.BlockPos{int32(pos[0]), int32(pos[1]), int32(pos[2])}
 	s.writePacket(&packet.UpdateBlock{
 		Position:          blockPos,
@@ -1023,6 +1054,9 @@ func (s *Session) ViewBlockUpdate(pos cube.Pos, b world.Block, layer int) {
 
 // ViewEntityAction ...
 func (s *Session) ViewEntityAction(e world.Entity, a world.EntityAction) {
+	if s.viewLayer != nil && !s.viewLayer.ViewsAction(e, a) {
+		return
+	}
 	switch act := a.(type) {
 	case entity.SwingArmAction:
 		if _, ok := e.(Controllable); ok {
@@ -1124,6 +1158,11 @@ func (s *Session) ViewEntityState(e world.Entity) {
 // applied through its ViewLayer.
 func (s *Session) entityMetadata(e world.Entity) protocol.EntityMetadata {
 	metadata := s.parseEntityMetadata(e)
+	if t, ok := s.entityDisguise(e); ok {
+		bb := t.BBox(e)
+		metadata[protocol.EntityDataKeyWidth] = float32(bb.Width())
+		metadata[protocol.EntityDataKeyHeight] = float32(bb.Height())
+	}
 	if s.viewLayer == nil {
 		return metadata
 	}
@@ -1142,6 +1181,9 @@ func (s *Session) entityMetadata(e world.Entity) protocol.EntityMetadata {
 	if st, ok := s.viewLayer.ScoreTag(e); ok {
 		metadata[protocol.EntityDataKeyScore] = st
 	}
+	if scale, ok := s.viewLayer.Scale(e); ok {
+		metadata[protocol.EntityDataKeyScale] = float32(scale)
+	}
 	if visibility := s.viewLayer.Visibility(e); visibility.EnforceVisibility() {
 		if visibility == world.EnforceInvisible() {
 			metadata.SetFlag(protocol.EntityDataKeyFlags, protocol.EntityDataFlagInvisible)
@@ -1247,6 +1289,8 @@ func (s *Session) openNormalContainer(b block.Container, pos cube.Pos, tx *world
 		containerType = protocol.ContainerTypeSmoker
 	case block.Hopper:
 		containerType = protocol.ContainerTypeHopper
+	case block.Crafter:
+		containerType = protocol.ContainerTypeCrafter
 	}
 
 	s.openedContainerID.Store(uint32(containerType))
@@ -1273,6 +1317,7 @@ func (s *Session) ViewSlotChange(slot int, newItem item.Stack) {
 		Slot:     uint32(slot),
 		NewItem:  instanceFromItem(s.br, newItem),
 	})
+	s.sendBundleContents(newItem)
 }
 
 // ViewBlockAction ...
@@ -1344,7 +1389,7 @@ func (s *Session) ViewSkin(e world.Entity) {
 	if v, ok := e.(Controllable); ok {
 		s.writePacket(&packet.PlayerSkin{
 			UUID: v.UUID(),
-			Skin: skinToProtocol(v.Skin()),
+			Skin: skinToProtocol(s.viewedSkin(e, v.Skin())),
 		})
 	}
 }
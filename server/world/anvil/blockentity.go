@@ -0,0 +1,272 @@
+package anvil
+
+import (
+	"encoding/json"
+	"slices"
+	"strings"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/internal/nbtconv"
+	"github.com/df-mc/dragonfly/server/item"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// containerIDs maps Java block entity IDs of containers to their Bedrock IDs.
+var containerIDs = map[string]string{
+	"minecraft:chest":         "Chest",
+	"minecraft:trapped_chest": "Chest",
+	"minecraft:barrel":        "Barrel",
+	"minecraft:shulker_box":   "ShulkerBox",
+	"minecraft:hopper":        "Hopper",
+	"minecraft:dispenser":     "Dispenser",
+	"minecraft:dropper":       "Dropper",
+}
+
+// convertBlockEntity converts the NBT of a Java block entity to a Bedrock
+// chunk.BlockEntity. stateAt is used to look up the Java block state at a
+// position in the chunk. Chests and other containers, signs and banners are
+// supported. False is returned for other block entities.
+func convertBlockEntity(m map[string]any, stateAt func(pos cube.Pos) (javaState, bool)) (chunk.BlockEntity, bool) {
+	id := stringOf(m["id"])
+	if !strings.Contains(id, ":") {
+		id = "minecraft:" + strings.ToLower(id)
+	}
+	pos := cube.Pos{intOf(m["x"]), intOf(m["y"]), intOf(m["z"])}
+	state, _ := stateAt(pos)
+
+	var data map[string]any
+	switch id {
+	case "minecraft:sign", "minecraft:hanging_sign":
+		data = convertSign(m)
+		data["id"] = "Sign"
+		if id == "minecraft:hanging_sign" {
+			data["id"] = "HangingSign"
+		}
+	case "minecraft:banner":
+		data = convertBanner(m, state)
+	default:
+		bedrockID, ok := containerIDs[id]
+		if !ok {
+			return chunk.BlockEntity{}, false
+		}
+		data = map[string]any{"id": bedrockID, "Items": convertItems(listOf(m, "Items"))}
+		if name := plainText(stringOf(m["CustomName"])); name != "" {
+			data["CustomName"] = name
+		}
+		if id == "minecraft:chest" || id == "minecraft:trapped_chest" {
+			if pair, ok := chestPair(pos, state); ok {
+				data["pairx"], data["pairz"] = int32(pair[0]), int32(pair[2])
+			}
+		}
+	}
+	data["x"], data["y"], data["z"] = int32(pos[0]), int32(pos[1]), int32(pos[2])
+	return chunk.BlockEntity{Pos: pos, Data: data}, true
+}
+
+// chestPair returns the position of the other half of a double chest, based
+// on the type and facing properties of the Java chest state.
+func chestPair(pos cube.Pos, state javaState) (cube.Pos, bool) {
+	facing, ok := directionByName(state.props["facing"])
+	if !ok {
+		return cube.Pos{}, false
+	}
+	switch state.props["type"] {
+	case "left":
+		return pos.Side(facing.RotateRight().Face()), true
+	case "right":
+		return pos.Side(facing.RotateLeft().Face()), true
+	}
+	return cube.Pos{}, false
+}
+
+// directionByName returns the cube.Direction with the name passed.
+func directionByName(name string) (cube.Direction, bool) {
+	switch name {
+	case "north":
+		return cube.North, true
+	case "south":
+		return cube.South, true
+	case "west":
+		return cube.West, true
+	case "east":
+		return cube.East, true
+	}
+	return 0, false
+}
+
+// convertSign converts the text of a Java sign to the NBT of a Bedrock sign.
+// Both the format used since Java Edition 1.20, with separate front and back
+// text, and the older format with four text lines are supported.
+func convertSign(m map[string]any) map[string]any {
+	if _, ok := m["front_text"]; !ok {
+		// Signs before Java Edition 1.20 only had front text.
+		lines := make([]any, 4)
+		for i := range lines {
+			lines[i] = m["Text"+string(rune('1'+i))]
+		}
+		front := map[string]any{"messages": lines, "color": m["Color"], "has_glowing_text": m["GlowingText"]}
+		return map[string]any{"FrontText": convertSignText(front), "BackText": convertSignText(nil)}
+	}
+	front, _ := m["front_text"].(map[string]any)
+	back, _ := m["back_text"].(map[string]any)
+	return map[string]any{
+		"FrontText": convertSignText(front),
+		"BackText":  convertSignText(back),
+		"IsWaxed":   byteOf(m["is_waxed"]),
+	}
+}
+
+// convertSignText converts one side of a Java sign to the NBT of one side of a
+// Bedrock sign.
+func convertSignText(m map[string]any) map[string]any {
+	lines := make([]string, 0, 4)
+	for _, l := range listOf(m, "messages") {
+		lines = append(lines, plainText(stringOf(l)))
+	}
+	for len(lines) > 0 && lines[len(lines)-1] == "" {
+		lines = lines[:len(lines)-1]
+	}
+	colour := item.ColourBlack()
+	if c, ok := colourByName(stringOf(m["color"])); ok {
+		colour = c
+	}
+	return map[string]any{
+		"Text":           strings.Join(lines, "\n"),
+		"SignTextColor":  nbtconv.Int32FromRGBA(colour.SignRGBA()),
+		"IgnoreLighting": byteOf(m["has_glowing_text"]),
+	}
+}
+
+// convertBanner converts a Java banner to the NBT of a Bedrock banner. The
+// base colour of a Java banner is part of its block state.
+func convertBanner(m map[string]any, state javaState) map[string]any {
+	base := item.ColourWhite()
+	name := strings.TrimSuffix(strings.TrimSuffix(strings.TrimPrefix(state.name, "minecraft:"), "_wall_banner"), "_banner")
+	if c, ok := colourByName(name); ok {
+		base = c
+	}
+	var patterns []any
+	for _, p := range listOf(m, "patterns", "Patterns") {
+		pm, _ := p.(map[string]any)
+		code, colour := stringOf(pm["Pattern"]), item.ColourWhite()
+		if c, ok := pm["Color"].(int32); ok && c >= 0 && c < 16 {
+			colour = item.Colours()[c]
+		} else if c, ok := colourByName(stringOf(pm["color"])); ok {
+			// Since Java Edition 1.20.5, patterns and colours are stored by
+			// name.
+			colour, code = c, bannerPatternCodes[strings.TrimPrefix(stringOf(pm["pattern"]), "minecraft:")]
+		}
+		if _, ok := block.BannerPatternByID(code); !ok {
+			continue
+		}
+		patterns = append(patterns, map[string]any{"Pattern": code, "Color": invertedColour(colour)})
+	}
+	return map[string]any{"id": "Banner", "Base": invertedColour(base), "Type": int32(0), "Patterns": patterns}
+}
+
+// invertedColour returns the inverted colour ID that Bedrock banners use.
+func invertedColour(c item.Colour) int32 {
+	return int32(^c.Uint8() & 0xf)
+}
+
+// bannerPatternCodes maps the names of banner patterns as used since Java
+// Edition 1.20.5 to the pattern codes used by Bedrock Edition.
+var bannerPatternCodes = map[string]string{
+	"square_bottom_left": "bl", "square_bottom_right": "br", "square_top_left": "tl", "square_top_right": "tr",
+	"stripe_bottom": "bs", "stripe_top": "ts", "stripe_left": "ls", "stripe_right": "rs", "stripe_center": "cs",
+	"stripe_middle": "ms", "stripe_downright": "drs", "stripe_downleft": "dls", "small_stripes": "ss",
+	"cross": "cr", "straight_cross": "sc", "triangle_bottom": "bt", "triangle_top": "tt",
+	"triangles_bottom": "bts", "triangles_top": "tts", "diagonal_left": "ld", "diagonal_up_right": "rd",
+	"diagonal_up_left": "lud", "diagonal_right": "rud", "circle": "mc", "rhombus": "mr",
+	"half_vertical": "vh", "half_horizontal": "hh", "half_vertical_right": "vhr",
+	"half_horizontal_bottom": "hhb", "border": "bo", "curly_border": "cbo", "gradient": "gra",
+	"gradient_up": "gru", "bricks": "bri", "globe": "glb", "creeper": "cre", "skull": "sku", "flower": "flo",
+	"mojang": "moj", "piglin": "pig", "flow": "flw", "guster": "gus",
+}
+
+// javaColourNames holds the names of dye colours in Java Edition, in the
+// order of item.Colours.
+var javaColourNames = []string{
+	"white", "orange", "magenta", "light_blue", "yellow", "lime", "pink", "gray",
+	"light_gray", "cyan", "purple", "blue", "brown", "green", "red", "black",
+}
+
+// colourByName returns the item.Colour with the Java name passed.
+func colourByName(name string) (item.Colour, bool) {
+	if i := slices.Index(javaColourNames, name); i >= 0 {
+		return item.Colours()[i], true
+	}
+	return item.Colour{}, false
+}
+
+// convertItems converts a list of Java item stacks to Bedrock item NBT.
+func convertItems(items []any) []any {
+	converted := make([]any, 0, len(items))
+	for _, it := range items {
+		m, _ := it.(map[string]any)
+		if data, ok := convertItem(m); ok {
+			data["Slot"] = byteOf(m["Slot"])
+			converted = append(converted, data)
+		}
+	}
+	return converted
+}
+
+// convertItem converts a Java item stack to Bedrock item NBT. Both the item
+// format with a 'tag' compound and the format with components, used since
+// Java Edition 1.20.5, are supported. Only the count and damage are kept.
+func convertItem(m map[string]any) (map[string]any, bool) {
+	name := stringOf(m["id"])
+	if name == "" {
+		return nil, false
+	}
+	count := intOf(m["Count"])
+	if c, ok := m["count"]; ok {
+		count = intOf(c)
+	}
+	data := map[string]any{"Name": name, "Count": byte(max(count, 1)), "Damage": int16(0)}
+
+	damage := 0
+	if tag, ok := m["tag"].(map[string]any); ok {
+		damage = intOf(tag["Damage"])
+	} else if components, ok := m["components"].(map[string]any); ok {
+		damage = intOf(components["minecraft:damage"])
+	}
+	if damage != 0 {
+		data["tag"] = map[string]any{"Damage": int32(damage)}
+	}
+	return data, true
+}
+
+// plainText converts a JSON text component, as used by Java Edition for
+// sign text and custom names, to plain text. If s is not a JSON text
+// component, it is returned as is.
+func plainText(s string) string {
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		return s
+	}
+	var sb strings.Builder
+	writeText(&sb, v)
+	return sb.String()
+}
+
+// writeText writes the text of a decoded JSON text component to sb.
+func writeText(sb *strings.Builder, v any) {
+	switch v := v.(type) {
+	case string:
+		sb.WriteString(v)
+	case []any:
+		for _, e := range v {
+			writeText(sb, e)
+		}
+	case map[string]any:
+		if t, ok := v["text"].(string); ok {
+			sb.WriteString(t)
+		}
+		if extra, ok := v["extra"].([]any); ok {
+			writeText(sb, extra)
+		}
+	}
+}
@@ -0,0 +1,349 @@
+package anvil
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"math/bits"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// converter converts the NBT data of Java Edition chunks to chunk.Columns.
+type converter struct {
+	br     world.BlockRegistry
+	mapper StateMapper
+	log    *slog.Logger
+
+	air, water uint32
+}
+
+// newConverter creates a converter that maps block states using the
+// StateMapper passed and encodes them using the world.BlockRegistry passed.
+func newConverter(br world.BlockRegistry, mapper StateMapper, log *slog.Logger) *converter {
+	water, _ := br.StateToRuntimeID("minecraft:water", map[string]any{"liquid_depth": int32(0)})
+	return &converter{br: br, mapper: mapper, log: log, air: br.AirRuntimeID(), water: water}
+}
+
+// errLegacyFormat is returned when converting chunks that store numeric block
+// IDs, as done by Java Edition versions before 1.13 (DataVersion 1451).
+var errLegacyFormat = errors.New("numeric block IDs of Java Edition before 1.13 are not supported")
+
+// javaState is a block state as found in the palette of a Java chunk section.
+type javaState struct {
+	name  string
+	props map[string]string
+}
+
+// javaSection holds the decoded block states of a Java chunk section.
+type javaSection struct {
+	palette []javaState
+	indices []uint16
+}
+
+// state returns the Java block state at the position passed, relative to the
+// section.
+func (s javaSection) state(x, y, z int) javaState {
+	if len(s.indices) == 0 {
+		return s.palette[0]
+	}
+	return s.palette[s.indices[y<<8|z<<4|x]]
+}
+
+// convert converts the NBT data of a Java chunk to a chunk.Column for the
+// dimension passed. Entities stored outside the chunk data, as done since Java
+// Edition 1.17, may be passed and are converted along with the chunk.
+func (c *converter) convert(data map[string]any, entities []any, dim world.Dimension) (*chunk.Column, error) {
+	root := data
+	if level, ok := data["Level"].(map[string]any); ok {
+		// Chunks before Java Edition 1.18 nest all data in a Level compound.
+		root = level
+	}
+	r := dim.Range()
+	col := &chunk.Column{Chunk: chunk.New(c.br, r)}
+
+	sections := make(map[int]javaSection)
+	for _, s := range listOf(root, "sections", "Sections") {
+		sec, _ := s.(map[string]any)
+		subY := int(int8(byteOf(sec["Y"])))
+		if subY<<4 < r.Min() || subY<<4 > r.Max() {
+			continue
+		}
+		if _, ok := sec["Blocks"]; ok {
+			// Chunks written before Java Edition 1.13 store numeric block IDs
+			// instead of a palette. Converting them as empty sections would
+			// silently turn the world into air.
+			return nil, fmt.Errorf("section %v: %w (DataVersion %v)", subY, errLegacyFormat, intOf(data["DataVersion"]))
+		}
+		js, err := c.convertSection(col.Chunk, sec, subY)
+		if err != nil {
+			return nil, fmt.Errorf("section %v: %w", subY, err)
+		}
+		sections[subY] = js
+		c.convertSectionBiomes(col.Chunk, sec, subY)
+	}
+	c.convertLegacyBiomes(col.Chunk, root["Biomes"])
+
+	stateAt := func(pos cube.Pos) (javaState, bool) {
+		sec, ok := sections[pos[1]>>4]
+		if !ok || len(sec.palette) == 0 {
+			return javaState{}, false
+		}
+		return sec.state(pos[0]&15, pos[1]&15, pos[2]&15), true
+	}
+	for _, be := range listOf(root, "block_entities", "TileEntities") {
+		m, _ := be.(map[string]any)
+		if converted, ok := convertBlockEntity(m, stateAt); ok {
+			col.BlockEntities = append(col.BlockEntities, converted)
+		}
+	}
+	for _, e := range append(listOf(root, "Entities"), entities...) {
+		m, _ := e.(map[string]any)
+		if converted, ok := convertEntity(m); ok {
+			col.Entities = append(col.Entities, converted)
+		}
+	}
+	return col, nil
+}
+
+// convertSection converts the blocks of a Java chunk section and sets them to
+// the chunk passed. The decoded section is returned so that block entities
+// may look up the Java states of their blocks.
+func (c *converter) convertSection(ch *chunk.Chunk, sec map[string]any, subY int) (javaSection, error) {
+	palette, data := listOf(sec, "Palette"), int64sOf(sec["BlockStates"])
+	if states, ok := sec["block_states"].(map[string]any); ok {
+		palette, data = listOf(states, "palette"), int64sOf(states["data"])
+	}
+	if len(palette) == 0 {
+		return javaSection{}, nil
+	}
+
+	js := javaSection{palette: make([]javaState, len(palette))}
+	rids, waterlogged := make([]uint32, len(palette)), make([]bool, len(palette))
+	for i, p := range palette {
+		m, _ := p.(map[string]any)
+		state := javaState{name: stringOf(m["Name"]), props: make(map[string]string)}
+		if props, ok := m["Properties"].(map[string]any); ok {
+			for k, v := range props {
+				state.props[k] = stringOf(v)
+			}
+		}
+		js.palette[i] = state
+		rids[i], waterlogged[i] = c.runtimeID(state), state.props["waterlogged"] == "true"
+	}
+
+	if len(palette) > 1 {
+		indices, err := unpack(data, max(4, bits.Len(uint(len(palette)-1))), 4096)
+		if err != nil {
+			return js, err
+		}
+		js.indices = indices
+	}
+	baseY := subY << 4
+	for i := range 4096 {
+		index := 0
+		if js.indices != nil {
+			index = int(js.indices[i])
+			if index >= len(rids) {
+				return js, fmt.Errorf("palette index %v out of range", index)
+			}
+		}
+		x, y, z := uint8(i&15), int16(baseY+i>>8), uint8((i>>4)&15)
+		if rid := rids[index]; rid != c.air {
+			ch.SetBlock(x, y, z, 0, rid)
+		}
+		if waterlogged[index] {
+			ch.SetBlock(x, y, z, 1, c.water)
+		}
+	}
+	return js, nil
+}
+
+// runtimeID returns the runtime ID of the Bedrock block state that the Java
+// state passed maps to. If the state cannot be mapped, air is returned.
+func (c *converter) runtimeID(state javaState) uint32 {
+	props := state.props
+	if _, ok := props["waterlogged"]; ok {
+		// Waterlogging is handled separately by setting water in the second
+		// layer, so it is not passed to the StateMapper.
+		props = make(map[string]string, len(state.props))
+		for k, v := range state.props {
+			if k != "waterlogged" {
+				props[k] = v
+			}
+		}
+	}
+	s, ok := c.mapper.MapState(state.name, props)
+	if !ok {
+		c.log.Debug("convert chunk: unmapped block state", "state", StateKey(state.name, props))
+		return c.air
+	}
+	rid, ok := c.br.StateToRuntimeID(s.Name, s.Properties)
+	if !ok {
+		c.log.Debug("convert chunk: unknown bedrock block state", "name", s.Name, "properties", fmt.Sprint(s.Properties))
+		return c.air
+	}
+	return rid
+}
+
+// convertSectionBiomes converts the biomes of a Java chunk section, as stored
+// since Java Edition 1.18, and sets them to the chunk passed.
+func (c *converter) convertSectionBiomes(ch *chunk.Chunk, sec map[string]any, subY int) {
+	biomes, ok := sec["biomes"].(map[string]any)
+	if !ok {
+		return
+	}
+	palette := listOf(biomes, "palette")
+	if len(palette) == 0 {
+		return
+	}
+	ids := make([]uint32, len(palette))
+	for i, p := range palette {
+		ids[i] = biomeID(stringOf(p))
+	}
+	indices := make([]uint16, 64)
+	if len(palette) > 1 {
+		var err error
+		if indices, err = unpack(int64sOf(biomes["data"]), bits.Len(uint(len(palette)-1)), 64); err != nil {
+			c.log.Debug("convert chunk: invalid biome data", "section", subY, "err", err)
+			return
+		}
+	}
+	for i, index := range indices {
+		if int(index) >= len(ids) {
+			continue
+		}
+		// Biomes are stored per 4x4x4 cell, indexed as y<<4|z<<2|x.
+		cx, cy, cz := (i&3)<<2, (i>>4)<<2, ((i>>2)&3)<<2
+		setBiomeCell(ch, cx, subY<<4+cy, cz, 4, ids[index])
+	}
+}
+
+// convertLegacyBiomes converts the biomes of a chunk from before Java Edition
+// 1.18, which are stored as numeric IDs for the whole chunk, and sets them to
+// the chunk passed.
+func (c *converter) convertLegacyBiomes(ch *chunk.Chunk, v any) {
+	r := ch.Range()
+	switch biomes := v.(type) {
+	case []int32:
+		if len(biomes) == 256 {
+			// Java Edition 1.13-1.14 stored a biome per column.
+			for i, id := range biomes {
+				setBiomeColumn(ch, i&15, i>>4, uint32(id))
+			}
+			return
+		}
+		// Java Edition 1.15-1.17 stored biomes per 4x4x4 cell from y=0.
+		for i, id := range biomes {
+			cx, cy, cz := (i&3)<<2, (i>>4)<<2, ((i>>2)&3)<<2
+			if cy > r.Max() {
+				break
+			}
+			setBiomeCell(ch, cx, cy, cz, 4, uint32(id))
+		}
+	case []byte:
+		for i, id := range biomes {
+			setBiomeColumn(ch, i&15, i>>4, uint32(id))
+		}
+	}
+}
+
+// setBiomeCell sets the biome of a size*size*size cell of blocks starting at
+// the position passed.
+func setBiomeCell(ch *chunk.Chunk, x, y, z, size int, biome uint32) {
+	r := ch.Range()
+	for bx := x; bx < x+size; bx++ {
+		for bz := z; bz < z+size; bz++ {
+			for by := max(y, r.Min()); by < y+size && by <= r.Max(); by++ {
+				ch.SetBiome(uint8(bx), int16(by), uint8(bz), biome)
+			}
+		}
+	}
+}
+
+// setBiomeColumn sets the biome of a full column of blocks.
+func setBiomeColumn(ch *chunk.Chunk, x, z int, biome uint32) {
+	r := ch.Range()
+	for y := r.Min(); y <= r.Max(); y++ {
+		ch.SetBiome(uint8(x), int16(y), uint8(z), biome)
+	}
+}
+
+// unpack unpacks n values of the number of bits passed from the long array
+// data. Java Edition 1.16 and later do not let values span across multiple
+// longs, while earlier versions did. Both layouts are supported.
+func unpack(data []int64, bitsPerValue, n int) ([]uint16, error) {
+	values := make([]uint16, n)
+	if bitsPerValue == 0 {
+		return values, nil
+	}
+	perLong := 64 / bitsPerValue
+	padded, spanning := (n+perLong-1)/perLong, (n*bitsPerValue+63)/64
+	mask := uint64(1)<<bitsPerValue - 1
+
+	switch len(data) {
+	case padded:
+		for i := range n {
+			long := uint64(data[i/perLong])
+			values[i] = uint16(long >> ((i % perLong) * bitsPerValue) & mask)
+		}
+	case spanning:
+		for i := range n {
+			bit := i * bitsPerValue
+			start, offset := bit/64, bit%64
+			v := uint64(data[start]) >> offset
+			if offset+bitsPerValue > 64 {
+				v |= uint64(data[start+1]) << (64 - offset)
+			}
+			values[i] = uint16(v & mask)
+		}
+	default:
+		return nil, fmt.Errorf("unexpected data length %v for %v bits per value", len(data), bitsPerValue)
+	}
+	return values, nil
+}
+
+// listOf returns the first list found in m under one of the keys passed.
+func listOf(m map[string]any, keys ...string) []any {
+	for _, k := range keys {
+		if l, ok := m[k].([]any); ok {
+			return l
+		}
+	}
+	return nil
+}
+
+// int64sOf converts a long array NBT value to an []int64.
+func int64sOf(v any) []int64 {
+	l, _ := v.([]int64)
+	return l
+}
+
+// stringOf converts a string NBT value to a string.
+func stringOf(v any) string {
+	s, _ := v.(string)
+	return s
+}
+
+// byteOf converts a byte NBT value to a byte.
+func byteOf(v any) byte {
+	b, _ := v.(byte)
+	return b
+}
+
+// intOf converts any integer NBT value to an int.
+func intOf(v any) int {
+	switch v := v.(type) {
+	case byte:
+		return int(v)
+	case int16:
+		return int(v)
+	case int32:
+		return int(v)
+	case int64:
+		return int(v)
+	}
+	return 0
+}
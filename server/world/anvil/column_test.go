@@ -0,0 +1,62 @@
+package anvil
+
+import (
+	"errors"
+	"log/slog"
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestUnpack(t *testing.T) {
+	values := make([]uint16, 4096)
+	for i := range values {
+		values[i] = uint16(i*7) % 32
+	}
+	// Java Edition 1.16+: 12 values of 5 bits per long, without spanning.
+	padded := make([]int64, (4096+11)/12)
+	for i, v := range values {
+		padded[i/12] |= int64(v) << ((i % 12) * 5)
+	}
+	// Java Edition 1.13-1.15: values span across longs.
+	spanning := make([]int64, 4096*5/64)
+	for i, v := range values {
+		bit := i * 5
+		spanning[bit/64] |= int64(uint64(v) << (bit % 64))
+		if bit%64+5 > 64 {
+			spanning[bit/64+1] |= int64(uint64(v) >> (64 - bit%64))
+		}
+	}
+	for name, data := range map[string][]int64{"padded": padded, "spanning": spanning} {
+		got, err := unpack(data, 5, 4096)
+		if err != nil {
+			t.Fatalf("unpack %v: %v", name, err)
+		}
+		if !slices.Equal(got, values) {
+			t.Errorf("unpack %v: values do not match", name)
+		}
+	}
+	if _, err := unpack(make([]int64, 3), 5, 4096); err == nil {
+		t.Errorf("unpack with invalid length: expected error, got nil")
+	}
+}
+
+func TestConvertLegacySection(t *testing.T) {
+	br := world.DefaultBlockRegistry
+	br.Finalize()
+	c := newConverter(br, NewRegistryMapper(br), slog.Default())
+	data := map[string]any{"DataVersion": int32(1343), "Level": map[string]any{"Sections": []any{
+		map[string]any{"Y": byte(0), "Blocks": make([]byte, 4096), "Data": make([]byte, 2048)},
+	}}}
+	if _, err := c.convert(data, nil, world.Overworld); !errors.Is(err, errLegacyFormat) {
+		t.Fatalf("expected legacy format error, got %v", err)
+	}
+
+	// Sections without blocks, such as sections only holding light, are
+	// converted as air.
+	data = map[string]any{"DataVersion": int32(2975), "sections": []any{map[string]any{"Y": byte(0)}}}
+	if _, err := c.convert(data, nil, world.Overworld); err != nil {
+		t.Fatalf("expected empty section to convert, got %v", err)
+	}
+}
@@ -0,0 +1,81 @@
+package anvil
+
+import (
+	"encoding/binary"
+	"math/rand/v2"
+
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// convertEntity converts the NBT of a Java entity to a Bedrock chunk.Entity.
+// Only item entities, experience orbs and primed TNT are converted, as other
+// entities either do not persist in Bedrock Edition or store data in ways that
+// cannot be converted. False is returned for other entities.
+func convertEntity(m map[string]any) (chunk.Entity, bool) {
+	data := make(map[string]any)
+	switch stringOf(m["id"]) {
+	case "minecraft:item":
+		it, _ := m["Item"].(map[string]any)
+		converted, ok := convertItem(it)
+		if !ok {
+			return chunk.Entity{}, false
+		}
+		data["identifier"], data["Item"] = "minecraft:item", converted
+	case "minecraft:experience_orb":
+		data["identifier"], data["Value"] = "minecraft:xp_orb", int32(intOf(m["Value"]))
+	case "minecraft:tnt":
+		fuse := m["Fuse"]
+		if f, ok := m["fuse"]; ok {
+			// Java Edition 1.20.3 renamed the Fuse field.
+			fuse = f
+		}
+		data["identifier"], data["Fuse"] = "minecraft:tnt", int16(intOf(fuse))
+	default:
+		return chunk.Entity{}, false
+	}
+	data["Pos"], data["Motion"] = float32s(m["Pos"]), float32s(m["Motion"])
+	if rot := float32s(m["Rotation"]); len(rot) == 2 {
+		data["Yaw"], data["Pitch"] = rot[0], rot[1]
+	}
+	if name := plainText(stringOf(m["CustomName"])); name != "" {
+		data["NameTag"] = name
+	}
+	data["Fire"], data["Age"] = int16(max(intOf(m["Fire"]), 0)), int16(intOf(m["Age"]))
+	return chunk.Entity{ID: entityID(m), Data: data}, true
+}
+
+// entityID returns a unique ID for the Java entity passed, derived from its
+// UUID if it has one.
+func entityID(m map[string]any) int64 {
+	if uuid, ok := m["UUID"].([]int32); ok && len(uuid) == 4 {
+		var b [8]byte
+		binary.BigEndian.PutUint32(b[:4], uint32(uuid[2]))
+		binary.BigEndian.PutUint32(b[4:], uint32(uuid[3]))
+		return int64(binary.BigEndian.Uint64(b[:]))
+	}
+	if most, ok := m["UUIDMost"].(int64); ok {
+		return most ^ intOf64(m["UUIDLeast"])
+	}
+	return rand.Int64()
+}
+
+// float32s converts a list of float or double NBT values to a []float32.
+func float32s(v any) []float32 {
+	l, _ := v.([]any)
+	f := make([]float32, 0, len(l))
+	for _, e := range l {
+		switch e := e.(type) {
+		case float32:
+			f = append(f, e)
+		case float64:
+			f = append(f, float32(e))
+		}
+	}
+	return f
+}
+
+// intOf64 converts a long NBT value to an int64.
+func intOf64(v any) int64 {
+	i, _ := v.(int64)
+	return i
+}
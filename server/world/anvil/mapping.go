@@ -0,0 +1,255 @@
+package anvil
+
+import (
+	"maps"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+// State is a Bedrock Edition block state: The name of a block and its
+// properties.
+type State struct {
+	Name       string
+	Properties map[string]any
+}
+
+// StateMapper maps Java Edition block states to Bedrock Edition block states.
+// Implementations must be safe for concurrent use.
+type StateMapper interface {
+	// MapState returns the Bedrock State of the Java block state with the
+	// name and properties passed. If the state could not be mapped, false is
+	// returned.
+	MapState(name string, properties map[string]string) (State, bool)
+}
+
+// StateKey returns the key of a Java block state in the format
+// 'minecraft:oak_log[axis=x]', with properties sorted by name. This is the
+// format most Java to Bedrock block mapping files use.
+func StateKey(name string, properties map[string]string) string {
+	if len(properties) == 0 {
+		return name
+	}
+	keys := slices.Sorted(maps.Keys(properties))
+	parts := make([]string, len(keys))
+	for i, k := range keys {
+		parts[i] = k + "=" + properties[k]
+	}
+	return name + "[" + strings.Join(parts, ",") + "]"
+}
+
+// TableMapper is a StateMapper that looks up states in a table keyed by
+// StateKey. States not present in the table are passed to Fallback, if set.
+// A TableMapper is typically filled with a full mapping of Java to Bedrock
+// states, such as one generated from the game's data files.
+type TableMapper struct {
+	Table    map[string]State
+	Fallback StateMapper
+}
+
+// MapState ...
+func (m TableMapper) MapState(name string, properties map[string]string) (State, bool) {
+	if s, ok := m.Table[StateKey(name, properties)]; ok {
+		return s, true
+	}
+	if m.Fallback != nil {
+		return m.Fallback.MapState(name, properties)
+	}
+	return State{}, false
+}
+
+// RegistryMapper is a StateMapper that maps Java states to the Bedrock state
+// in a world.BlockRegistry with the same name that shares the most
+// properties with the Java state. Common differences in property names, such
+// as 'axis' and 'pillar_axis', are accounted for. Because Java and Bedrock
+// block states differ for many blocks, a RegistryMapper is best used as the
+// Fallback of a TableMapper.
+type RegistryMapper struct {
+	states map[string][]map[string]any
+
+	mu    sync.RWMutex
+	cache map[string]State
+}
+
+// NewRegistryMapper creates a RegistryMapper for the states registered in the
+// world.BlockRegistry passed.
+func NewRegistryMapper(br world.BlockRegistry) *RegistryMapper {
+	m := &RegistryMapper{states: make(map[string][]map[string]any), cache: make(map[string]State)}
+	for rid := range uint32(br.BlockCount()) {
+		name, props, ok := br.RuntimeIDToState(rid)
+		if ok {
+			m.states[name] = append(m.states[name], props)
+		}
+	}
+	return m
+}
+
+// MapState ...
+func (m *RegistryMapper) MapState(name string, properties map[string]string) (State, bool) {
+	key := StateKey(name, properties)
+	m.mu.RLock()
+	s, ok := m.cache[key]
+	m.mu.RUnlock()
+	if ok {
+		return s, s.Name != ""
+	}
+
+	if renamed, ok := javaBlockNames[name]; ok {
+		name = renamed
+	}
+	best, bestScore := map[string]any(nil), -1
+	for _, candidate := range m.states[name] {
+		if score := matchScore(candidate, properties); score > bestScore {
+			best, bestScore = candidate, score
+		}
+	}
+	if best != nil {
+		s = State{Name: name, Properties: best}
+	}
+	m.mu.Lock()
+	m.cache[key] = s
+	m.mu.Unlock()
+	return s, best != nil
+}
+
+// matchScore returns the number of Java properties passed that match a
+// Bedrock property of the candidate state.
+func matchScore(candidate map[string]any, properties map[string]string) int {
+	score := 0
+	for k, v := range properties {
+		for _, alias := range javaPropertyAliases(k, v) {
+			if bedrockValue, ok := candidate[alias.key]; ok && propertyString(bedrockValue) == alias.val {
+				score++
+				break
+			}
+		}
+	}
+	return score
+}
+
+// propertyAlias is a Bedrock property key and value that a Java property may
+// correspond to.
+type propertyAlias struct {
+	key, val string
+}
+
+// javaPropertyAliases returns the Bedrock properties that the Java property
+// passed may correspond to, including the property itself.
+func javaPropertyAliases(k, v string) []propertyAlias {
+	aliases := []propertyAlias{{k, v}}
+	switch k {
+	case "axis":
+		aliases = append(aliases, propertyAlias{"pillar_axis", v})
+	case "facing":
+		aliases = append(aliases, propertyAlias{"minecraft:cardinal_direction", v}, propertyAlias{"minecraft:facing_direction", v})
+		if dir, ok := facingDirections[v]; ok {
+			aliases = append(aliases, propertyAlias{"facing_direction", dir})
+		}
+	case "level":
+		aliases = append(aliases, propertyAlias{"liquid_depth", v})
+	case "age":
+		aliases = append(aliases, propertyAlias{"growth", v})
+	case "half", "type":
+		aliases = append(aliases, propertyAlias{"minecraft:vertical_half", v}, propertyAlias{"upper_block_bit", strconv.FormatBool(v == "upper")})
+	case "powered", "open", "lit", "attached", "hanging", "extended", "triggered":
+		aliases = append(aliases, propertyAlias{k + "_bit", strconv.FormatBool(v == "true")})
+	case "rotation":
+		aliases = append(aliases, propertyAlias{"ground_sign_direction", v})
+	}
+	return aliases
+}
+
+// facingDirections maps Java facing values to Bedrock facing_direction values.
+var facingDirections = map[string]string{
+	"down": "0", "up": "1", "north": "2", "south": "3", "west": "4", "east": "5",
+}
+
+// javaBlockNames maps Java block names to Bedrock block names for blocks that
+// are named differently.
+var javaBlockNames = map[string]string{
+	"minecraft:cave_air":         "minecraft:air",
+	"minecraft:void_air":         "minecraft:air",
+	"minecraft:grass":            "minecraft:short_grass",
+	"minecraft:dirt_path":        "minecraft:grass_path",
+	"minecraft:snow_block":       "minecraft:snow",
+	"minecraft:snow":             "minecraft:snow_layer",
+	"minecraft:note_block":       "minecraft:noteblock",
+	"minecraft:spawner":          "minecraft:mob_spawner",
+	"minecraft:nether_portal":    "minecraft:portal",
+	"minecraft:magma_block":      "minecraft:magma",
+	"minecraft:melon":            "minecraft:melon_block",
+	"minecraft:slime_block":      "minecraft:slime",
+	"minecraft:terracotta":       "minecraft:hardened_clay",
+	"minecraft:sugar_cane":       "minecraft:reeds",
+	"minecraft:cobweb":           "minecraft:web",
+	"minecraft:lily_pad":         "minecraft:waterlily",
+	"minecraft:jack_o_lantern":   "minecraft:lit_pumpkin",
+	"minecraft:bubble_column":    "minecraft:water",
+	"minecraft:moving_piston":    "minecraft:air",
+	"minecraft:end_stone_bricks": "minecraft:end_bricks",
+}
+
+// propertyString converts a Bedrock property value to a string that may be
+// compared with Java property values.
+func propertyString(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case bool:
+		return strconv.FormatBool(v)
+	case uint8:
+		return strconv.FormatBool(v == 1)
+	case int32:
+		return strconv.Itoa(int(v))
+	}
+	return ""
+}
+
+// biomeID returns the Bedrock biome ID of the Java biome name passed. Biomes
+// that do not exist in Bedrock Edition are converted to plains.
+func biomeID(name string) uint32 {
+	name = strings.TrimPrefix(name, "minecraft:")
+	if renamed, ok := javaBiomeNames[name]; ok {
+		name = renamed
+	}
+	if b, ok := world.BiomeByName(name); ok {
+		return uint32(b.EncodeBiome())
+	}
+	if b, ok := world.BiomeByName("plains"); ok {
+		return uint32(b.EncodeBiome())
+	}
+	return 1
+}
+
+// javaBiomeNames maps Java biome names to Bedrock biome names for biomes that
+// are named differently.
+var javaBiomeNames = map[string]string{
+	"badlands":                 "mesa",
+	"eroded_badlands":          "mesa_bryce",
+	"wooded_badlands":          "mesa_plateau_stone",
+	"dark_forest":              "roofed_forest",
+	"nether_wastes":            "hell",
+	"soul_sand_valley":         "soulsand_valley",
+	"small_end_islands":        "the_end",
+	"end_midlands":             "the_end",
+	"end_highlands":            "the_end",
+	"end_barrens":              "the_end",
+	"windswept_hills":          "extreme_hills",
+	"windswept_forest":         "extreme_hills_plus_trees",
+	"windswept_gravelly_hills": "extreme_hills_mutated",
+	"windswept_savanna":        "savanna_mutated",
+	"snowy_plains":             "ice_plains",
+	"snowy_taiga":              "cold_taiga",
+	"snowy_beach":              "cold_beach",
+	"old_growth_pine_taiga":    "mega_taiga",
+	"old_growth_spruce_taiga":  "redwood_taiga_mutated",
+	"old_growth_birch_forest":  "birch_forest_mutated",
+	"sparse_jungle":            "jungle_edge",
+	"stony_shore":              "stone_beach",
+	"mushroom_fields":          "mushroom_island",
+	"ice_spikes":               "ice_plains_spikes",
+	"swamp":                    "swampland",
+}
@@ -0,0 +1,351 @@
+package anvil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"container/list"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/google/uuid"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// Config holds the optional parameters of a Provider.
+type Config struct {
+	// Log is the Logger that will be used to log errors and debug messages to.
+	// If set to nil, Log is set to slog.Default().
+	Log *slog.Logger
+	// Blocks is the BlockRegistry used to encode converted blocks. If nil,
+	// world.DefaultBlockRegistry is used. When using a non-default registry,
+	// pass the same registry used by the World.
+	Blocks world.BlockRegistry
+	// Mapper is the StateMapper used to map Java block states to Bedrock
+	// block states. If nil, a RegistryMapper for Blocks is used. For accurate
+	// conversion, a TableMapper filled with a full mapping should be used.
+	Mapper StateMapper
+	// MaxRegions is the maximum number of region files that the Provider
+	// keeps in memory. The region file used least recently is released once
+	// the limit is exceeded. If 0 or lower, MaxRegions is set to 64.
+	MaxRegions int
+}
+
+// Provider is a read-only world.Provider for Java Edition worlds stored in the
+// Anvil format. Chunks are converted to Bedrock Edition as they are loaded.
+// Changes made to the world are not written back: StoreColumn and
+// SaveSettings do not persist anything. To keep changes, a world should
+// first be converted to another world.Provider using Provider.Convert.
+type Provider struct {
+	conf Config
+	dir  string
+	conv *converter
+	set  *world.Settings
+
+	mu sync.Mutex
+	// regions holds the region files opened, indexed by their key. The
+	// elements of regions are held by lru, with the region used most recently
+	// at the front.
+	regions map[regionKey]*list.Element
+	lru     *list.List
+}
+
+// regionKey identifies a region file in a world.
+type regionKey struct {
+	dir  string
+	x, z int
+}
+
+// errChunkNotGenerated is returned by Provider.LoadColumn for chunks that are
+// stored but not yet fully generated.
+var errChunkNotGenerated = errors.New("chunk not fully generated")
+
+// Open opens the Java Edition world in the directory passed. An error is
+// returned if the level.dat of the world could not be read.
+func (conf Config) Open(dir string) (*Provider, error) {
+	if conf.Log == nil {
+		conf.Log = slog.Default()
+	}
+	conf.Log = conf.Log.With("provider", "anvil")
+	if conf.Blocks == nil {
+		conf.Blocks = world.DefaultBlockRegistry
+	}
+	conf.Blocks.Finalize()
+	if conf.Mapper == nil {
+		conf.Mapper = NewRegistryMapper(conf.Blocks)
+	}
+	if conf.MaxRegions <= 0 {
+		conf.MaxRegions = 64
+	}
+	set, err := readSettings(filepath.Join(dir, "level.dat"))
+	if err != nil {
+		return nil, fmt.Errorf("open anvil world: %w", err)
+	}
+	return &Provider{
+		conf:    conf,
+		dir:     dir,
+		conv:    newConverter(conf.Blocks, conf.Mapper, conf.Log),
+		set:     set,
+		regions: make(map[regionKey]*list.Element),
+		lru:     list.New(),
+	}, nil
+}
+
+// Settings returns the world.Settings read from the level.dat of the world.
+func (p *Provider) Settings() *world.Settings {
+	return p.set
+}
+
+// SaveSettings does nothing, as the Provider is read-only.
+func (p *Provider) SaveSettings(*world.Settings) {}
+
+// LoadPlayerSpawnPosition always returns false, as player spawn positions of
+// Java worlds are not converted.
+func (p *Provider) LoadPlayerSpawnPosition(uuid.UUID) (cube.Pos, bool, error) {
+	return cube.Pos{}, false, nil
+}
+
+// SavePlayerSpawnPosition does nothing, as the Provider is read-only.
+func (p *Provider) SavePlayerSpawnPosition(uuid.UUID, cube.Pos) error {
+	return nil
+}
+
+// LoadColumn reads the Java chunk at the position and dimension passed and
+// converts it to a chunk.Column. If the chunk does not exist or is not fully
+// generated, errors.Is(err, leveldb.ErrNotFound) equals true.
+func (p *Provider) LoadColumn(pos world.ChunkPos, dim world.Dimension) (*chunk.Column, error) {
+	data, err := p.chunk(dimensionDir(dim), pos)
+	if err != nil {
+		return nil, fmt.Errorf("load column %v (%v): %w", pos, dim, err)
+	}
+	if !generated(data) {
+		return nil, fmt.Errorf("load column %v (%v): %w: %w", pos, dim, errChunkNotGenerated, leveldb.ErrNotFound)
+	}
+	// Since Java Edition 1.17, entities are stored in separate region files.
+	var entities []any
+	if entityData, err := p.chunk(filepath.Join(dimensionDir(dim), "entities"), pos); err == nil {
+		entities = listOf(entityData, "Entities")
+	}
+	col, err := p.conv.convert(data, entities, dim)
+	if err != nil {
+		return nil, fmt.Errorf("load column %v (%v): %w", pos, dim, err)
+	}
+	return col, nil
+}
+
+// StoreColumn does nothing, as the Provider is read-only.
+func (p *Provider) StoreColumn(world.ChunkPos, world.Dimension, *chunk.Column) error {
+	return nil
+}
+
+// Close releases the region files held in memory by the Provider.
+func (p *Provider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	clear(p.regions)
+	p.lru.Init()
+	return nil
+}
+
+// Convert converts all chunks of all dimensions in the world to Bedrock
+// Edition and stores them in the world.Provider passed, along with the
+// world's settings. Chunks that fail to convert are logged and skipped.
+// Convert does not close dst.
+func (p *Provider) Convert(dst world.Provider) error {
+	for _, dim := range []world.Dimension{world.Overworld, world.Nether, world.End} {
+		dir := dimensionDir(dim)
+		files, err := filepath.Glob(filepath.Join(p.dir, dir, "region", "r.*.*.mca"))
+		if err != nil {
+			return fmt.Errorf("convert: %w", err)
+		}
+		for _, file := range files {
+			r, err := OpenRegion(file)
+			if err != nil {
+				p.conf.Log.Error("convert: "+err.Error(), "file", file)
+				continue
+			}
+			for _, rel := range r.Chunks() {
+				pos := world.ChunkPos{int32(r.x*regionSize + rel[0]), int32(r.z*regionSize + rel[1])}
+				col, err := p.LoadColumn(pos, dim)
+				if errors.Is(err, leveldb.ErrNotFound) {
+					continue
+				} else if err != nil {
+					p.conf.Log.Error("convert: "+err.Error(), "X", pos[0], "Z", pos[1], "dimension", fmt.Sprint(dim))
+					continue
+				}
+				if err := dst.StoreColumn(pos, dim, col); err != nil {
+					return fmt.Errorf("convert: store column %v (%v): %w", pos, dim, err)
+				}
+			}
+			// Regions are only needed once during conversion, so they are
+			// released right away to limit memory usage.
+			p.mu.Lock()
+			p.release(regionKey{dir: filepath.Join(dir, "region"), x: r.x, z: r.z})
+			p.release(regionKey{dir: filepath.Join(dir, "entities"), x: r.x, z: r.z})
+			p.mu.Unlock()
+		}
+	}
+	dst.SaveSettings(p.set)
+	return nil
+}
+
+// chunk reads the NBT of the chunk at the position passed from the region
+// files in the directory passed, relative to the world directory.
+func (p *Provider) chunk(dir string, pos world.ChunkPos) (map[string]any, error) {
+	if !strings.HasSuffix(dir, "entities") {
+		dir = filepath.Join(dir, "region")
+	}
+	r, err := p.region(regionKey{dir: dir, x: int(pos[0]) >> 5, z: int(pos[1]) >> 5})
+	if err != nil {
+		return nil, err
+	}
+	data, err := r.Chunk(int(pos[0]), int(pos[1]))
+	if errors.Is(err, ErrChunkNotFound) {
+		return nil, fmt.Errorf("%w: %w", err, leveldb.ErrNotFound)
+	}
+	return data, err
+}
+
+// region returns the Region with the key passed, opening it if it was not yet
+// opened. If more than Config.MaxRegions regions are open after opening it,
+// the region used least recently is released.
+func (p *Provider) region(k regionKey) (*Region, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if e, ok := p.regions[k]; ok {
+		p.lru.MoveToFront(e)
+		return e.Value.(*cachedRegion).r, nil
+	}
+	r, err := OpenRegion(filepath.Join(p.dir, k.dir, fmt.Sprintf("r.%v.%v.mca", k.x, k.z)))
+	if errors.Is(err, os.ErrNotExist) {
+		// Cache a Region without data so that the file is not looked up again.
+		r, err = &Region{x: k.x, z: k.z}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	p.regions[k] = p.lru.PushFront(&cachedRegion{k: k, r: r})
+	for p.lru.Len() > p.conf.MaxRegions {
+		p.release(p.lru.Back().Value.(*cachedRegion).k)
+	}
+	return r, nil
+}
+
+// release releases the region with the key passed if it is open. p.mu must be
+// held when calling release.
+func (p *Provider) release(k regionKey) {
+	if e, ok := p.regions[k]; ok {
+		p.lru.Remove(e)
+		delete(p.regions, k)
+	}
+}
+
+// cachedRegion is a Region held in the LRU list of a Provider.
+type cachedRegion struct {
+	k regionKey
+	r *Region
+}
+
+// dimensionDir returns the directory, relative to the world directory, that
+// holds the data of the world.Dimension passed.
+func dimensionDir(dim world.Dimension) string {
+	switch dim {
+	case world.Nether:
+		return "DIM-1"
+	case world.End:
+		return "DIM1"
+	}
+	return ""
+}
+
+// generated checks if the chunk NBT passed holds a fully generated chunk.
+// Chunks without a status, written by versions before Java Edition 1.14, are
+// always considered generated.
+func generated(data map[string]any) bool {
+	status, ok := data["Status"].(string)
+	if level, isLevel := data["Level"].(map[string]any); isLevel {
+		status, ok = level["Status"].(string)
+	}
+	if !ok {
+		return true
+	}
+	switch strings.TrimPrefix(status, "minecraft:") {
+	case "full", "postprocessed", "fullchunk":
+		return true
+	}
+	return false
+}
+
+// levelDat holds the fields of a Java Edition level.dat that are converted to
+// world.Settings.
+type levelDat struct {
+	LevelName   string
+	SpawnX      int32
+	SpawnY      int32
+	SpawnZ      int32
+	Time        int64
+	DayTime     int64
+	Raining     byte  `nbt:"raining"`
+	RainTime    int32 `nbt:"rainTime"`
+	Thundering  byte  `nbt:"thundering"`
+	ThunderTime int32 `nbt:"thunderTime"`
+	GameType    int32
+	Difficulty  byte
+	GameRules   map[string]string
+}
+
+// readSettings reads the gzip compressed level.dat at the path passed and
+// converts it to world.Settings.
+func readSettings(path string) (*world.Settings, error) {
+	f, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read level.dat: %w", err)
+	}
+	r, err := gzip.NewReader(bytes.NewReader(f))
+	if err != nil {
+		return nil, fmt.Errorf("read level.dat: %w", err)
+	}
+	raw, err := io.ReadAll(r)
+	if err != nil {
+		return nil, fmt.Errorf("read level.dat: %w", err)
+	}
+	var root struct {
+		Data levelDat
+	}
+	if err := nbt.UnmarshalEncoding(raw, &root, nbt.BigEndian); err != nil {
+		return nil, fmt.Errorf("decode level.dat: %w", err)
+	}
+	d := root.Data
+	mode, ok := world.GameModeByID(int(d.GameType))
+	if !ok {
+		mode = world.GameModeSurvival
+	}
+	diff, ok := world.DifficultyByID(int(d.Difficulty))
+	if !ok {
+		diff = world.DifficultyNormal
+	}
+	return &world.Settings{
+		Name:            d.LevelName,
+		Spawn:           cube.Pos{int(d.SpawnX), int(d.SpawnY), int(d.SpawnZ)},
+		Time:            d.DayTime,
+		TimeCycle:       d.GameRules["doDaylightCycle"] != "false",
+		RainTime:        int64(d.RainTime),
+		Raining:         d.Raining == 1,
+		ThunderTime:     int64(d.ThunderTime),
+		Thundering:      d.Thundering == 1,
+		WeatherCycle:    d.GameRules["doWeatherCycle"] != "false",
+		CurrentTick:     d.Time,
+		DefaultGameMode: mode,
+		Difficulty:      diff,
+		TickRange:       6,
+	}, nil
+}
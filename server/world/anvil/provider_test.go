@@ -0,0 +1,31 @@
+package anvil
+
+import (
+	"container/list"
+	"testing"
+)
+
+func TestProviderRegionEviction(t *testing.T) {
+	p := &Provider{conf: Config{MaxRegions: 2}, dir: t.TempDir(), regions: make(map[regionKey]*list.Element), lru: list.New()}
+	a, b, c := regionKey{dir: "region", x: 0}, regionKey{dir: "region", x: 1}, regionKey{dir: "region", x: 2}
+	for _, k := range []regionKey{a, b, a, c} {
+		if _, err := p.region(k); err != nil {
+			t.Fatalf("open region %v: %v", k, err)
+		}
+	}
+	if len(p.regions) != 2 || p.lru.Len() != 2 {
+		t.Fatalf("expected 2 open regions, got %v (%v in LRU)", len(p.regions), p.lru.Len())
+	}
+	if _, ok := p.regions[b]; ok {
+		t.Errorf("expected least recently used region %v to be released", b)
+	}
+	for _, k := range []regionKey{a, c} {
+		if _, ok := p.regions[k]; !ok {
+			t.Errorf("expected recently used region %v to be kept", k)
+		}
+	}
+	_ = p.Close()
+	if len(p.regions) != 0 || p.lru.Len() != 0 {
+		t.Errorf("expected all regions to be released on close")
+	}
+}
@@ -0,0 +1,146 @@
+package anvil
+
+import (
+	"bytes"
+	"compress/gzip"
+	"compress/zlib"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// ErrChunkNotFound is returned by Region.Chunk if no chunk is stored at the position requested.
+var ErrChunkNotFound = errors.New("chunk not found in region")
+
+const (
+	// sectorSize is the size in bytes of a single sector in a region file.
+	sectorSize = 4096
+	// regionSize is the width and length in chunks of a single region.
+	regionSize = 32
+)
+
+// Compression types that chunks in a region file may be stored with.
+const (
+	compressionGzip = 1
+	compressionZlib = 2
+	compressionNone = 3
+	// compressionExternal is set in the compression type if the chunk is
+	// stored in a separate .mcc file because it was too large.
+	compressionExternal = 128
+)
+
+// Region is a Java Edition Anvil region file (.mca). It holds 32x32 chunks and
+// is read fully into memory when opened.
+type Region struct {
+	dir  string
+	x, z int
+	data []byte
+}
+
+// OpenRegion reads the region file at the path passed. An error is returned if
+// the file could not be read or if it is too small to hold a region header.
+func OpenRegion(path string) (*Region, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("open region: %w", err)
+	}
+	if len(data) != 0 && len(data) < sectorSize*2 {
+		return nil, fmt.Errorf("open region %v: file size %v is smaller than header", path, len(data))
+	}
+	r := &Region{dir: filepath.Dir(path), data: data}
+	// Region files are named r.<x>.<z>.mca. The coordinates are needed to find
+	// chunks that are stored externally.
+	_, _ = fmt.Sscanf(filepath.Base(path), "r.%d.%d.mca", &r.x, &r.z)
+	return r, nil
+}
+
+// Chunks returns the positions, relative to the region, of all chunks stored
+// in the Region.
+func (r *Region) Chunks() [][2]int {
+	var positions [][2]int
+	for i := range regionSize * regionSize {
+		if off, _ := r.location(i); off != 0 {
+			positions = append(positions, [2]int{i % regionSize, i / regionSize})
+		}
+	}
+	return positions
+}
+
+// Chunk reads and decodes the NBT data of the chunk at the position passed. x
+// and z may be either chunk coordinates or coordinates relative to the region.
+// If no chunk is stored at the position, ErrChunkNotFound is returned.
+func (r *Region) Chunk(x, z int) (map[string]any, error) {
+	raw, err := r.raw(x&(regionSize-1) + (z&(regionSize-1))*regionSize)
+	if err != nil {
+		return nil, err
+	}
+	var m map[string]any
+	if err := nbt.UnmarshalEncoding(raw, &m, nbt.BigEndian); err != nil {
+		return nil, fmt.Errorf("decode chunk %v, %v nbt: %w", x, z, err)
+	}
+	return m, nil
+}
+
+// location returns the sector offset and sector count of the chunk at the
+// index passed.
+func (r *Region) location(index int) (offset, sectors int) {
+	if len(r.data) < sectorSize {
+		return 0, 0
+	}
+	loc := binary.BigEndian.Uint32(r.data[index*4:])
+	return int(loc >> 8), int(loc & 0xff)
+}
+
+// raw returns the uncompressed NBT data of the chunk at the index passed.
+func (r *Region) raw(index int) ([]byte, error) {
+	offset, sectors := r.location(index)
+	if offset == 0 || sectors == 0 {
+		return nil, ErrChunkNotFound
+	}
+	start := offset * sectorSize
+	if start+5 > len(r.data) {
+		return nil, fmt.Errorf("chunk %v: offset %v out of bounds", index, start)
+	}
+	length := int(binary.BigEndian.Uint32(r.data[start:]))
+	compression := r.data[start+4]
+	if length < 1 || start+4+length > len(r.data) {
+		return nil, fmt.Errorf("chunk %v: invalid length %v", index, length)
+	}
+	payload := r.data[start+5 : start+4+length]
+
+	if compression&compressionExternal != 0 {
+		compression &^= compressionExternal
+		x, z := r.x*regionSize+index%regionSize, r.z*regionSize+index/regionSize
+		ext, err := os.ReadFile(filepath.Join(r.dir, fmt.Sprintf("c.%v.%v.mcc", x, z)))
+		if err != nil {
+			return nil, fmt.Errorf("chunk %v: read external chunk: %w", index, err)
+		}
+		payload = ext
+	}
+
+	var rd io.Reader
+	switch compression {
+	case compressionGzip:
+		gr, err := gzip.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("chunk %v: %w", index, err)
+		}
+		rd = gr
+	case compressionZlib:
+		zr, err := zlib.NewReader(bytes.NewReader(payload))
+		if err != nil {
+			return nil, fmt.Errorf("chunk %v: %w", index, err)
+		}
+		rd = zr
+	case compressionNone:
+		return payload, nil
+	default:
+		return nil, fmt.Errorf("chunk %v: unsupported compression type %v", index, compression)
+	}
+	return io.ReadAll(rd)
+}
@@ -115,6 +115,12 @@ func BlockByName(name string, properties map[string]any) (Block, bool) {
 	return DefaultBlockRegistry.BlockByName(name, properties)
 }
 
+// BlockDefaultProperties returns a copy of the properties of the default state of the block with the name
+// passed using the DefaultBlockRegistry. If not found, the bool returned is false.
+func BlockDefaultProperties(name string) (map[string]any, bool) {
+	return DefaultBlockRegistry.DefaultProperties(name)
+}
+
 // Blocks returns a slice of all blocks registered in the DefaultBlockRegistry.
 // If you use a non-default registry (NewBlockRegistry), use your registry instance's Blocks() instead.
 func Blocks() []Block {
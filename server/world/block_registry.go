@@ -442,6 +442,17 @@ func (br *BasicBlockRegistry) RuntimeIDToState(runtimeID uint32) (name string, p
 	return name, properties, true
 }
 
+// DefaultProperties returns a copy of the properties of the default state of the block with the name passed.
+// The default state of a block is the first state registered for it. False is returned if no state with the
+// name passed was registered.
+func (br *BasicBlockRegistry) DefaultProperties(name string) (map[string]any, bool) {
+	if !br.finalized {
+		panic("BlockRegistry.DefaultProperties called on non finalized BlockRegistry")
+	}
+	properties, ok := br.blockProperties[name]
+	return maps.Clone(properties), ok
+}
+
 // StateToRuntimeID returns the runtime ID of a block by its name and state properties.
 func (br *BasicBlockRegistry) StateToRuntimeID(name string, properties map[string]any) (runtimeID uint32, found bool) {
 	if !br.finalized {
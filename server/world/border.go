@@ -0,0 +1,167 @@
+package world
+
+import (
+	"math"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Border is the border of a World. Players are unable to move out of the
+// border or place blocks outside it, and players outside the border are
+// damaged. Other entities are not restricted by the border. A zero Border is
+// disabled.
+// The Border is part of the Settings of a World, so worlds sharing their
+// Settings, such as the dimensions of a server, share the same Border. The
+// coordinates and size of the Border are not scaled between dimensions.
+type Border struct {
+	// Centre is the centre of the border on the X and Z axes.
+	Centre mgl64.Vec2
+	// Size is the length of the sides of the border in blocks. If Size is 0 or
+	// lower, the border is disabled.
+	Size float64
+	// TargetSize is the size that the border is shrinking or growing towards.
+	// It is reached once TargetDuration has passed.
+	TargetSize float64
+	// TargetDuration is the time left until the border reaches TargetSize. If
+	// 0, the border does not change in size.
+	TargetDuration time.Duration
+	// DamagePerBlock is the damage dealt every half second to players outside
+	// the border, for every block that they are outside SafeZone.
+	DamagePerBlock float64
+	// SafeZone is the distance in blocks that players may be outside the
+	// border before they start taking damage.
+	SafeZone float64
+	// WarningDistance is the distance in blocks from the border at which
+	// players are warned that they are close to the border.
+	WarningDistance float64
+	// WarningTime is the time before a shrinking border reaches a player at
+	// which the player is warned.
+	WarningTime time.Duration
+}
+
+// NewBorder returns a Border with the centre and size passed and the vanilla
+// defaults for damage and warnings.
+func NewBorder(centre mgl64.Vec2, size float64) Border {
+	return Border{
+		Centre:          centre,
+		Size:            size,
+		DamagePerBlock:  0.2,
+		SafeZone:        5,
+		WarningDistance: 5,
+		WarningTime:     time.Second * 15,
+	}
+}
+
+// Enabled checks if the Border is enabled, meaning its Size is above 0.
+func (b Border) Enabled() bool {
+	return b.Size > 0
+}
+
+// Bounds returns the minimum and maximum X and Z coordinates of the Border.
+func (b Border) Bounds() (min, max mgl64.Vec2) {
+	half := b.Size / 2
+	return b.Centre.Sub(mgl64.Vec2{half, half}), b.Centre.Add(mgl64.Vec2{half, half})
+}
+
+// Distance returns the distance from the position passed to the nearest side
+// of the Border. The distance is positive if the position is inside the
+// Border and negative if outside it. If the Border is disabled, Distance
+// returns +Inf.
+func (b Border) Distance(pos mgl64.Vec3) float64 {
+	if !b.Enabled() {
+		return math.Inf(1)
+	}
+	min, max := b.Bounds()
+	return math.Min(
+		math.Min(pos[0]-min[0], max[0]-pos[0]),
+		math.Min(pos[2]-min[1], max[1]-pos[2]),
+	)
+}
+
+// Contains checks if the position passed is within the Border.
+func (b Border) Contains(pos mgl64.Vec3) bool {
+	return b.Distance(pos) >= 0
+}
+
+// ContainsBlock checks if the centre of the block at the position passed is
+// within the Border.
+func (b Border) ContainsBlock(pos cube.Pos) bool {
+	return b.Contains(pos.Vec3Centre())
+}
+
+// Warns checks if a player at the position passed should be warned about the
+// Border, either because the position is within WarningDistance of the
+// Border or because a shrinking Border will reach it within WarningTime.
+func (b Border) Warns(pos mgl64.Vec3) bool {
+	if !b.Enabled() {
+		return false
+	}
+	dist := b.Distance(pos)
+	if dist < b.WarningDistance {
+		return true
+	}
+	if b.TargetDuration <= 0 || b.TargetSize >= b.Size {
+		return false
+	}
+	// Every side of the border moves half of the total size difference.
+	speed := (b.Size - b.TargetSize) / 2 / b.TargetDuration.Seconds()
+	return time.Duration(dist/speed*float64(time.Second)) < b.WarningTime
+}
+
+// tick advances the Border by a single tick, moving its Size towards the
+// TargetSize.
+func (b *Border) tick() {
+	if b.TargetDuration <= 0 {
+		return
+	}
+	const tick = time.Second / 20
+	if b.TargetDuration <= tick {
+		b.Size, b.TargetDuration = b.TargetSize, 0
+		return
+	}
+	b.Size += (b.TargetSize - b.Size) / float64(b.TargetDuration/tick)
+	b.TargetDuration -= tick
+}
+
+// Border returns the current Border of the World. If the World has no border,
+// the Border returned is disabled.
+func (w *World) Border() Border {
+	if w == nil {
+		return Border{}
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	return w.set.Border
+}
+
+// SetBorder changes the Border of the World. Passing a zero Border disables
+// the border.
+func (w *World) SetBorder(b Border) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	w.set.Border = b
+}
+
+// ResizeBorder changes the size of the Border of the World to the size passed
+// over the duration d. If d is 0 or lower, the Border is resized immediately.
+// ResizeBorder has no effect if the World has no Border.
+func (w *World) ResizeBorder(size float64, d time.Duration) {
+	if w == nil {
+		return
+	}
+	w.set.Lock()
+	defer w.set.Unlock()
+	if !w.set.Border.Enabled() {
+		return
+	}
+	if d <= 0 {
+		w.set.Border.Size, w.set.Border.TargetDuration = size, 0
+		return
+	}
+	w.set.Border.TargetSize, w.set.Border.TargetDuration = size, d
+}
@@ -0,0 +1,52 @@
+package world
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+func TestBorder(t *testing.T) {
+	b := NewBorder(mgl64.Vec2{10, -10}, 20)
+	if d := b.Distance(mgl64.Vec3{10, 64, -10}); d != 10 {
+		t.Errorf("expected distance 10 from centre, got %v", d)
+	}
+	if d := b.Distance(mgl64.Vec3{22, 64, -10}); d != -2 {
+		t.Errorf("expected distance -2 outside border, got %v", d)
+	}
+	if !b.ContainsBlock(cube.Pos{19, 0, -19}) || b.ContainsBlock(cube.Pos{20, 0, -10}) {
+		t.Errorf("unexpected blocks contained by border")
+	}
+	if b.Warns(mgl64.Vec3{10, 64, -10}) || !b.Warns(mgl64.Vec3{17, 64, -10}) {
+		t.Errorf("unexpected warnings for static border")
+	}
+	if (Border{}).Contains(mgl64.Vec3{1e9, 0, 1e9}) == false || (Border{}).Warns(mgl64.Vec3{}) {
+		t.Errorf("disabled border should contain all positions without warning")
+	}
+
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	w.SetBorder(b)
+	w.ResizeBorder(10, time.Second)
+	// The border shrinks 5 blocks on every side in a second, so a player in
+	// the centre is reached in 2 seconds, within the warning time.
+	if !w.Border().Warns(mgl64.Vec3{10, 64, -10}) {
+		t.Errorf("expected warning for shrinking border within warning time")
+	}
+	for range 10 {
+		w.AdvanceTick()
+	}
+	if s := w.Border().Size; math.Abs(s-15) > 1e-9 {
+		t.Errorf("expected border size 15 after half the resize duration, got %v", s)
+	}
+	for range 10 {
+		w.AdvanceTick()
+	}
+	if got := w.Border(); got.Size != 10 || got.TargetDuration != 0 {
+		t.Errorf("expected border to reach size 10, got %v (%v left)", got.Size, got.TargetDuration)
+	}
+}
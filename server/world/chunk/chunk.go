@@ -26,6 +26,9 @@ type Chunk struct {
 	sub []*SubChunk
 	// biomes is an array of biome IDs. There is one biome ID for every column in the chunk.
 	biomes []*PalettedStorage
+	// sharedBiomes is true if the biome storages are shared with another Chunk as a result of Fork, in which
+	// case they are copied before being changed.
+	sharedBiomes bool
 }
 
 // New initialises a new chunk and returns it, so that it may be used.
@@ -69,6 +72,28 @@ func (chunk *Chunk) Clone() *Chunk {
 	return clone
 }
 
+// Fork returns a copy of the Chunk that shares its block and biome storages with the Chunk. Storages are
+// copied lazily by the fork as soon as they are changed, making Fork much cheaper than Clone in both time and
+// memory when only few parts of the fork are changed. Because the storages of the Chunk itself are not copied
+// when changed, the Chunk forked must not be changed afterwards. Fork is therefore intended for chunks that
+// serve as an immutable template.
+func (chunk *Chunk) Fork() *Chunk {
+	fork := &Chunk{
+		r:                    chunk.r,
+		br:                   chunk.br,
+		air:                  chunk.air,
+		recalculateHeightMap: chunk.recalculateHeightMap,
+		heightMap:            slices.Clone(chunk.heightMap),
+		sub:                  make([]*SubChunk, len(chunk.sub)),
+		biomes:               slices.Clone(chunk.biomes),
+		sharedBiomes:         true,
+	}
+	for i, sub := range chunk.sub {
+		fork.sub[i] = sub.fork()
+	}
+	return fork
+}
+
 // Equals returns if the chunk passed is equal to the current one
 func (chunk *Chunk) Equals(c *Chunk) bool {
 	if !chunk.recalculateHeightMap && !c.recalculateHeightMap && !slices.Equal(c.heightMap, chunk.heightMap) {
@@ -128,6 +153,12 @@ func (chunk *Chunk) Biome(x uint8, y int16, z uint8) uint32 {
 
 // SetBiome sets the biome ID at a specific column in the chunk.
 func (chunk *Chunk) SetBiome(x uint8, y int16, z uint8, biome uint32) {
+	if chunk.sharedBiomes {
+		for i, biomes := range chunk.biomes {
+			chunk.biomes[i] = biomes.Clone()
+		}
+		chunk.sharedBiomes = false
+	}
 	chunk.biomes[chunk.SubIndex(y)].Set(x, uint8(y), z, biome)
 }
 
@@ -1,6 +1,9 @@
 package chunk
 
 import (
+	"maps"
+	"slices"
+
 	"github.com/df-mc/dragonfly/server/block/cube"
 )
 
@@ -12,6 +15,27 @@ type Column struct {
 	ScheduledBlocks []ScheduledBlockUpdate
 }
 
+// Fork returns a copy of the Column whose Chunk is forked from the Chunk of
+// the Column using Chunk.Fork. Entities, block entities and scheduled block
+// updates are copied, so that the fork may be changed freely. Like with
+// Chunk.Fork, the Column forked must not be changed afterwards.
+func (col *Column) Fork() *Column {
+	c := &Column{
+		Chunk:           col.Chunk.Fork(),
+		Entities:        make([]Entity, len(col.Entities)),
+		BlockEntities:   make([]BlockEntity, len(col.BlockEntities)),
+		Tick:            col.Tick,
+		ScheduledBlocks: slices.Clone(col.ScheduledBlocks),
+	}
+	for i, e := range col.Entities {
+		c.Entities[i] = Entity{ID: e.ID, Data: maps.Clone(e.Data)}
+	}
+	for i, be := range col.BlockEntities {
+		c.BlockEntities[i] = BlockEntity{Pos: be.Pos, Data: maps.Clone(be.Data)}
+	}
+	return c
+}
+
 type BlockEntity struct {
 	Pos  cube.Pos
 	Data map[string]any
@@ -9,6 +9,9 @@ type SubChunk struct {
 	storages   []*PalettedStorage
 	blockLight []uint8
 	skyLight   []uint8
+	// shared is true if the storages are shared with another SubChunk as a result of Chunk.Fork, in which case
+	// they are copied before being changed.
+	shared bool
 }
 
 // Equals returns if the sub chunk passed is equal to the current one.
@@ -45,6 +48,29 @@ func (sub *SubChunk) Clone() *SubChunk {
 	return clone
 }
 
+// fork returns a copy of the SubChunk that shares its storages with the SubChunk until they are changed.
+func (sub *SubChunk) fork() *SubChunk {
+	return &SubChunk{
+		air:        sub.air,
+		storages:   slices.Clone(sub.storages),
+		blockLight: cloneLight(sub.blockLight),
+		skyLight:   cloneLight(sub.skyLight),
+		shared:     len(sub.storages) != 0,
+	}
+}
+
+// own copies the storages of the SubChunk if they are shared with another SubChunk, so that they may be
+// changed.
+func (sub *SubChunk) own() {
+	if !sub.shared {
+		return
+	}
+	for i, storage := range sub.storages {
+		sub.storages[i] = storage.Clone()
+	}
+	sub.shared = false
+}
+
 func cloneLight(light []uint8) []uint8 {
 	if len(light) == 0 {
 		return slices.Clone(light)
@@ -68,6 +94,7 @@ func (sub *SubChunk) Empty() bool {
 // Layer returns a certain block storage/layer from a sub chunk. If no storage at the layer exists, the layer
 // is created, as well as all layers between the current highest layer and the new highest layer.
 func (sub *SubChunk) Layer(layer uint8) *PalettedStorage {
+	sub.own()
 	for uint8(len(sub.storages)) <= layer {
 		// Keep appending to storages until the requested layer is achieved. Makes working with new layers
 		// much easier.
@@ -136,6 +163,10 @@ func (sub *SubChunk) SkyLight(x, y, z byte) uint8 {
 // Compact cleans the garbage from all block storages that sub chunk contains, so that they may be
 // cleanly written to a database.
 func (sub *SubChunk) compact() {
+	if sub.shared {
+		// Shared storages are never changed, so there is nothing to compact.
+		return
+	}
 	newStorages := make([]*PalettedStorage, 0, len(sub.storages))
 	for _, storage := range sub.storages {
 		storage.compact()
@@ -4,6 +4,8 @@ import (
 	"log/slog"
 	"math/rand/v2"
 	"time"
+
+	"github.com/df-mc/dragonfly/server/metrics"
 )
 
 type blockRegistrySetter interface {
@@ -76,6 +78,13 @@ type Config struct {
 	// use NewBlockRegistry(), register blocks/states, and call Finalize().
 	Blocks BlockRegistry
 
+	// Metrics is the metrics.Registry that metrics of the World, such as tick
+	// durations, loaded chunks and chunk load latency, are recorded in. If
+	// nil, no metrics are recorded. Metrics have a world and dimension label
+	// holding the name and Dimension of the World, and an id label unique to
+	// the World, so Worlds sharing a Registry may have the same name.
+	Metrics *metrics.Registry
+
 	// Synchronous removes the World's own background goroutines. Immediate tasks
 	// from World.Do and Call run on the calling goroutine, the World is not saved
 	// or unloaded automatically, and time only passes on explicit
@@ -161,6 +170,7 @@ func (conf Config) New() *World {
 		conf:             conf,
 		ra:               conf.Dim.Range(),
 		set:              s,
+		metrics:          newWorldMetrics(conf.Metrics, s.Name, conf.Dim),
 	}
 	w.chunkWorkers = newChunkWorkerPool(w)
 	w.weather = weather{w: w}
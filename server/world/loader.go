@@ -49,6 +49,9 @@ func (l *Loader) ChangeWorld(tx *Tx, new *World) {
 	defer l.mu.Unlock()
 
 	loaded := maps.Clone(l.loaded)
+	if vl := l.viewLayer(); vl != nil {
+		vl.removeBlocks()
+	}
 	l.w.exec(func(tx *Tx) {
 		for pos := range loaded {
 			tx.World().removeViewer(tx, pos, l)
@@ -165,6 +168,9 @@ func (l *Loader) Close(tx *Tx) {
 	for pos := range l.loaded {
 		tx.World().removeViewer(tx, pos, l)
 	}
+	if vl := l.viewLayer(); vl != nil {
+		vl.removeBlocks()
+	}
 	l.loaded = map[ChunkPos]*Column{}
 	clear(l.pending)
 
@@ -191,10 +197,21 @@ func (l *Loader) evictUnused(tx *Tx) {
 		if !l.withinLoadRadius(pos) {
 			delete(l.loaded, pos)
 			l.w.removeViewer(tx, pos, l)
+			if vl := l.viewLayer(); vl != nil {
+				vl.removeChunkBlocks(pos)
+			}
 		}
 	}
 }
 
+// viewLayer returns the ViewLayer of the Loader's Viewer, or nil if it does not have one.
+func (l *Loader) viewLayer() *ViewLayer {
+	if v, ok := l.viewer.(viewLayerViewer); ok {
+		return v.ViewLayer()
+	}
+	return nil
+}
+
 // withinLoadRadius checks if a chunk position is within the Loader's radius.
 func (l *Loader) withinLoadRadius(pos ChunkPos) bool {
 	return chunkDistance(pos, l.pos) <= int32(l.r)
@@ -0,0 +1,156 @@
+package mapdb
+
+import (
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// magic is written at the start of every map file, followed by the version of
+// the format.
+const (
+	magic   = "DFMAP"
+	version = 2
+)
+
+// fileData is the NBT structure stored, compressed, in a map file.
+type fileData struct {
+	Settings settingsData
+	Columns  []columnData
+}
+
+// settingsData holds the fields of world.Settings stored in a map file.
+type settingsData struct {
+	Name               string
+	SpawnX             int32
+	SpawnY             int32
+	SpawnZ             int32
+	Time               int64
+	TimeCycle          bool
+	RainTime           int64
+	Raining            bool
+	ThunderTime        int64
+	Thundering         bool
+	WeatherCycle       bool
+	RequiredSleepTicks int64
+	CurrentTick        int64
+	DefaultGameMode    int32
+	Difficulty         int32
+	TickRange          int32
+	Border             borderData
+}
+
+// borderData holds the fields of world.Border stored in a map file.
+type borderData struct {
+	CentreX, CentreZ float64
+	Size, TargetSize float64
+	TargetDuration   int64
+	DamagePerBlock   float64
+	SafeZone         float64
+	WarningDistance  float64
+	WarningTime      int64
+}
+
+// columnData is the encoded form of a chunk.Column in a map file.
+type columnData struct {
+	X, Z            int32
+	Dimension       int32
+	SubChunks       [][]byte
+	Biomes          []byte
+	Entities        []entityData
+	BlockEntities   []blockEntityData
+	Tick            int64
+	ScheduledBlocks []scheduledData
+}
+
+// entityData is the encoded form of a chunk.Entity.
+type entityData struct {
+	ID   int64
+	Data map[string]any
+}
+
+// blockEntityData is the encoded form of a chunk.BlockEntity.
+type blockEntityData struct {
+	X, Y, Z int32
+	Data    map[string]any
+}
+
+// scheduledData is the encoded form of a chunk.ScheduledBlockUpdate. Block
+// holds the name, states and version of the block, so that the update can be
+// resolved on load even if runtime IDs changed since the file was written.
+type scheduledData struct {
+	X, Y, Z int32
+	Block   map[string]any
+	Tick    int64
+}
+
+// encodeSettings converts world.Settings to settingsData.
+func encodeSettings(s *world.Settings) settingsData {
+	s.Lock()
+	defer s.Unlock()
+	mode, _ := world.GameModeID(s.DefaultGameMode)
+	diff, _ := world.DifficultyID(s.Difficulty)
+	return settingsData{
+		Name:               s.Name,
+		SpawnX:             int32(s.Spawn[0]),
+		SpawnY:             int32(s.Spawn[1]),
+		SpawnZ:             int32(s.Spawn[2]),
+		Time:               s.Time,
+		TimeCycle:          s.TimeCycle,
+		RainTime:           s.RainTime,
+		Raining:            s.Raining,
+		ThunderTime:        s.ThunderTime,
+		Thundering:         s.Thundering,
+		WeatherCycle:       s.WeatherCycle,
+		RequiredSleepTicks: s.RequiredSleepTicks,
+		CurrentTick:        s.CurrentTick,
+		DefaultGameMode:    int32(mode),
+		Difficulty:         int32(diff),
+		TickRange:          s.TickRange,
+		Border: borderData{
+			CentreX:         s.Border.Centre[0],
+			CentreZ:         s.Border.Centre[1],
+			Size:            s.Border.Size,
+			TargetSize:      s.Border.TargetSize,
+			TargetDuration:  int64(s.Border.TargetDuration),
+			DamagePerBlock:  s.Border.DamagePerBlock,
+			SafeZone:        s.Border.SafeZone,
+			WarningDistance: s.Border.WarningDistance,
+			WarningTime:     int64(s.Border.WarningTime),
+		},
+	}
+}
+
+// settings converts settingsData to a new world.Settings.
+func (d settingsData) settings() *world.Settings {
+	mode, _ := world.GameModeByID(int(d.DefaultGameMode))
+	diff, _ := world.DifficultyByID(int(d.Difficulty))
+	return &world.Settings{
+		Name:               d.Name,
+		Spawn:              cube.Pos{int(d.SpawnX), int(d.SpawnY), int(d.SpawnZ)},
+		Time:               d.Time,
+		TimeCycle:          d.TimeCycle,
+		RainTime:           d.RainTime,
+		Raining:            d.Raining,
+		ThunderTime:        d.ThunderTime,
+		Thundering:         d.Thundering,
+		WeatherCycle:       d.WeatherCycle,
+		RequiredSleepTicks: d.RequiredSleepTicks,
+		CurrentTick:        d.CurrentTick,
+		DefaultGameMode:    mode,
+		Difficulty:         diff,
+		TickRange:          d.TickRange,
+		Border: world.Border{
+			Centre:          mgl64.Vec2{d.Border.CentreX, d.Border.CentreZ},
+			Size:            d.Border.Size,
+			TargetSize:      d.Border.TargetSize,
+			TargetDuration:  time.Duration(d.Border.TargetDuration),
+			DamagePerBlock:  d.Border.DamagePerBlock,
+			SafeZone:        d.Border.SafeZone,
+			WarningDistance: d.Border.WarningDistance,
+			WarningTime:     time.Duration(d.Border.WarningTime),
+		},
+	}
+}
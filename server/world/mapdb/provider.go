@@ -0,0 +1,120 @@
+package mapdb
+
+import (
+	"fmt"
+	"maps"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/google/uuid"
+)
+
+// Compile time check to make sure Provider implements world.Provider.
+var _ world.Provider = (*Provider)(nil)
+
+// Provider is a copy-on-write world.Provider backed by a Template. Columns
+// are read from the Template until they are stored to the Provider, after
+// which the stored copy is used instead. Nothing is ever written to disk:
+// Changes are lost when the Provider is closed, unless they are saved using
+// Provider.Template.
+type Provider struct {
+	t *Template
+
+	mu      sync.Mutex
+	set     *world.Settings
+	changed map[columnKey]*chunk.Column
+	spawns  map[uuid.UUID]cube.Pos
+}
+
+// Settings returns the world.Settings of the Provider, which are a copy of
+// the settings of its Template.
+func (p *Provider) Settings() *world.Settings {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.set
+}
+
+// SaveSettings keeps the world.Settings passed in memory.
+func (p *Provider) SaveSettings(s *world.Settings) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.set = s
+}
+
+// LoadPlayerSpawnPosition returns the spawn position of a player previously
+// saved using SavePlayerSpawnPosition.
+func (p *Provider) LoadPlayerSpawnPosition(id uuid.UUID) (cube.Pos, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pos, ok := p.spawns[id]
+	return pos, ok, nil
+}
+
+// SavePlayerSpawnPosition keeps the spawn position of a player in memory.
+func (p *Provider) SavePlayerSpawnPosition(id uuid.UUID, pos cube.Pos) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.spawns == nil {
+		p.spawns = make(map[uuid.UUID]cube.Pos)
+	}
+	p.spawns[id] = pos
+	return nil
+}
+
+// LoadColumn returns a copy of the column at the position and dimension
+// passed. If the column was stored to the Provider before, a copy of the
+// stored column is returned. Otherwise, a copy of the Template's column is
+// returned. If neither exists, errors.Is(err, leveldb.ErrNotFound) equals
+// true.
+func (p *Provider) LoadColumn(pos world.ChunkPos, dim world.Dimension) (*chunk.Column, error) {
+	k := columnKey{pos: pos, dim: dim}
+	p.mu.Lock()
+	col, ok := p.changed[k]
+	p.mu.Unlock()
+	if ok {
+		return col.Fork(), nil
+	}
+	if col = p.t.column(k); col == nil {
+		return nil, fmt.Errorf("load column %v (%v): %w", pos, dim, leveldb.ErrNotFound)
+	}
+	return col, nil
+}
+
+// StoreColumn stores a copy of the column passed in memory. The Template of
+// the Provider is not changed.
+func (p *Provider) StoreColumn(pos world.ChunkPos, dim world.Dimension, col *chunk.Column) error {
+	// The chunk of the column is still used by the World after storing it, so
+	// a copy is stored that is never changed and may be forked when loading.
+	c := *col
+	c.Chunk = col.Chunk.Clone()
+	col = &c
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changed[columnKey{pos: pos, dim: dim}] = col
+	return nil
+}
+
+// Template returns a new Template holding the columns of the Provider's
+// Template with the columns stored to the Provider applied over them, and
+// the current settings of the Provider. This Template may be written to a
+// file to persist the changes made.
+func (p *Provider) Template() *Template {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	// Stored columns are never changed after storing them, so they may be
+	// shared with the new Template.
+	columns := maps.Clone(p.t.columns)
+	maps.Copy(columns, p.changed)
+	return &Template{conf: p.t.conf, set: encodeSettings(p.set), columns: columns}
+}
+
+// Close releases the columns stored to the Provider.
+func (p *Provider) Close() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	clear(p.changed)
+	return nil
+}
@@ -0,0 +1,267 @@
+package mapdb
+
+import (
+	"bytes"
+	"compress/flate"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/sandertv/gophertunnel/minecraft/nbt"
+)
+
+// Config holds the optional parameters used to read a Template.
+type Config struct {
+	// Log is the Logger that will be used to log errors and debug messages to.
+	// If set to nil, Log is set to slog.Default().
+	Log *slog.Logger
+	// Blocks is the BlockRegistry used for chunk decoding/encoding. If nil,
+	// world.DefaultBlockRegistry is used. When using a non-default registry,
+	// pass the same registry used by the World.
+	Blocks world.BlockRegistry
+}
+
+// Template is an immutable, bounded map held fully in memory. It is read from
+// a single compressed file and decoded once, after which any number of
+// Providers may be created from it. Loading a column from a Provider only
+// forks the decoded column of the Template, so that many worlds may share a
+// single Template without decoding or disk I/O.
+type Template struct {
+	conf    Config
+	set     settingsData
+	columns map[columnKey]*chunk.Column
+}
+
+// columnKey identifies a column in a Template.
+type columnKey struct {
+	pos world.ChunkPos
+	dim world.Dimension
+}
+
+// Area is a bounded area of chunks in a dimension, with Min and Max
+// inclusive.
+type Area struct {
+	Dimension world.Dimension
+	Min, Max  world.ChunkPos
+}
+
+// ReadFile reads a Template from the map file at the path passed. An error is
+// returned if the file could not be read or decoded.
+func (conf Config) ReadFile(path string) (*Template, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("read map: %w", err)
+	}
+	defer f.Close()
+	return conf.Read(f)
+}
+
+// Read reads a Template from the io.Reader passed, which holds data written
+// by Template.WriteTo.
+func (conf Config) Read(r io.Reader) (*Template, error) {
+	conf = conf.withDefaults()
+	header := make([]byte, len(magic)+1)
+	if _, err := io.ReadFull(r, header); err != nil {
+		return nil, fmt.Errorf("read map: header: %w", err)
+	}
+	if string(header[:len(magic)]) != magic {
+		return nil, fmt.Errorf("read map: not a map file")
+	}
+	if header[len(magic)] != version {
+		return nil, fmt.Errorf("read map: unsupported version %v", header[len(magic)])
+	}
+	raw, err := io.ReadAll(flate.NewReader(r))
+	if err != nil {
+		return nil, fmt.Errorf("read map: decompress: %w", err)
+	}
+	var data fileData
+	if err := nbt.UnmarshalEncoding(raw, &data, nbt.LittleEndian); err != nil {
+		return nil, fmt.Errorf("read map: decode: %w", err)
+	}
+
+	t := &Template{conf: conf, set: data.Settings, columns: make(map[columnKey]*chunk.Column, len(data.Columns))}
+	for _, c := range data.Columns {
+		dim, ok := world.DimensionByID(int(c.Dimension))
+		if !ok {
+			return nil, fmt.Errorf("read map: column %v, %v: unknown dimension %v", c.X, c.Z, c.Dimension)
+		}
+		col, err := conf.decodeColumn(c, dim.Range())
+		if err != nil {
+			return nil, fmt.Errorf("read map: column %v, %v: %w", c.X, c.Z, err)
+		}
+		t.columns[columnKey{pos: world.ChunkPos{c.X, c.Z}, dim: dim}] = col
+	}
+	return t, nil
+}
+
+// FromProvider creates a Template from the columns in the areas passed of a
+// world.Provider, and the settings of the world.Provider. Columns in the
+// areas that do not exist in the world.Provider are skipped.
+func (conf Config) FromProvider(src world.Provider, areas ...Area) (*Template, error) {
+	t := &Template{conf: conf.withDefaults(), set: encodeSettings(src.Settings()), columns: make(map[columnKey]*chunk.Column)}
+	for _, a := range areas {
+		for x := a.Min[0]; x <= a.Max[0]; x++ {
+			for z := a.Min[1]; z <= a.Max[1]; z++ {
+				pos := world.ChunkPos{x, z}
+				col, err := src.LoadColumn(pos, a.Dimension)
+				if errors.Is(err, leveldb.ErrNotFound) {
+					continue
+				} else if err != nil {
+					return nil, fmt.Errorf("template from provider: %w", err)
+				}
+				t.columns[columnKey{pos: pos, dim: a.Dimension}] = col
+			}
+		}
+	}
+	return t, nil
+}
+
+// Settings returns a new copy of the world.Settings stored in the Template.
+func (t *Template) Settings() *world.Settings {
+	return t.set.settings()
+}
+
+// Len returns the number of columns stored in the Template.
+func (t *Template) Len() int {
+	return len(t.columns)
+}
+
+// Provider creates a new copy-on-write Provider that reads from the Template.
+// Columns stored to the Provider are kept in memory by the Provider only, and
+// never change the Template.
+func (t *Template) Provider() *Provider {
+	return &Provider{
+		t:       t,
+		set:     t.Settings(),
+		changed: make(map[columnKey]*chunk.Column),
+	}
+}
+
+// WriteFile writes the Template to a map file at the path passed.
+func (t *Template) WriteFile(path string) error {
+	f, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("write map: %w", err)
+	}
+	if _, err := t.WriteTo(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+// WriteTo writes the Template, compressed, to the io.Writer passed.
+func (t *Template) WriteTo(w io.Writer) (int64, error) {
+	data := fileData{Settings: t.set, Columns: make([]columnData, 0, len(t.columns))}
+	for k, col := range t.columns {
+		dim, ok := world.DimensionID(k.dim)
+		if !ok {
+			return 0, fmt.Errorf("write map: column %v: unknown dimension %v", k.pos, k.dim)
+		}
+		data.Columns = append(data.Columns, t.conf.encodeColumn(k.pos, int32(dim), col))
+	}
+	raw, err := nbt.MarshalEncoding(data, nbt.LittleEndian)
+	if err != nil {
+		return 0, fmt.Errorf("write map: encode: %w", err)
+	}
+	buf := bytes.NewBuffer(append([]byte(magic), version))
+	fw, _ := flate.NewWriter(buf, flate.BestCompression)
+	_, _ = fw.Write(raw)
+	_ = fw.Close()
+	n, err := w.Write(buf.Bytes())
+	if err != nil {
+		return int64(n), fmt.Errorf("write map: %w", err)
+	}
+	return int64(n), nil
+}
+
+// column returns a fork of the column at the key passed, as returned by
+// chunk.Column.Fork, or nil if the Template does not hold a column at the key.
+func (t *Template) column(k columnKey) *chunk.Column {
+	if col, ok := t.columns[k]; ok {
+		return col.Fork()
+	}
+	return nil
+}
+
+// withDefaults returns a copy of conf with default values set for fields
+// that were left empty.
+func (conf Config) withDefaults() Config {
+	if conf.Log == nil {
+		conf.Log = slog.Default()
+	}
+	conf.Log = conf.Log.With("provider", "mapdb")
+	if conf.Blocks == nil {
+		conf.Blocks = world.DefaultBlockRegistry
+	}
+	conf.Blocks.Finalize()
+	return conf
+}
+
+// encodeColumn encodes a chunk.Column at a position to columnData.
+func (conf Config) encodeColumn(pos world.ChunkPos, dim int32, col *chunk.Column) columnData {
+	data := chunk.Encode(col.Chunk, chunk.DiskEncoding)
+	c := columnData{
+		X:               pos[0],
+		Z:               pos[1],
+		Dimension:       dim,
+		SubChunks:       data.SubChunks,
+		Biomes:          data.Biomes,
+		Entities:        make([]entityData, len(col.Entities)),
+		BlockEntities:   make([]blockEntityData, len(col.BlockEntities)),
+		Tick:            col.Tick,
+		ScheduledBlocks: make([]scheduledData, len(col.ScheduledBlocks)),
+	}
+	for i, e := range col.Entities {
+		c.Entities[i] = entityData{ID: e.ID, Data: e.Data}
+	}
+	for i, be := range col.BlockEntities {
+		c.BlockEntities[i] = blockEntityData{X: int32(be.Pos[0]), Y: int32(be.Pos[1]), Z: int32(be.Pos[2]), Data: be.Data}
+	}
+	bpe := chunk.BlockPaletteEncoding{Blocks: conf.Blocks}
+	for i, s := range col.ScheduledBlocks {
+		state := bpe.EncodeBlockState(s.Block)
+		c.ScheduledBlocks[i] = scheduledData{
+			X: int32(s.Pos[0]), Y: int32(s.Pos[1]), Z: int32(s.Pos[2]), Tick: s.Tick,
+			Block: map[string]any{"name": state.Name, "states": state.State, "version": state.Version},
+		}
+	}
+	return c
+}
+
+// decodeColumn decodes columnData to a chunk.Column with the range passed.
+func (conf Config) decodeColumn(c columnData, r cube.Range) (*chunk.Column, error) {
+	ch, err := chunk.DiskDecode(conf.Blocks, chunk.SerialisedData{SubChunks: c.SubChunks, Biomes: c.Biomes}, r)
+	if err != nil {
+		return nil, err
+	}
+	col := &chunk.Column{
+		Chunk:           ch,
+		Entities:        make([]chunk.Entity, len(c.Entities)),
+		BlockEntities:   make([]chunk.BlockEntity, len(c.BlockEntities)),
+		Tick:            c.Tick,
+		ScheduledBlocks: make([]chunk.ScheduledBlockUpdate, 0, len(c.ScheduledBlocks)),
+	}
+	for i, e := range c.Entities {
+		col.Entities[i] = chunk.Entity{ID: e.ID, Data: e.Data}
+	}
+	for i, be := range c.BlockEntities {
+		col.BlockEntities[i] = chunk.BlockEntity{Pos: cube.Pos{int(be.X), int(be.Y), int(be.Z)}, Data: be.Data}
+	}
+	bpe := chunk.BlockPaletteEncoding{Blocks: conf.Blocks}
+	for _, s := range c.ScheduledBlocks {
+		block, err := bpe.DecodeBlockState(s.Block)
+		if err != nil {
+			conf.Log.Error("decode scheduled update: " + err.Error())
+			continue
+		}
+		col.ScheduledBlocks = append(col.ScheduledBlocks, chunk.ScheduledBlockUpdate{Pos: cube.Pos{int(s.X), int(s.Y), int(s.Z)}, Block: block, Tick: s.Tick})
+	}
+	return col, nil
+}
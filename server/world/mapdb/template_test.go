@@ -0,0 +1,86 @@
+package mapdb
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+func TestTemplateCopyOnWrite(t *testing.T) {
+	base := (&Template{conf: Config{}.withDefaults(), columns: make(map[columnKey]*chunk.Column)}).Provider()
+	col := &chunk.Column{Chunk: chunk.New(world.DefaultBlockRegistry, world.Overworld.Range())}
+	stone := world.BlockRuntimeID(block.Stone{})
+	col.Chunk.SetBlock(1, 2, 3, 0, stone)
+	col.BlockEntities = []chunk.BlockEntity{{Pos: cube.Pos{1, 2, 3}, Data: map[string]any{"id": "Test"}}}
+	if err := base.StoreColumn(world.ChunkPos{4, 5}, world.Overworld, col); err != nil {
+		t.Fatalf("store column: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := base.Template().WriteTo(&buf); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	tmpl, err := Config{}.Read(&buf)
+	if err != nil {
+		t.Fatalf("read template: %v", err)
+	}
+	a, b := tmpl.Provider(), tmpl.Provider()
+
+	colA, err := a.LoadColumn(world.ChunkPos{4, 5}, world.Overworld)
+	if err != nil {
+		t.Fatalf("load column: %v", err)
+	}
+	if rid := colA.Chunk.Block(1, 2, 3, 0); rid != stone {
+		t.Fatalf("expected stone (%v) at 1 2 3, got %v", stone, rid)
+	}
+	if len(colA.BlockEntities) != 1 || colA.BlockEntities[0].Pos != (cube.Pos{1, 2, 3}) {
+		t.Fatalf("expected block entity at 1 2 3, got %v", colA.BlockEntities)
+	}
+	colA.Chunk.SetBlock(1, 2, 3, 0, world.DefaultBlockRegistry.AirRuntimeID())
+	if err := a.StoreColumn(world.ChunkPos{4, 5}, world.Overworld, colA); err != nil {
+		t.Fatalf("store column: %v", err)
+	}
+
+	colA, _ = a.LoadColumn(world.ChunkPos{4, 5}, world.Overworld)
+	colB, _ := b.LoadColumn(world.ChunkPos{4, 5}, world.Overworld)
+	if rid := colA.Chunk.Block(1, 2, 3, 0); rid != world.DefaultBlockRegistry.AirRuntimeID() {
+		t.Errorf("expected changed column to be loaded from provider, got block %v", rid)
+	}
+	if rid := colB.Chunk.Block(1, 2, 3, 0); rid != stone {
+		t.Errorf("expected other provider to be unaffected by changes, got block %v", rid)
+	}
+	if _, err := a.LoadColumn(world.ChunkPos{0, 0}, world.Overworld); err == nil {
+		t.Errorf("expected error loading missing column, got nil")
+	}
+}
+
+func TestTemplateScheduledBlocks(t *testing.T) {
+	base := (&Template{conf: Config{}.withDefaults(), columns: make(map[columnKey]*chunk.Column)}).Provider()
+	col := &chunk.Column{Chunk: chunk.New(world.DefaultBlockRegistry, world.Overworld.Range())}
+	water := world.BlockRuntimeID(block.Water{Depth: 8})
+	col.ScheduledBlocks = []chunk.ScheduledBlockUpdate{{Pos: cube.Pos{1, 2, 3}, Block: water, Tick: 20}}
+	if err := base.StoreColumn(world.ChunkPos{}, world.Overworld, col); err != nil {
+		t.Fatalf("store column: %v", err)
+	}
+
+	var buf bytes.Buffer
+	if _, err := base.Template().WriteTo(&buf); err != nil {
+		t.Fatalf("write template: %v", err)
+	}
+	tmpl, err := Config{}.Read(&buf)
+	if err != nil {
+		t.Fatalf("read template: %v", err)
+	}
+	col, err = tmpl.Provider().LoadColumn(world.ChunkPos{}, world.Overworld)
+	if err != nil {
+		t.Fatalf("load column: %v", err)
+	}
+	want := chunk.ScheduledBlockUpdate{Pos: cube.Pos{1, 2, 3}, Block: water, Tick: 20}
+	if len(col.ScheduledBlocks) != 1 || col.ScheduledBlocks[0] != want {
+		t.Fatalf("expected scheduled updates %v, got %v", []chunk.ScheduledBlockUpdate{want}, col.ScheduledBlocks)
+	}
+}
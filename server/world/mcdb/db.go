@@ -16,6 +16,7 @@ import (
 	"github.com/df-mc/dragonfly/server/world/chunk"
 	"github.com/df-mc/dragonfly/server/world/mcdb/leveldat"
 	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/df-mc/goleveldb/leveldb/util"
 	"github.com/google/uuid"
 	"github.com/sandertv/gophertunnel/minecraft/nbt"
 )
@@ -479,6 +480,104 @@ type scheduledUpdates struct {
 	TickList    []map[string]any `nbt:"tickList"`
 }
 
+// DeleteColumn removes the world.Column at a position and dimension from the
+// DB, including its entities. Nothing happens if no column is stored at the
+// position.
+func (db *DB) DeleteColumn(pos world.ChunkPos, dim world.Dimension) error {
+	prefix := index(pos, dim)
+	batch := new(leveldb.Batch)
+
+	iter := db.ldb.NewIterator(util.BytesPrefix(prefix), nil)
+	for iter.Next() {
+		// Keys of the overworld are prefixes of keys of other dimensions at the
+		// same position, so only keys of exactly this column are deleted.
+		k := iter.Key()
+		if len(k) == len(prefix)+1 || (len(k) == len(prefix)+2 && k[len(prefix)] == keySubChunkData) {
+			batch.Delete(bytes.Clone(k))
+		}
+	}
+	iter.Release()
+	if err := iter.Error(); err != nil {
+		return fmt.Errorf("delete column %v (%v): %w", pos, dim, err)
+	}
+
+	idsKey := append([]byte(keyEntityIdentifiers), prefix...)
+	ids, err := db.ldb.Get(idsKey, nil)
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		return fmt.Errorf("delete column %v (%v): read entity IDs: %w", pos, dim, err)
+	}
+	for i := 0; i+8 <= len(ids); i += 8 {
+		batch.Delete(entityIndex(int64(binary.LittleEndian.Uint64(ids[i:]))))
+	}
+	batch.Delete(idsKey)
+
+	if err := db.ldb.Write(batch, nil); err != nil {
+		return fmt.Errorf("delete column %v (%v): %w", pos, dim, err)
+	}
+	return nil
+}
+
+// RepairColumn removes the sub chunks and biomes of the column at a position
+// and dimension that cannot be decoded, so that the column can be loaded
+// again. It returns the Y values of the sub chunks removed and whether the
+// biomes were removed.
+func (db *DB) RepairColumn(pos world.ChunkPos, dim world.Dimension) (subChunks []int, biomes bool, err error) {
+	k := dbKey{pos: pos, dim: dim}
+	r := dim.Range()
+	batch := new(leveldb.Batch)
+
+	data, err := db.biomes(k)
+	if err == nil {
+		_, err = chunk.DiskDecode(db.conf.Blocks, chunk.SerialisedData{Biomes: data}, r)
+	}
+	if err != nil && !errors.Is(err, leveldb.ErrNotFound) {
+		batch.Delete(k.Sum(key3DData))
+		biomes = true
+	}
+	subs, err := db.subChunks(k)
+	if err != nil {
+		return nil, false, fmt.Errorf("repair column %v (%v): %w", pos, dim, err)
+	}
+	for i, sub := range subs {
+		if len(sub) == 0 {
+			continue
+		}
+		only := make([][]byte, len(subs))
+		only[i] = sub
+		if _, err := chunk.DiskDecode(db.conf.Blocks, chunk.SerialisedData{SubChunks: only}, r); err != nil {
+			y := i + (r[0] >> 4)
+			batch.Delete(k.Sum(keySubChunkData, byte(y)))
+			subChunks = append(subChunks, y)
+		}
+	}
+	if err := db.ldb.Write(batch, nil); err != nil {
+		return nil, false, fmt.Errorf("repair column %v (%v): %w", pos, dim, err)
+	}
+	return subChunks, biomes, nil
+}
+
+// Trim removes all columns of the world.Dimension passed that are outside the
+// world.ChunkArea a from the DB. It returns the number of columns removed.
+func (db *DB) Trim(a world.ChunkArea, dim world.Dimension) (int, error) {
+	iter := db.NewColumnIterator(&IteratorRange{Dimension: dim})
+	defer iter.Release()
+
+	n := 0
+	for iter.Next() {
+		if a.Contains(iter.Position()) {
+			continue
+		}
+		if err := db.DeleteColumn(iter.Position(), dim); err != nil {
+			return n, fmt.Errorf("trim: %w", err)
+		}
+		n++
+	}
+	if err := iter.Error(); err != nil {
+		return n, fmt.Errorf("trim: %w", err)
+	}
+	return n, nil
+}
+
 // NewColumnIterator returns a ColumnIterator that may be used to iterate over all
 // position/chunk pairs in a database.
 // An IteratorRange r may be passed to specify limits in terms of what chunks
@@ -0,0 +1,156 @@
+package mcdb
+
+import (
+	"log/slog"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block"
+	"github.com/df-mc/dragonfly/server/world"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+)
+
+// openTestDB opens a DB in a temporary directory that is closed when the test finishes.
+func openTestDB(t *testing.T) *DB {
+	t.Helper()
+	db, err := Config{Log: slog.New(slog.DiscardHandler)}.Open(t.TempDir())
+	if err != nil {
+		t.Fatalf("open db: %v", err)
+	}
+	t.Cleanup(func() { _ = db.Close() })
+	return db
+}
+
+// storeTestColumn stores a column with a stone block and an entity with the ID passed at a position in a
+// dimension of db.
+func storeTestColumn(t *testing.T, db *DB, pos world.ChunkPos, dim world.Dimension, entityID int64) {
+	t.Helper()
+	col := &chunk.Column{Chunk: chunk.New(world.DefaultBlockRegistry, dim.Range())}
+	col.Chunk.SetBlock(0, 64, 0, 0, world.BlockRuntimeID(block.Stone{}))
+	col.Entities = []chunk.Entity{{ID: entityID, Data: map[string]any{"identifier": "minecraft:pig"}}}
+	if err := db.StoreColumn(pos, dim, col); err != nil {
+		t.Fatalf("store column: %v", err)
+	}
+}
+
+// keys returns all keys currently stored in db.
+func keys(db *DB) map[string]struct{} {
+	m := make(map[string]struct{})
+	iter := db.ldb.NewIterator(nil, nil)
+	defer iter.Release()
+	for iter.Next() {
+		m[string(iter.Key())] = struct{}{}
+	}
+	return m
+}
+
+func TestDBDeleteColumn(t *testing.T) {
+	db := openTestDB(t)
+	storeTestColumn(t, db, world.ChunkPos{0, 0}, world.Overworld, 1)
+	// The nether column shares its position with the deleted column, so its keys start with the keys of the
+	// deleted column.
+	storeTestColumn(t, db, world.ChunkPos{3, 4}, world.Nether, 2)
+	before := keys(db)
+
+	storeTestColumn(t, db, world.ChunkPos{3, 4}, world.Overworld, 3)
+	if err := db.DeleteColumn(world.ChunkPos{3, 4}, world.Overworld); err != nil {
+		t.Fatalf("delete column: %v", err)
+	}
+	if after := keys(db); !maps.Equal(before, after) {
+		t.Fatalf("expected only keys of the deleted column to be removed:\nbefore: %q\nafter: %q", slices.Sorted(maps.Keys(before)), slices.Sorted(maps.Keys(after)))
+	}
+	if _, err := db.LoadColumn(world.ChunkPos{3, 4}, world.Nether); err != nil {
+		t.Fatalf("load column in other dimension: %v", err)
+	}
+}
+
+func TestDBTrim(t *testing.T) {
+	db := openTestDB(t)
+	area := world.ChunkArea{Radius: 1}
+	inside := []world.ChunkPos{{0, 0}, {-1, 1}, {1, -1}}
+	for i, pos := range inside {
+		storeTestColumn(t, db, pos, world.Overworld, int64(i+1))
+	}
+	// Columns of other dimensions outside the area are not trimmed.
+	storeTestColumn(t, db, world.ChunkPos{5, 5}, world.Nether, 10)
+	before := keys(db)
+
+	outside := []world.ChunkPos{{2, 0}, {5, 5}, {-2, -2}, {0, 100}}
+	for i, pos := range outside {
+		storeTestColumn(t, db, pos, world.Overworld, int64(i+20))
+	}
+	n, err := db.Trim(area, world.Overworld)
+	if err != nil {
+		t.Fatalf("trim: %v", err)
+	}
+	if n != len(outside) {
+		t.Fatalf("expected %v columns to be removed, got %v", len(outside), n)
+	}
+	if after := keys(db); !maps.Equal(before, after) {
+		t.Fatalf("expected all keys of trimmed columns to be removed:\nbefore: %q\nafter: %q", slices.Sorted(maps.Keys(before)), slices.Sorted(maps.Keys(after)))
+	}
+	for _, pos := range inside {
+		col, err := db.LoadColumn(pos, world.Overworld)
+		if err != nil {
+			t.Fatalf("load column %v inside area: %v", pos, err)
+		}
+		if len(col.Entities) != 1 {
+			t.Fatalf("expected entity of column %v inside area to be kept, got %v", pos, col.Entities)
+		}
+	}
+}
+
+func TestDBRepairColumn(t *testing.T) {
+	// subKey returns the key of the sub chunk at the Y passed of the column at 0, 0 in the overworld.
+	subKey := func(y int) []byte {
+		return dbKey{dim: world.Overworld}.Sum(keySubChunkData, byte(y))
+	}
+	tests := []struct {
+		name      string
+		modify    func(db *DB)
+		subChunks []int
+		biomes    bool
+	}{
+		{name: "intact", modify: func(*DB) {}},
+		{name: "missing sub chunks", modify: func(db *DB) {
+			_ = db.ldb.Delete(subKey(-4), nil)
+			_ = db.ldb.Delete(subKey(4), nil)
+		}},
+		{name: "missing version", modify: func(db *DB) {
+			_ = db.ldb.Delete(dbKey{dim: world.Overworld}.Sum(keyVersion), nil)
+		}},
+		{name: "missing biomes", modify: func(db *DB) {
+			_ = db.ldb.Delete(dbKey{dim: world.Overworld}.Sum(key3DData), nil)
+		}},
+		{name: "corrupted sub chunks", modify: func(db *DB) {
+			_ = db.ldb.Put(subKey(-2), []byte{0xff, 0xff, 0xff}, nil)
+			_ = db.ldb.Put(subKey(4), []byte{0xff, 0xff, 0xff}, nil)
+		}, subChunks: []int{-2, 4}},
+		{name: "corrupted biomes", modify: func(db *DB) {
+			_ = db.ldb.Put(dbKey{dim: world.Overworld}.Sum(key3DData), slices.Repeat([]byte{0xff}, 520), nil)
+		}, biomes: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			db := openTestDB(t)
+			storeTestColumn(t, db, world.ChunkPos{}, world.Overworld, 1)
+			test.modify(db)
+
+			subChunks, biomes, err := db.RepairColumn(world.ChunkPos{}, world.Overworld)
+			if err != nil {
+				t.Fatalf("repair column: %v", err)
+			}
+			if !slices.Equal(subChunks, test.subChunks) || biomes != test.biomes {
+				t.Fatalf("expected sub chunks %v and biomes %v to be removed, got %v and %v", test.subChunks, test.biomes, subChunks, biomes)
+			}
+			if _, err := db.version(dbKey{dim: world.Overworld}); err != nil {
+				// Columns without version cannot be loaded, but should be left untouched by RepairColumn.
+				return
+			}
+			if _, err := db.LoadColumn(world.ChunkPos{}, world.Overworld); err != nil {
+				t.Fatalf("load repaired column: %v", err)
+			}
+		})
+	}
+}
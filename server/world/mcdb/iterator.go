@@ -50,28 +50,18 @@ func (iter *ColumnIterator) Next() bool {
 		iter.dim = nil
 		return false
 	}
-	k := iter.dbIter.Key()
-	kLen := len(k)
-	if (kLen != 9 && kLen != 13) || (k[kLen-1] != keyVersion && k[kLen-1] != keyVersionOld) {
-		return iter.Next()
-	}
-	iter.dim = world.Dimension(world.Overworld)
-	if kLen > 9 {
-		var ok bool
-		id := int(binary.LittleEndian.Uint32(k[8:12]))
-		if iter.dim, ok = world.DimensionByID(id); !ok {
-			iter.err = fmt.Errorf("unknown dimension id %v", id)
-			return false
-		}
+	key, ok, err := parseVersionKey(iter.dbIter.Key())
+	if err != nil {
+		iter.err = err
+		return false
 	}
-	iter.pos = world.ChunkPos{
-		int32(binary.LittleEndian.Uint32(k[:4])),
-		int32(binary.LittleEndian.Uint32(k[4:8])),
+	if !ok {
+		return iter.Next()
 	}
+	iter.pos, iter.dim = key.pos, key.dim
 	if !iter.r.within(iter.pos, iter.dim) {
 		return iter.Next()
 	}
-	key := dbKey{dim: iter.dim, pos: iter.pos}
 	if _, ok := iter.seen[key]; ok {
 		// Already encountered this chunk. This might happen if there are
 		// multiple version keys.
@@ -86,6 +76,29 @@ func (iter *ColumnIterator) Next() bool {
 	return true
 }
 
+// parseVersionKey parses the position and dimension of a column from the
+// version key k of the column. If k is not a version key, parseVersionKey
+// returns false.
+func parseVersionKey(k []byte) (dbKey, bool, error) {
+	kLen := len(k)
+	if (kLen != 9 && kLen != 13) || (k[kLen-1] != keyVersion && k[kLen-1] != keyVersionOld) {
+		return dbKey{}, false, nil
+	}
+	key := dbKey{dim: world.Overworld}
+	if kLen > 9 {
+		var ok bool
+		id := int(binary.LittleEndian.Uint32(k[8:12]))
+		if key.dim, ok = world.DimensionByID(id); !ok {
+			return dbKey{}, false, fmt.Errorf("unknown dimension id %v", id)
+		}
+	}
+	key.pos = world.ChunkPos{
+		int32(binary.LittleEndian.Uint32(k[:4])),
+		int32(binary.LittleEndian.Uint32(k[4:8])),
+	}
+	return key, true, nil
+}
+
 // Column returns the value of the current position/column pair, or nil if none.
 func (iter *ColumnIterator) Column() *chunk.Column {
 	return iter.current
@@ -134,3 +147,36 @@ func (r *IteratorRange) within(pos world.ChunkPos, dim world.Dimension) bool {
 	return ((r.Min == world.ChunkPos{}) && (r.Max == world.ChunkPos{})) ||
 		pos[0] >= r.Min[0] && pos[0] < r.Max[0] && pos[1] >= r.Min[1] && pos[1] < r.Max[1]
 }
+
+// ColumnPosition is the position and dimension of a column stored in a DB.
+type ColumnPosition struct {
+	Pos world.ChunkPos
+	Dim world.Dimension
+}
+
+// ColumnPositions returns the positions of all columns in the DB within the
+// IteratorRange r, which may be nil to return all positions. Unlike a
+// ColumnIterator, ColumnPositions does not decode the columns, so it may also
+// be used to find columns that are corrupted.
+func (db *DB) ColumnPositions(r *IteratorRange) ([]ColumnPosition, error) {
+	if r == nil {
+		r = &IteratorRange{}
+	}
+	iter := db.ldb.NewIterator(nil, nil)
+	defer iter.Release()
+
+	var positions []ColumnPosition
+	seen := make(map[dbKey]struct{})
+	for iter.Next() {
+		key, ok, err := parseVersionKey(iter.Key())
+		if err != nil {
+			return nil, err
+		}
+		if _, dup := seen[key]; !ok || dup || !r.within(key.pos, key.dim) {
+			continue
+		}
+		seen[key] = struct{}{}
+		positions = append(positions, ColumnPosition{Pos: key.pos, Dim: key.dim})
+	}
+	return positions, iter.Error()
+}
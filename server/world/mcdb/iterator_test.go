@@ -0,0 +1,88 @@
+package mcdb
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world"
+)
+
+func TestParseVersionKey(t *testing.T) {
+	positions := []world.ChunkPos{{0, 0}, {3, -7}, {-2147483648, 2147483647}}
+	for _, dim := range []world.Dimension{world.Overworld, world.Nether, world.End} {
+		for _, pos := range positions {
+			for _, suffix := range []byte{keyVersion, keyVersionOld} {
+				k, ok, err := parseVersionKey(dbKey{pos: pos, dim: dim}.Sum(suffix))
+				if err != nil || !ok {
+					t.Fatalf("parse version key %q of %v (%v): expected ok, got %v, %v", suffix, pos, dim, ok, err)
+				}
+				if k.pos != pos || k.dim != dim {
+					t.Fatalf("parse version key %q of %v (%v): got %v (%v)", suffix, pos, dim, k.pos, k.dim)
+				}
+			}
+			// Keys of other data of the column are not version keys.
+			for _, k := range [][]byte{dbKey{pos: pos, dim: dim}.Sum(key3DData), dbKey{pos: pos, dim: dim}.Sum(keySubChunkData, 0)} {
+				if _, ok, err := parseVersionKey(k); ok || err != nil {
+					t.Fatalf("parse key %q of %v (%v): expected no version key, got %v, %v", k, pos, dim, ok, err)
+				}
+			}
+		}
+	}
+
+	unknown := append(index(world.ChunkPos{1, 2}, world.Nether)[:8], 7, 0, 0, 0, keyVersion)
+	if _, _, err := parseVersionKey(unknown); err == nil {
+		t.Fatalf("expected error parsing version key with unknown dimension")
+	}
+	// "~local_player" has the length of a version key of the nether or end.
+	for _, k := range []string{keyLocalPlayer, keyAutonomousEntities, keyOverworld} {
+		if _, ok, err := parseVersionKey([]byte(k)); ok || err != nil {
+			t.Fatalf("parse key %q: expected no version key, got %v, %v", k, ok, err)
+		}
+	}
+}
+
+func TestDBColumnPositions(t *testing.T) {
+	db := openTestDB(t)
+	stored := []ColumnPosition{
+		{Pos: world.ChunkPos{0, 0}, Dim: world.Overworld},
+		{Pos: world.ChunkPos{-3, 5}, Dim: world.Overworld},
+		{Pos: world.ChunkPos{0, 0}, Dim: world.Nether},
+		{Pos: world.ChunkPos{2, -2}, Dim: world.End},
+	}
+	for i, p := range stored {
+		storeTestColumn(t, db, p.Pos, p.Dim, int64(i+1))
+	}
+	// A column with both the old and new version key is returned once.
+	_ = db.ldb.Put(dbKey{pos: world.ChunkPos{0, 0}, dim: world.Nether}.Sum(keyVersionOld), []byte{chunkVersion}, nil)
+	// A column with only sub chunks and no version is not returned.
+	storeTestColumn(t, db, world.ChunkPos{9, 9}, world.End, 10)
+	_ = db.ldb.Delete(dbKey{pos: world.ChunkPos{9, 9}, dim: world.End}.Sum(keyVersion), nil)
+
+	tests := []struct {
+		name string
+		r    *IteratorRange
+		want []ColumnPosition
+	}{
+		{name: "all", want: stored},
+		{name: "overworld", r: &IteratorRange{Dimension: world.Overworld}, want: stored[:2]},
+		{name: "nether", r: &IteratorRange{Dimension: world.Nether}, want: stored[2:3]},
+		{name: "end", r: &IteratorRange{Dimension: world.End}, want: stored[3:]},
+		{name: "area", r: &IteratorRange{Min: world.ChunkPos{-1, -1}, Max: world.ChunkPos{3, 3}}, want: []ColumnPosition{stored[0], stored[2]}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			positions, err := db.ColumnPositions(test.r)
+			if err != nil {
+				t.Fatalf("column positions: %v", err)
+			}
+			for _, want := range test.want {
+				if !slices.Contains(positions, want) {
+					t.Errorf("expected %v (%v) in positions %v", want.Pos, want.Dim, positions)
+				}
+			}
+			if len(positions) != len(test.want) {
+				t.Errorf("expected %v positions, got %v", len(test.want), positions)
+			}
+		})
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	"github.com/df-mc/dragonfly/server/block/cube"
 	"github.com/df-mc/dragonfly/server/world"
+	"github.com/go-gl/mathgl/mgl64"
 	"github.com/sandertv/gophertunnel/minecraft/protocol"
 )
 
@@ -16,6 +17,15 @@ import (
 type Data struct {
 	BaseGameVersion                string `nbt:"baseGameVersion"`
 	BiomeOverride                  string
+	BorderCentreX                  float64 `nbt:"BorderCenterX"`
+	BorderCentreZ                  float64 `nbt:"BorderCenterZ"`
+	BorderDamagePerBlock           float64
+	BorderSafeZone                 float64
+	BorderSize                     float64
+	BorderSizeLerpTarget           float64
+	BorderSizeLerpTime             int64
+	BorderWarningBlocks            float64
+	BorderWarningTime              float64
 	ConfirmedPlatformLockedContent bool
 	CentreMapsToOrigin             bool  `nbt:"CenterMapsToOrigin"`
 	CheatsEnabled                  bool  `nbt:"cheatsEnabled"`
@@ -247,6 +257,16 @@ func (d *Data) Settings() *world.Settings {
 		DefaultGameMode: mode,
 		Difficulty:      difficulty,
 		TickRange:       d.ServerChunkTickRange,
+		Border: world.Border{
+			Centre:          mgl64.Vec2{d.BorderCentreX, d.BorderCentreZ},
+			Size:            d.BorderSize,
+			TargetSize:      d.BorderSizeLerpTarget,
+			TargetDuration:  time.Duration(d.BorderSizeLerpTime) * time.Millisecond,
+			DamagePerBlock:  d.BorderDamagePerBlock,
+			SafeZone:        d.BorderSafeZone,
+			WarningDistance: d.BorderWarningBlocks,
+			WarningTime:     time.Duration(d.BorderWarningTime * float64(time.Second)),
+		},
 	}
 }
 
@@ -272,4 +292,9 @@ func (d *Data) PutSettings(s *world.Settings) {
 	d.GameType = int32(mode)
 	difficulty, _ := world.DifficultyID(s.Difficulty)
 	d.Difficulty = int32(difficulty)
+	d.BorderCentreX, d.BorderCentreZ = s.Border.Centre[0], s.Border.Centre[1]
+	d.BorderSize, d.BorderSizeLerpTarget = s.Border.Size, s.Border.TargetSize
+	d.BorderSizeLerpTime = s.Border.TargetDuration.Milliseconds()
+	d.BorderDamagePerBlock, d.BorderSafeZone = s.Border.DamagePerBlock, s.Border.SafeZone
+	d.BorderWarningBlocks, d.BorderWarningTime = s.Border.WarningDistance, s.Border.WarningTime.Seconds()
 }
@@ -0,0 +1,98 @@
+package world
+
+import (
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	"github.com/df-mc/dragonfly/server/metrics"
+)
+
+// tickBuckets are the bucket upper bounds, in seconds, of the tick duration
+// histogram. A tick should take no longer than 50ms, so the buckets are
+// concentrated below that.
+var tickBuckets = []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.02, 0.035, 0.05, 0.1, 0.25}
+
+// worldMetricsID is the ID of the last World whose metrics were created. It is
+// used to label the metrics of each World uniquely, as the worlds created
+// using World.Clone or Template.New share the name and Dimension of the World
+// they were created from.
+var worldMetricsID atomic.Uint64
+
+// worldMetrics holds the metrics recorded by a World. If Config.Metrics is
+// nil, all metrics are nil and values recorded are discarded.
+type worldMetrics struct {
+	labels []string
+
+	tickDuration  *metrics.Histogram
+	chunks        *metrics.Gauge
+	entities      *metrics.Gauge
+	chunkLoad     *metrics.Histogram
+	chunkGenerate *metrics.Histogram
+	saveColumn    *metrics.Histogram
+	saveSettings  *metrics.Histogram
+}
+
+// newWorldMetrics creates the metrics of a World with the name and Dimension
+// passed in the metrics.Registry r. The metrics are additionally labelled with
+// an ID unique to the World.
+func newWorldMetrics(r *metrics.Registry, name string, dim Dimension) worldMetrics {
+	id := strconv.FormatUint(worldMetricsID.Add(1), 10)
+	m := worldMetrics{labels: []string{name, fmt.Sprint(dim), id}}
+	if r == nil {
+		return m
+	}
+	m.tickDuration = worldTickDuration(r).With(m.labels...)
+	m.chunks = worldChunks(r).With(m.labels...)
+	m.entities = worldEntities(r).With(m.labels...)
+	m.chunkLoad = worldChunkLoad(r).With(m.labels...)
+	m.chunkGenerate = worldChunkGenerate(r).With(m.labels...)
+	m.saveColumn = worldSave(r).With(append(m.labels, "column")...)
+	m.saveSettings = worldSave(r).With(append(m.labels, "settings")...)
+	return m
+}
+
+// remove removes the metrics of the World from the metrics.Registry r, so
+// that they are no longer exposed after the World is closed.
+func (m worldMetrics) remove(r *metrics.Registry) {
+	if r == nil {
+		return
+	}
+	worldTickDuration(r).Delete(m.labels...)
+	worldChunks(r).Delete(m.labels...)
+	worldEntities(r).Delete(m.labels...)
+	worldChunkLoad(r).Delete(m.labels...)
+	worldChunkGenerate(r).Delete(m.labels...)
+	worldSave(r).Delete(append(m.labels, "column")...)
+	worldSave(r).Delete(append(m.labels, "settings")...)
+}
+
+// worldTickDuration returns the histogram of tick durations of worlds.
+func worldTickDuration(r *metrics.Registry) *metrics.HistogramVec {
+	return r.Histogram("dragonfly_world_tick_duration_seconds", "Duration of world ticks.", tickBuckets, "world", "dimension", "id")
+}
+
+// worldChunks returns the gauge of loaded chunks of worlds.
+func worldChunks(r *metrics.Registry) *metrics.GaugeVec {
+	return r.Gauge("dragonfly_world_loaded_chunks", "Number of chunks loaded in a world.", "world", "dimension", "id")
+}
+
+// worldEntities returns the gauge of entities in worlds.
+func worldEntities(r *metrics.Registry) *metrics.GaugeVec {
+	return r.Gauge("dragonfly_world_entities", "Number of entities in a world.", "world", "dimension", "id")
+}
+
+// worldChunkLoad returns the histogram of chunk load durations of worlds.
+func worldChunkLoad(r *metrics.Registry) *metrics.HistogramVec {
+	return r.Histogram("dragonfly_world_chunk_load_duration_seconds", "Duration of loading chunks from the world provider.", nil, "world", "dimension", "id")
+}
+
+// worldChunkGenerate returns the histogram of chunk generation durations of worlds.
+func worldChunkGenerate(r *metrics.Registry) *metrics.HistogramVec {
+	return r.Histogram("dragonfly_world_chunk_generate_duration_seconds", "Duration of generating chunks that were not stored in the world provider.", nil, "world", "dimension", "id")
+}
+
+// worldSave returns the histogram of provider save durations of worlds.
+func worldSave(r *metrics.Registry) *metrics.HistogramVec {
+	return r.Histogram("dragonfly_world_provider_save_duration_seconds", "Duration of saving data to the world provider.", nil, "world", "dimension", "id", "kind")
+}
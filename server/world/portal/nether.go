@@ -58,7 +58,7 @@ func ActivateNetherPortal(tx *world.Tx, pos cube.Pos) bool {
 	}
 	ctx := tx.Event()
 	positions := append([]cube.Pos(nil), p.Positions()...)
-	if tx.World().Handler().HandlePortalActivate(ctx, world.Nether, positions); ctx.Cancelled() {
+	if tx.Handler().HandlePortalActivate(ctx, world.Nether, positions); ctx.Cancelled() {
 		return false
 	}
 	p.Activate()
@@ -278,7 +278,7 @@ func CreateNetherPortal(tx *world.Tx, pos cube.Pos) (Nether, bool) {
 
 	ctx := tx.Event()
 	handlerPositions := append([]cube.Pos(nil), affected...)
-	if tx.World().Handler().HandlePortalCreate(ctx, world.Nether, handlerPositions); ctx.Cancelled() {
+	if tx.Handler().HandlePortalCreate(ctx, world.Nether, handlerPositions); ctx.Cancelled() {
 		return Nether{}, false
 	}
 	for _, pos := range affected {
@@ -0,0 +1,173 @@
+package world
+
+import (
+	"cmp"
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+)
+
+// ChunkArea is a square or circular area of chunks around a centre chunk.
+type ChunkArea struct {
+	// Centre is the chunk in the centre of the area.
+	Centre ChunkPos
+	// Radius is the radius of the area in chunks. A Radius of 0 covers only
+	// the Centre.
+	Radius int32
+	// Circle specifies if the area is a circle. If false, the area is a
+	// square with sides of 2*Radius+1 chunks.
+	Circle bool
+}
+
+// Contains checks if the chunk at the position passed is within the
+// ChunkArea.
+func (a ChunkArea) Contains(pos ChunkPos) bool {
+	dx, dz := int64(pos[0])-int64(a.Centre[0]), int64(pos[1])-int64(a.Centre[1])
+	r := int64(a.Radius)
+	if a.Circle {
+		return dx*dx+dz*dz <= r*r
+	}
+	return dx >= -r && dx <= r && dz >= -r && dz <= r
+}
+
+// Positions returns all chunk positions within the ChunkArea, ordered by
+// their distance to the Centre so that the area is filled outwards.
+func (a ChunkArea) Positions() []ChunkPos {
+	positions := make([]ChunkPos, 0, (2*a.Radius+1)*(2*a.Radius+1))
+	for x := a.Centre[0] - a.Radius; x <= a.Centre[0]+a.Radius; x++ {
+		for z := a.Centre[1] - a.Radius; z <= a.Centre[1]+a.Radius; z++ {
+			if pos := (ChunkPos{x, z}); a.Contains(pos) {
+				positions = append(positions, pos)
+			}
+		}
+	}
+	dist := func(pos ChunkPos) int64 {
+		dx, dz := int64(pos[0])-int64(a.Centre[0]), int64(pos[1])-int64(a.Centre[1])
+		return dx*dx + dz*dz
+	}
+	slices.SortStableFunc(positions, func(i, j ChunkPos) int {
+		return cmp.Compare(dist(i), dist(j))
+	})
+	return positions
+}
+
+// PregenProgress is the progress of a call to World.Pregenerate.
+type PregenProgress struct {
+	// Total is the total number of chunks in the area being pre-generated.
+	Total int
+	// Generated is the number of chunks generated and stored so far.
+	Generated int
+	// Skipped is the number of chunks that were skipped because they were
+	// already stored in the Provider or loaded in the World. Loaded chunks are
+	// stored by the World when they are unloaded.
+	Skipped int
+}
+
+// Done returns the number of chunks that have been handled so far.
+func (p PregenProgress) Done() int {
+	return p.Generated + p.Skipped
+}
+
+// Pregenerate generates all chunks within the ChunkArea passed that are not
+// yet stored in the Provider of the World and stores them, so that they no
+// longer need to be generated when players first explore them. Chunks are
+// generated by Config.ChunkLoadWorkers workers, without loading them into the
+// World.
+//
+// Chunks already stored are skipped, so a Pregenerate call that was
+// interrupted, for example by cancelling ctx, may be resumed by calling
+// Pregenerate again with the same ChunkArea. progress, if not nil, is called
+// after every chunk handled. It is never called concurrently.
+// Pregenerate blocks until all chunks are handled, ctx is cancelled or an
+// error occurs, and may not be called from a transaction of the World.
+func (w *World) Pregenerate(ctx context.Context, a ChunkArea, progress func(p PregenProgress)) (PregenProgress, error) {
+	positions := a.Positions()
+	prog := PregenProgress{Total: len(positions)}
+	if w.conf.ReadOnly {
+		return prog, errors.New("pregenerate: world is read-only")
+	}
+	ctx, cancel := context.WithCancelCause(ctx)
+	defer cancel(nil)
+
+	var (
+		mu    sync.Mutex
+		wg    sync.WaitGroup
+		queue = make(chan ChunkPos)
+	)
+	wg.Add(w.conf.ChunkLoadWorkers)
+	for range w.conf.ChunkLoadWorkers {
+		go func() {
+			defer wg.Done()
+			for pos := range queue {
+				if ctx.Err() != nil {
+					continue
+				}
+				generated, err := w.pregenerateChunk(ctx, pos)
+				if err != nil {
+					cancel(err)
+					continue
+				}
+				mu.Lock()
+				if generated {
+					prog.Generated++
+				} else {
+					prog.Skipped++
+				}
+				if progress != nil {
+					progress(prog)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+feed:
+	for _, pos := range positions {
+		select {
+		case queue <- pos:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(queue)
+	wg.Wait()
+
+	if err := context.Cause(ctx); err != nil {
+		return prog, fmt.Errorf("pregenerate: %w", err)
+	}
+	return prog, nil
+}
+
+// pregenerateChunk generates and stores the chunk at the position passed if it
+// is not yet stored in the Provider. It returns true if the chunk was
+// generated and stored.
+func (w *World) pregenerateChunk(ctx context.Context, pos ChunkPos) (bool, error) {
+	if _, err := w.conf.Provider.LoadColumn(pos, w.conf.Dim); !errors.Is(err, leveldb.ErrNotFound) {
+		return false, err
+	}
+	col := &chunk.Column{Chunk: w.generateChunk(pos)}
+
+	// Storing happens on the World so that chunks loaded in the meantime, which
+	// may have been modified and saved, are never overwritten.
+	stored := false
+	err := w.scheduleTask(newTask(), func(tx *Tx) error {
+		if c, ok := w.chunks[pos]; ok {
+			// The World saves the chunk itself when it is unloaded.
+			c.modified = true
+			return nil
+		}
+		if _, err := w.conf.Provider.LoadColumn(pos, w.conf.Dim); !errors.Is(err, leveldb.ErrNotFound) {
+			return err
+		}
+		if err := w.conf.Provider.StoreColumn(pos, w.conf.Dim, col); err != nil {
+			return err
+		}
+		stored = true
+		return nil
+	}).Wait(ctx)
+	return stored, err
+}
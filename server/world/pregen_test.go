@@ -0,0 +1,75 @@
+package world
+
+import (
+	"context"
+	"sync"
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+)
+
+// memoryProvider is a Provider that keeps columns in memory.
+type memoryProvider struct {
+	NopProvider
+	mu      sync.Mutex
+	columns map[ChunkPos]*chunk.Column
+}
+
+func (p *memoryProvider) LoadColumn(pos ChunkPos, _ Dimension) (*chunk.Column, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if c, ok := p.columns[pos]; ok {
+		return c, nil
+	}
+	return nil, leveldb.ErrNotFound
+}
+
+func (p *memoryProvider) StoreColumn(pos ChunkPos, _ Dimension, c *chunk.Column) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.columns[pos] = c
+	return nil
+}
+
+func TestChunkAreaPositions(t *testing.T) {
+	square := ChunkArea{Centre: ChunkPos{4, -4}, Radius: 2}
+	if n := len(square.Positions()); n != 25 {
+		t.Errorf("expected 25 chunks in square, got %v", n)
+	}
+	circle := ChunkArea{Centre: ChunkPos{4, -4}, Radius: 2, Circle: true}
+	positions := circle.Positions()
+	if n := len(positions); n != 13 {
+		t.Errorf("expected 13 chunks in circle, got %v", n)
+	}
+	if positions[0] != circle.Centre {
+		t.Errorf("expected centre to come first, got %v", positions[0])
+	}
+	if circle.Contains(ChunkPos{6, -2}) || !square.Contains(ChunkPos{6, -2}) {
+		t.Errorf("unexpected containment of corner chunk")
+	}
+}
+
+func TestWorldPregenerate(t *testing.T) {
+	p := &memoryProvider{columns: make(map[ChunkPos]*chunk.Column)}
+	w := Config{Provider: p, ChunkLoadWorkers: 4}.New()
+	defer w.Close()
+
+	calls := 0
+	prog, err := w.Pregenerate(context.Background(), ChunkArea{Radius: 3}, func(PregenProgress) { calls++ })
+	if err != nil {
+		t.Fatalf("pregenerate: %v", err)
+	}
+	if prog.Total != 49 || prog.Done() != 49 || calls != 49 {
+		t.Errorf("expected 49 chunks handled, got %+v with %v progress calls", prog, calls)
+	}
+	if prog, _ = w.Pregenerate(context.Background(), ChunkArea{Radius: 4}, nil); prog.Generated != 32 {
+		t.Errorf("expected only the 32 new chunks to be generated when resuming, got %+v", prog)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if _, err = w.Pregenerate(ctx, ChunkArea{Radius: 8}, nil); err == nil {
+		t.Errorf("expected error for cancelled context")
+	}
+}
@@ -0,0 +1,381 @@
+package world
+
+import (
+	"cmp"
+	"fmt"
+	"io"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/go-gl/mathgl/mgl64"
+)
+
+// Tick sections reported by a profiler.
+const (
+	sectionEntities         = "entity ticks"
+	sectionScheduled        = "scheduled ticks"
+	sectionRandom           = "random ticks"
+	sectionNeighbourUpdates = "neighbour updates"
+	sectionRedstone         = "redstone"
+)
+
+// profiler records the time spent on the different parts of the ticks of a
+// World. A profiler is only used while profiling is enabled through
+// World.StartProfiling. Times are recorded by the goroutine ticking the World,
+// while reports may be created concurrently.
+type profiler struct {
+	mu sync.Mutex
+
+	start    time.Time
+	ticks    int
+	tickTime time.Duration
+
+	sections, blocks, entities, handlers map[string]*timing
+	chunks                               map[ChunkPos]*timing
+}
+
+// timing holds the times recorded for a single section, block type, entity
+// type, handler or chunk.
+type timing struct {
+	count      int
+	total, max time.Duration
+}
+
+// add adds a single duration to the timing.
+func (t *timing) add(d time.Duration) {
+	t.count++
+	t.total += d
+	t.max = max(t.max, d)
+}
+
+// newProfiler creates a profiler that starts profiling immediately.
+func newProfiler() *profiler {
+	return &profiler{
+		start:    time.Now(),
+		sections: make(map[string]*timing),
+		blocks:   make(map[string]*timing),
+		entities: make(map[string]*timing),
+		handlers: make(map[string]*timing),
+		chunks:   make(map[ChunkPos]*timing),
+	}
+}
+
+// now returns the current time if p is not nil. If p is nil, the zero time is
+// returned so that no time is spent when profiling is disabled.
+func (p *profiler) now() time.Time {
+	if p == nil {
+		return time.Time{}
+	}
+	return time.Now()
+}
+
+// record adds the duration d to the timing under the key k in the map m.
+func record[K comparable](m map[K]*timing, k K, d time.Duration) {
+	t, ok := m[k]
+	if !ok {
+		t = &timing{}
+		m[k] = t
+	}
+	t.add(d)
+}
+
+// tick records a full tick that started at the time passed.
+func (p *profiler) tick(start time.Time) {
+	if p == nil {
+		return
+	}
+	d := time.Since(start)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.ticks++
+	p.tickTime += d
+}
+
+// section records a section of a tick that started at the time passed.
+func (p *profiler) section(name string, start time.Time) {
+	if p == nil {
+		return
+	}
+	d := time.Since(start)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	record(p.sections, name, d)
+}
+
+// block records an update of the Block b at the position passed that started
+// at the time passed.
+func (p *profiler) block(b Block, pos cube.Pos, start time.Time) {
+	if p == nil {
+		return
+	}
+	d := time.Since(start)
+	name, _ := b.EncodeBlock()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	record(p.blocks, name, d)
+	record(p.chunks, chunkPosFromBlockPos(pos), d)
+}
+
+// entity records a tick of the Entity e that started at the time passed.
+func (p *profiler) entity(e Entity, pos ChunkPos, start time.Time) {
+	if p == nil {
+		return
+	}
+	d := time.Since(start)
+	name := e.H().Type().EncodeEntity()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	record(p.entities, name, d)
+	record(p.chunks, pos, d)
+}
+
+// handler records a call to the Handler method with the name passed that
+// started at the time passed.
+func (p *profiler) handler(name string, start time.Time) {
+	d := time.Since(start)
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	record(p.handlers, name, d)
+}
+
+// report creates a ProfileReport of the times recorded so far.
+func (p *profiler) report() ProfileReport {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return ProfileReport{
+		Duration: time.Since(p.start),
+		Ticks:    p.ticks,
+		TickTime: p.tickTime,
+		Sections: entries(p.sections, func(s string) string { return s }),
+		Blocks:   entries(p.blocks, func(s string) string { return s }),
+		Entities: entries(p.entities, func(s string) string { return s }),
+		Handlers: entries(p.handlers, func(s string) string { return s }),
+		Chunks: entries(p.chunks, func(pos ChunkPos) string {
+			return fmt.Sprintf("%v, %v", pos[0], pos[1])
+		}),
+	}
+}
+
+// entries converts the timings in m to ProfileEntries, sorted by the total
+// time spent, longest first.
+func entries[K comparable](m map[K]*timing, name func(K) string) []ProfileEntry {
+	e := make([]ProfileEntry, 0, len(m))
+	for k, t := range m {
+		e = append(e, ProfileEntry{Name: name(k), Count: t.count, Total: t.total, Max: t.max})
+	}
+	slices.SortFunc(e, func(a, b ProfileEntry) int {
+		return cmp.Or(cmp.Compare(b.Total, a.Total), strings.Compare(a.Name, b.Name))
+	})
+	return e
+}
+
+// ProfileReport is a report of the time spent on the ticks of a World while
+// it was being profiled. A ProfileReport is obtained by calling
+// World.ProfileReport or World.StopProfiling.
+type ProfileReport struct {
+	// Duration is the wall time passed since profiling was started.
+	Duration time.Duration
+	// Ticks is the number of ticks profiled. Ticks skipped because no viewers
+	// were present in the World are not counted.
+	Ticks int
+	// TickTime is the total time spent on the ticks profiled.
+	TickTime time.Duration
+	// Sections holds the time spent on the different sections of a tick:
+	// entity ticks, scheduled ticks, random ticks, neighbour updates and
+	// redstone.
+	Sections []ProfileEntry
+	// Blocks holds the time spent on random, scheduled and neighbour update
+	// ticks per block type.
+	Blocks []ProfileEntry
+	// Entities holds the time spent on entity ticks per entity type.
+	Entities []ProfileEntry
+	// Handlers holds the time spent in each method of the Handler of the World.
+	Handlers []ProfileEntry
+	// Chunks holds the time spent on block and entity ticks per chunk, with the
+	// chunk coordinates as name.
+	Chunks []ProfileEntry
+}
+
+// ProfileEntry holds the time recorded for a single section, block type,
+// entity type, Handler method or chunk in a ProfileReport.
+type ProfileEntry struct {
+	// Name is the name of the section, block type, entity type, Handler
+	// method or the coordinates of the chunk.
+	Name string
+	// Count is the number of times the entry was recorded.
+	Count int
+	// Total is the total time spent on the entry.
+	Total time.Duration
+	// Max is the longest time spent on the entry at once.
+	Max time.Duration
+}
+
+// maxReportEntries is the maximum number of entries per category written by
+// ProfileReport.WriteTo.
+const maxReportEntries = 15
+
+// WriteTo writes the ProfileReport to w in a human-readable format. Only the
+// entries that took the most time are written for every category.
+func (r ProfileReport) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+	avg := time.Duration(0)
+	if r.Ticks > 0 {
+		avg = r.TickTime / time.Duration(r.Ticks)
+	}
+	fmt.Fprintf(&sb, "Profiled %v: %v ticks, %v on average (%.1f%% of tick budget)\n", r.Duration.Round(time.Millisecond), r.Ticks, avg, float64(avg)/float64(time.Second/20)*100)
+	for _, c := range []struct {
+		name    string
+		entries []ProfileEntry
+	}{
+		{"Sections", r.Sections},
+		{"Blocks", r.Blocks},
+		{"Entities", r.Entities},
+		{"Handlers", r.Handlers},
+		{"Chunks", r.Chunks},
+	} {
+		if len(c.entries) == 0 {
+			continue
+		}
+		fmt.Fprintf(&sb, "\n%v:\n", c.name)
+		for _, e := range c.entries[:min(len(c.entries), maxReportEntries)] {
+			share, perTick := 0.0, time.Duration(0)
+			if r.TickTime > 0 {
+				share = float64(e.Total) / float64(r.TickTime) * 100
+			}
+			if r.Ticks > 0 {
+				perTick = e.Total / time.Duration(r.Ticks)
+			}
+			fmt.Fprintf(&sb, "  %-32v %5.1f%%  total %-10v per tick %-10v count %-8v max %v\n", e.Name, share, e.Total.Round(time.Microsecond), perTick.Round(time.Microsecond), e.Count, e.Max.Round(time.Microsecond))
+		}
+		if n := len(c.entries) - maxReportEntries; n > 0 {
+			fmt.Fprintf(&sb, "  ... and %v more\n", n)
+		}
+	}
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+// String returns the ProfileReport in the format written by WriteTo.
+func (r ProfileReport) String() string {
+	var sb strings.Builder
+	_, _ = r.WriteTo(&sb)
+	return sb.String()
+}
+
+// StartProfiling starts profiling the ticks of the World, recording the time
+// spent on entity ticks, scheduled ticks, random ticks, neighbour updates,
+// redstone and calls to the Handler of the World. Times are attributed to
+// block types, entity types and chunks. If the World was already being
+// profiled, the times recorded so far are discarded. Profiling adds a small
+// overhead to every tick and should only be enabled while investigating lag.
+func (w *World) StartProfiling() {
+	w.profiler.Store(newProfiler())
+}
+
+// ProfileReport returns a ProfileReport of the times recorded since profiling
+// was started, without stopping profiling. If the World is not being
+// profiled, false is returned.
+func (w *World) ProfileReport() (ProfileReport, bool) {
+	p := w.profiler.Load()
+	if p == nil {
+		return ProfileReport{}, false
+	}
+	return p.report(), true
+}
+
+// StopProfiling stops profiling the World and returns a ProfileReport of the
+// times recorded since profiling was started. If the World was not being
+// profiled, false is returned.
+func (w *World) StopProfiling() (ProfileReport, bool) {
+	p := w.profiler.Swap(nil)
+	if p == nil {
+		return ProfileReport{}, false
+	}
+	return p.report(), true
+}
+
+// profiledHandler is a Handler that records the time spent in each method of
+// the Handler it wraps. It is returned by Tx.Handler while the World is
+// being profiled.
+type profiledHandler struct {
+	h Handler
+	p *profiler
+}
+
+func (h profiledHandler) HandleLiquidFlow(ctx *Context, from, into cube.Pos, liquid Liquid, replaced Block) {
+	defer h.p.handler("HandleLiquidFlow", time.Now())
+	h.h.HandleLiquidFlow(ctx, from, into, liquid, replaced)
+}
+
+func (h profiledHandler) HandleLiquidDecay(ctx *Context, pos cube.Pos, before, after Liquid) {
+	defer h.p.handler("HandleLiquidDecay", time.Now())
+	h.h.HandleLiquidDecay(ctx, pos, before, after)
+}
+
+func (h profiledHandler) HandleLiquidHarden(ctx *Context, hardenedPos cube.Pos, liquidHardened, otherLiquid, newBlock Block) {
+	defer h.p.handler("HandleLiquidHarden", time.Now())
+	h.h.HandleLiquidHarden(ctx, hardenedPos, liquidHardened, otherLiquid, newBlock)
+}
+
+func (h profiledHandler) HandleSound(ctx *Context, s Sound, pos mgl64.Vec3) {
+	defer h.p.handler("HandleSound", time.Now())
+	h.h.HandleSound(ctx, s, pos)
+}
+
+func (h profiledHandler) HandleFireSpread(ctx *Context, from, to cube.Pos) {
+	defer h.p.handler("HandleFireSpread", time.Now())
+	h.h.HandleFireSpread(ctx, from, to)
+}
+
+func (h profiledHandler) HandleBlockBurn(ctx *Context, pos cube.Pos) {
+	defer h.p.handler("HandleBlockBurn", time.Now())
+	h.h.HandleBlockBurn(ctx, pos)
+}
+
+func (h profiledHandler) HandleCropTrample(ctx *Context, pos cube.Pos) {
+	defer h.p.handler("HandleCropTrample", time.Now())
+	h.h.HandleCropTrample(ctx, pos)
+}
+
+func (h profiledHandler) HandleLeavesDecay(ctx *Context, pos cube.Pos) {
+	defer h.p.handler("HandleLeavesDecay", time.Now())
+	h.h.HandleLeavesDecay(ctx, pos)
+}
+
+func (h profiledHandler) HandlePortalCreate(ctx *Context, portalType Dimension, positions []cube.Pos) {
+	defer h.p.handler("HandlePortalCreate", time.Now())
+	h.h.HandlePortalCreate(ctx, portalType, positions)
+}
+
+func (h profiledHandler) HandlePortalActivate(ctx *Context, portalType Dimension, positions []cube.Pos) {
+	defer h.p.handler("HandlePortalActivate", time.Now())
+	h.h.HandlePortalActivate(ctx, portalType, positions)
+}
+
+func (h profiledHandler) HandleEntitySpawn(tx *Tx, e Entity) {
+	defer h.p.handler("HandleEntitySpawn", time.Now())
+	h.h.HandleEntitySpawn(tx, e)
+}
+
+func (h profiledHandler) HandleEntityDespawn(tx *Tx, e Entity) {
+	defer h.p.handler("HandleEntityDespawn", time.Now())
+	h.h.HandleEntityDespawn(tx, e)
+}
+
+func (h profiledHandler) HandleExplosion(ctx *Context, src ExplosionSource, entities *[]Entity, blocks *[]cube.Pos, itemDropChance *float64, spawnFire *bool) {
+	defer h.p.handler("HandleExplosion", time.Now())
+	h.h.HandleExplosion(ctx, src, entities, blocks, itemDropChance, spawnFire)
+}
+
+func (h profiledHandler) HandleRedstoneUpdate(ctx *Context, update RedstoneUpdate) {
+	defer h.p.handler("HandleRedstoneUpdate", time.Now())
+	h.h.HandleRedstoneUpdate(ctx, update)
+}
+
+func (h profiledHandler) HandleClose(tx *Tx) {
+	defer h.p.handler("HandleClose", time.Now())
+	h.h.HandleClose(tx)
+}
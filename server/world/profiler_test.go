@@ -0,0 +1,50 @@
+package world
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestWorldProfiling(t *testing.T) {
+	w := Config{Synchronous: true}.New()
+	defer w.Close()
+
+	if _, ok := w.ProfileReport(); ok {
+		t.Fatalf("expected no report before profiling was started")
+	}
+	w.Handle(profilerTestHandler{})
+	w.StartProfiling()
+	if _, ok := w.Handler().(profilerTestHandler); !ok {
+		t.Errorf("expected World.Handler to return the handler set while profiling")
+	}
+	for range 3 {
+		w.AdvanceTick()
+	}
+	runWorld(w, func(tx *Tx) {
+		tx.Handler().HandleClose(tx)
+	})
+
+	r, ok := w.StopProfiling()
+	if !ok {
+		t.Fatalf("expected report after profiling was started")
+	}
+	if r.Ticks != 3 {
+		t.Errorf("expected 3 ticks to be profiled, got %v", r.Ticks)
+	}
+	if len(r.Sections) != 5 {
+		t.Errorf("expected 5 sections, got %v", r.Sections)
+	}
+	if len(r.Handlers) != 1 || r.Handlers[0].Name != "HandleClose" || r.Handlers[0].Count != 1 {
+		t.Errorf("expected a single HandleClose call, got %v", r.Handlers)
+	}
+	if s := r.String(); !strings.Contains(s, "Sections:") || !strings.Contains(s, "HandleClose") {
+		t.Errorf("unexpected report:\n%v", s)
+	}
+	runWorld(w, func(tx *Tx) {
+		if _, ok := tx.Handler().(profiledHandler); ok {
+			t.Errorf("expected handler not to be profiled after profiling was stopped")
+		}
+	})
+}
+
+type profilerTestHandler struct{ NopHandler }
@@ -1003,7 +1003,7 @@ func (e *redstoneEngine) redstonePower(source RedstonePowerSource, pos cube.Pos,
 // redstoneUpdateAllowed dispatches redstone callbacks and reports whether the update was cancelled.
 func (e *redstoneEngine) redstoneUpdateAllowed(tx *Tx, update RedstoneUpdate) bool {
 	ctx := tx.Event()
-	tx.World().Handler().HandleRedstoneUpdate(ctx, update)
+	tx.Handler().HandleRedstoneUpdate(ctx, update)
 	return !ctx.Cancelled()
 }
 
@@ -45,6 +45,9 @@ type Settings struct {
 	// TickRange is the radius in chunks around a Viewer that has its blocks and entities ticked when the world is
 	// ticked. If set to 0, blocks and entities will never be ticked.
 	TickRange int32
+	// Border is the Border of the World. A zero Border means the World has no border. The Border is
+	// shared by all worlds using these Settings, without scaling it between dimensions.
+	Border Border
 }
 
 // defaultSettings returns the default Settings for a new World.
@@ -60,3 +63,26 @@ func defaultSettings() *Settings {
 		TickRange:       6,
 	}
 }
+
+// clone returns a copy of the Settings that is not referenced by any World.
+func (s *Settings) clone() *Settings {
+	s.Lock()
+	defer s.Unlock()
+	return &Settings{
+		Name:               s.Name,
+		Spawn:              s.Spawn,
+		Time:               s.Time,
+		TimeCycle:          s.TimeCycle,
+		RainTime:           s.RainTime,
+		Raining:            s.Raining,
+		ThunderTime:        s.ThunderTime,
+		Thundering:         s.Thundering,
+		WeatherCycle:       s.WeatherCycle,
+		RequiredSleepTicks: s.RequiredSleepTicks,
+		CurrentTick:        s.CurrentTick,
+		DefaultGameMode:    s.DefaultGameMode,
+		Difficulty:         s.Difficulty,
+		TickRange:          s.TickRange,
+		Border:             s.Border,
+	}
+}
@@ -54,6 +54,15 @@ type EnderChestClose struct{ sound }
 // BarrelOpen is played when a barrel is opened.
 type BarrelOpen struct{ sound }
 
+// CrafterCraft is played when a crafter crafts an item.
+type CrafterCraft struct{ sound }
+
+// CrafterFail is played when a crafter is triggered, but the items in its grid do not match any recipe.
+type CrafterFail struct{ sound }
+
+// CrafterDisableSlot is played when a slot of a crafter is disabled or enabled.
+type CrafterDisableSlot struct{ sound }
+
 // BarrelClose is played when a barrel is closed.
 type BarrelClose struct{ sound }
 
@@ -0,0 +1,148 @@
+package world
+
+import (
+	"fmt"
+	"slices"
+	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+	"github.com/df-mc/dragonfly/server/world/chunk"
+	"github.com/df-mc/goleveldb/leveldb"
+	"github.com/google/uuid"
+)
+
+// Template is an immutable snapshot of the chunks loaded in a World, including
+// their block entities, entities and scheduled block updates, and the
+// World's settings. Any number of independent Worlds may be created from a
+// single Template using Template.New. The chunks of these Worlds share their
+// block storage with the Template until it is changed, so that creating many
+// Worlds from one Template does not multiply memory usage.
+type Template struct {
+	dim     Dimension
+	set     *Settings
+	columns map[ChunkPos]*chunk.Column
+}
+
+// Template creates a Template from the chunks currently loaded in the World
+// and its settings. Players in the World are not part of the Template.
+// Template must not be called from within a transaction of the World.
+func (w *World) Template() *Template {
+	t := &Template{dim: w.conf.Dim, set: w.set.clone(), columns: make(map[ChunkPos]*chunk.Column)}
+	<-w.exec(func(*Tx) {
+		for pos, c := range w.chunks {
+			col := w.columnTo(c, pos)
+			col.Chunk = col.Chunk.Clone()
+			col.Entities = slices.DeleteFunc(col.Entities, func(e chunk.Entity) bool {
+				return e.Data["identifier"] == "minecraft:player"
+			})
+			t.columns[pos] = col
+		}
+	})
+	return t
+}
+
+// Clone creates a new World from a Template of the World, as returned by
+// World.Template. The new World uses the same Config as the World, except
+// that it has its own Provider, which serves the chunks of the Template and
+// keeps any changes in memory. The new World has its own tick loop and a
+// NopHandler, which may be replaced by calling World.Handle.
+// Clone must not be called from within a transaction of the World.
+func (w *World) Clone() *World {
+	conf := w.conf
+	if g, ok := conf.Generator.(*lockedGenerator); ok {
+		conf.Generator = g.g
+	}
+	conf.RandSource = nil
+	return w.Template().New(conf)
+}
+
+// Dimension returns the Dimension of the World that the Template was created
+// from.
+func (t *Template) Dimension() Dimension {
+	return t.dim
+}
+
+// Len returns the number of chunks held by the Template.
+func (t *Template) Len() int {
+	return len(t.columns)
+}
+
+// New creates a new World from the Template using the Config passed.
+// conf.Provider is replaced by a Provider that loads chunks from the
+// Template and keeps chunks stored to it in memory, so that the Template is
+// never changed and nothing is written to disk. Chunks not present in the
+// Template are generated using conf.Generator. conf.Dim is set to the
+// Dimension of the Template.
+func (t *Template) New(conf Config) *World {
+	conf.Dim = t.dim
+	conf.Provider = &templateProvider{t: t, set: t.set.clone(), changed: make(map[ChunkPos]*chunk.Column)}
+	return conf.New()
+}
+
+// templateProvider is a copy-on-write Provider that loads columns from a
+// Template. Columns stored to it are kept in memory.
+type templateProvider struct {
+	t *Template
+
+	mu      sync.Mutex
+	set     *Settings
+	changed map[ChunkPos]*chunk.Column
+	spawns  map[uuid.UUID]cube.Pos
+}
+
+func (p *templateProvider) Close() error { return nil }
+
+func (p *templateProvider) Settings() *Settings {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.set
+}
+
+func (p *templateProvider) SaveSettings(s *Settings) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.set = s
+}
+
+func (p *templateProvider) LoadPlayerSpawnPosition(id uuid.UUID) (cube.Pos, bool, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	pos, ok := p.spawns[id]
+	return pos, ok, nil
+}
+
+func (p *templateProvider) SavePlayerSpawnPosition(id uuid.UUID, pos cube.Pos) error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if p.spawns == nil {
+		p.spawns = make(map[uuid.UUID]cube.Pos)
+	}
+	p.spawns[id] = pos
+	return nil
+}
+
+func (p *templateProvider) LoadColumn(pos ChunkPos, dim Dimension) (*chunk.Column, error) {
+	p.mu.Lock()
+	col, ok := p.changed[pos]
+	p.mu.Unlock()
+	if !ok {
+		if col, ok = p.t.columns[pos]; !ok || dim != p.t.dim {
+			return nil, fmt.Errorf("load column %v (%v): %w", pos, dim, leveldb.ErrNotFound)
+		}
+	}
+	return col.Fork(), nil
+}
+
+func (p *templateProvider) StoreColumn(pos ChunkPos, dim Dimension, col *chunk.Column) error {
+	if dim != p.t.dim {
+		return nil
+	}
+	// The chunk of the column is still used by the World after storing it, so
+	// a copy is stored that is never changed and may be forked when loading.
+	c := *col
+	c.Chunk = col.Chunk.Clone()
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.changed[pos] = &c
+	return nil
+}
@@ -0,0 +1,38 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+func TestWorldClone(t *testing.T) {
+	pos := cube.Pos{1, 64, 1}
+	w := Config{Synchronous: true, Blocks: redstoneCancellationTestRegistry()}.New()
+	defer w.Close()
+	runWorld(w, func(tx *Tx) {
+		tx.SetBlock(pos, redstoneCancellationAction{}, nil)
+	})
+
+	tmpl := w.Template()
+	a, b := w.Clone(), tmpl.New(Config{Synchronous: true, Blocks: w.BlockRegistry()})
+	defer a.Close()
+	defer b.Close()
+
+	runWorld(a, func(tx *Tx) {
+		if _, ok := tx.Block(pos).(redstoneCancellationAction); !ok {
+			t.Fatalf("expected cloned world to have block at %v, got %#v", pos, tx.Block(pos))
+		}
+		tx.SetBlock(pos, redstoneCancellationSource{}, nil)
+	})
+	runWorld(b, func(tx *Tx) {
+		if _, ok := tx.Block(pos).(redstoneCancellationAction); !ok {
+			t.Errorf("expected world from template to be unaffected by changes to clone, got %#v", tx.Block(pos))
+		}
+	})
+	runWorld(w, func(tx *Tx) {
+		if _, ok := tx.Block(pos).(redstoneCancellationAction); !ok {
+			t.Errorf("expected original world to be unaffected by changes to clone, got %#v", tx.Block(pos))
+		}
+	})
+}
@@ -47,6 +47,9 @@ func (t ticker) tick(tx *Tx) {
 	viewers, loaders := tx.World().allViewers()
 	w := tx.World()
 
+	w.metrics.chunks.Set(float64(len(w.chunks)))
+	w.metrics.entities.Set(float64(len(w.entities)))
+
 	w.set.Lock()
 	if s := w.set.Spawn; s[1] > tx.Range()[1] && w.Dimension() == Overworld {
 		// Vanilla will set the spawn position's Y value to max to indicate that
@@ -67,8 +70,13 @@ func (t ticker) tick(tx *Tx) {
 		if w.set.WeatherCycle {
 			w.advanceWeather()
 		}
+		w.set.Border.tick()
 	}
 
+	start, p := time.Now(), w.profiler.Load()
+	defer w.metrics.tickDuration.ObserveSince(start)
+	defer p.tick(start)
+
 	rain, thunder, tick, tim, cycle := w.set.Raining, w.set.Thundering && w.set.Raining, w.set.CurrentTick, int(w.set.Time), w.set.TimeCycle
 
 	tryAdvanceDay := false
@@ -97,34 +105,53 @@ func (t ticker) tick(tx *Tx) {
 		w.tickLightning(tx)
 	}
 
-	t.tickEntities(tx, tick)
-	w.scheduledUpdates.tick(tx, tick)
-	t.tickBlocksRandomly(tx, loaders, tick)
-	t.performNeighbourUpdates(tx)
+	sectionStart := p.now()
+	t.tickEntities(tx, tick, p)
+	p.section(sectionEntities, sectionStart)
+
+	sectionStart = p.now()
+	w.scheduledUpdates.tick(tx, tick, p)
+	p.section(sectionScheduled, sectionStart)
+
+	sectionStart = p.now()
+	t.tickBlocksRandomly(tx, loaders, tick, p)
+	p.section(sectionRandom, sectionStart)
+
+	sectionStart = p.now()
+	t.performNeighbourUpdates(tx, p)
+	p.section(sectionNeighbourUpdates, sectionStart)
+
+	sectionStart = p.now()
 	w.redstone.tick(tx, tick)
+	p.section(sectionRedstone, sectionStart)
 }
 
 // performNeighbourUpdates performs all block updates that came as a result of a neighbouring block being changed.
-func (t ticker) performNeighbourUpdates(tx *Tx) {
+func (t ticker) performNeighbourUpdates(tx *Tx, p *profiler) {
 	updates := slices.Clone(tx.World().neighbourUpdates)
 	clear(tx.World().neighbourUpdates)
 	tx.World().neighbourUpdates = tx.World().neighbourUpdates[:0]
 
 	for _, update := range updates {
 		pos, changedNeighbour := update.pos, update.neighbour
-		if ticker, ok := tx.Block(pos).(NeighbourUpdateTicker); ok {
+		b := tx.Block(pos)
+		if ticker, ok := b.(NeighbourUpdateTicker); ok {
+			start := p.now()
 			ticker.NeighbourUpdateTick(pos, changedNeighbour, tx)
+			p.block(b, pos, start)
 		}
 		if liquid, ok := tx.additionalLiquid(pos); ok {
 			if ticker, ok := liquid.(NeighbourUpdateTicker); ok {
+				start := p.now()
 				ticker.NeighbourUpdateTick(pos, changedNeighbour, tx)
+				p.block(liquid, pos, start)
 			}
 		}
 	}
 }
 
 // tickBlocksRandomly executes random block ticks in loaded chunks within range of loaders.
-func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
+func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64, p *profiler) {
 	var (
 		r             = int32(tx.World().tickRange())
 		g             randUint4
@@ -183,13 +210,19 @@ func (t ticker) tickBlocksRandomly(tx *Tx, loaders []*Loader, tick int64) {
 	}
 
 	for _, pos := range randomBlocks {
-		if rb, ok := tx.Block(pos).(RandomTicker); ok {
+		b := tx.Block(pos)
+		if rb, ok := b.(RandomTicker); ok {
+			start := p.now()
 			rb.RandomTick(pos, tx, tx.World().r)
+			p.block(b, pos, start)
 		}
 	}
 	for _, pos := range blockEntities {
-		if tb, ok := tx.Block(pos).(TickerBlock); ok {
+		b := tx.Block(pos)
+		if tb, ok := b.(TickerBlock); ok {
+			start := p.now()
 			tb.Tick(tick, pos, tx)
+			p.block(b, pos, start)
 		}
 	}
 }
@@ -209,7 +242,7 @@ func (t ticker) anyWithinDistance(pos ChunkPos, loaded []ChunkPos, r int32) bool
 
 // tickEntities ticks all entities in the world, making sure they are still located in the correct chunks and
 // updating where necessary.
-func (t ticker) tickEntities(tx *Tx, tick int64) {
+func (t ticker) tickEntities(tx *Tx, tick int64, p *profiler) {
 	for handle, lastPos := range tx.World().entities {
 		e := handle.mustEntity(tx)
 		chunkPos := chunkPosFromVec3(handle.data.Pos)
@@ -253,7 +286,9 @@ func (t ticker) tickEntities(tx *Tx, tick int64) {
 
 		if tx.World().conf.Synchronous || len(c.viewers) > 0 {
 			if te, ok := e.(TickerEntity); ok {
+				start := p.now()
 				te.Tick(tx, tick)
+				p.entity(e, chunkPos, start)
 			}
 		}
 	}
@@ -306,7 +341,7 @@ func newScheduledTickQueue(tick int64) *scheduledTickQueue {
 // tick processes scheduled ticks, calling ScheduledTicker.ScheduledTick for any
 // block update that is scheduled for the tick passed, and removing it from the
 // queue.
-func (queue *scheduledTickQueue) tick(tx *Tx, tick int64) {
+func (queue *scheduledTickQueue) tick(tx *Tx, tick int64, p *profiler) {
 	queue.currentTick = tick
 
 	w := tx.World()
@@ -316,10 +351,14 @@ func (queue *scheduledTickQueue) tick(tx *Tx, tick int64) {
 		}
 		b := tx.Block(t.pos)
 		if ticker, ok := b.(ScheduledTicker); ok && w.conf.Blocks.BlockHash(b) == t.bhash {
+			start := p.now()
 			ticker.ScheduledTick(t.pos, tx, w.r)
+			p.block(b, t.pos, start)
 		} else if liquid, ok := tx.additionalLiquid(t.pos); ok && w.conf.Blocks.BlockHash(liquid) == t.bhash {
 			if ticker, ok := liquid.(ScheduledTicker); ok {
+				start := p.now()
 				ticker.ScheduledTick(t.pos, tx, w.r)
+				p.block(liquid, t.pos, start)
 			}
 		}
 	}
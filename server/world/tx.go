@@ -366,6 +366,13 @@ func (tx *Tx) World() *World {
 	return tx.w
 }
 
+// Handler returns the Handler that events of the Tx's World should be
+// dispatched to. Unlike World.Handler, the time spent handling events through
+// it is recorded while the World is being profiled.
+func (tx *Tx) Handler() Handler {
+	return tx.World().eventHandler()
+}
+
 // CurrentTick returns the current tick of the transaction's world.
 func (tx *Tx) CurrentTick() int64 {
 	w := tx.World()
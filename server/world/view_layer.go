@@ -4,6 +4,8 @@ import (
 	"maps"
 	"slices"
 	"sync"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
 )
 
 // layer stores the appearance overrides that a ViewLayer applies to an entity.
@@ -12,23 +14,50 @@ type layer struct {
 	alwaysShowNameTag *bool
 	scoreTag          *string
 	visibility        VisibilityLevel
+	scale             *float64
+	actions           func(a EntityAction) bool
+	disguise          EntityType
+	values            map[any]any
 }
 
-// ViewLayerUpdater handles immediate updates after a ViewLayer changes how an entity is viewed.
+// ViewLayerUpdater handles immediate updates after a ViewLayer changes how an entity is viewed. A
+// ViewLayerUpdater may additionally implement ViewLayerValueUpdater, ViewLayerDisguiseUpdater and
+// ViewLayerBlockUpdater to handle other changes of the ViewLayer.
 type ViewLayerUpdater interface {
 	// ViewLayerEntityChanged handles an entity whose view-layer overrides changed.
 	ViewLayerEntityChanged(entity Entity)
 }
 
+// ViewLayerValueUpdater is a ViewLayerUpdater that handles changes of the custom values set using
+// ViewLayer.ViewValue.
+type ViewLayerValueUpdater interface {
+	// ViewLayerValueChanged handles an entity whose custom value identified by key changed.
+	ViewLayerValueChanged(entity Entity, key any)
+}
+
+// ViewLayerDisguiseUpdater is a ViewLayerUpdater that handles changes of the disguise of an entity.
+type ViewLayerDisguiseUpdater interface {
+	// ViewLayerDisguiseChanged handles an entity whose disguise override changed.
+	ViewLayerDisguiseChanged(entity Entity)
+}
+
+// ViewLayerBlockUpdater is a ViewLayerUpdater that handles changes of block overrides.
+type ViewLayerBlockUpdater interface {
+	// ViewLayerBlockChanged handles a block position whose view-layer override changed.
+	ViewLayerBlockChanged(pos cube.Pos)
+}
+
 type viewLayerViewer interface {
 	ViewLayer() *ViewLayer
 }
 
-// ViewLayer holds overrides for how entities are viewed by a single viewer. It allows entities to be
-// viewed differently by different players, such as with a different name tag or visibility state.
+// ViewLayer holds overrides for how entities and blocks are viewed by a single viewer. It allows entities to be
+// viewed differently by different players, such as with a different name tag or visibility state, and blocks
+// to be shown that do not exist in the world.
 type ViewLayer struct {
 	mu       sync.RWMutex
 	entities map[*EntityHandle]layer
+	blocks   map[ChunkPos]map[cube.Pos]Block
 	updater  ViewLayerUpdater
 }
 
@@ -36,6 +65,7 @@ type ViewLayer struct {
 func NewViewLayer(updater ViewLayerUpdater) *ViewLayer {
 	return &ViewLayer{
 		entities: map[*EntityHandle]layer{},
+		blocks:   map[ChunkPos]map[cube.Pos]Block{},
 		updater:  updater,
 	}
 }
@@ -148,23 +178,131 @@ func (v *ViewLayer) Visibility(entity Entity) VisibilityLevel {
 	return v.entities[entity.H()].visibility
 }
 
+// ViewScale overwrites the public scale of the entity and allows this ViewLayer to view the entity with a
+// different size. The scale only changes how the entity is rendered, not its bounding box.
+func (v *ViewLayer) ViewScale(entity Entity, scale float64) {
+	v.update(entity, func(l *layer) {
+		l.scale = &scale
+	})
+}
+
+// ViewPublicScale removes the scale override from the entity, causing the public scale to be viewed again.
+func (v *ViewLayer) ViewPublicScale(entity Entity) {
+	v.update(entity, func(l *layer) {
+		l.scale = nil
+	})
+}
+
+// Scale returns the overwritten scale of the entity and whether an override was set.
+func (v *ViewLayer) Scale(entity Entity) (float64, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	scale := v.entities[entity.H()].scale
+	if scale == nil {
+		return 0, false
+	}
+	return *scale, true
+}
+
+// ViewActions sets a function that decides which actions of the entity are viewed by this ViewLayer.
+// Actions for which f returns false are not shown. Passing nil shows all actions again.
+func (v *ViewLayer) ViewActions(entity Entity, f func(a EntityAction) bool) {
+	v.update(entity, func(l *layer) {
+		l.actions = f
+	})
+}
+
+// ViewsAction checks if the EntityAction passed performed by the entity is viewed by this ViewLayer.
+func (v *ViewLayer) ViewsAction(entity Entity, a EntityAction) bool {
+	v.mu.RLock()
+	f := v.entities[entity.H()].actions
+	v.mu.RUnlock()
+
+	return f == nil || f(a)
+}
+
+// ViewDisguise disguises the entity as an entity of the EntityType passed for this ViewLayer. The entity is
+// shown with the identifier and bounding box of the EntityType, but otherwise behaves as before. A disguise
+// set in the ViewLayer takes precedence over a disguise the entity has for all viewers.
+func (v *ViewLayer) ViewDisguise(entity Entity, t EntityType) {
+	v.update(entity, func(l *layer) {
+		l.disguise = t
+	})
+	v.refreshDisguise(entity)
+}
+
+// ViewPublicDisguise removes the disguise override from the entity, causing it to be viewed as its own type,
+// or with its public disguise, again.
+func (v *ViewLayer) ViewPublicDisguise(entity Entity) {
+	v.update(entity, func(l *layer) {
+		l.disguise = nil
+	})
+	v.refreshDisguise(entity)
+}
+
+// Disguise returns the EntityType the entity is disguised as and whether an override was set.
+func (v *ViewLayer) Disguise(entity Entity) (EntityType, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	t := v.entities[entity.H()].disguise
+	return t, t != nil
+}
+
+// ViewValue overwrites a custom value of the entity identified by key. It may be used by viewers to layer
+// overrides of properties that the ViewLayer does not know about, such as the items held by the entity. Keys
+// should be of an unexported type to avoid collisions, similar to context.Context values.
+func (v *ViewLayer) ViewValue(entity Entity, key, value any) {
+	v.update(entity, func(l *layer) {
+		if l.values == nil {
+			l.values = map[any]any{}
+		}
+		l.values[key] = value
+	})
+	v.refreshValue(entity, key)
+}
+
+// ViewPublicValue removes the custom value identified by key from the entity.
+func (v *ViewLayer) ViewPublicValue(entity Entity, key any) {
+	v.update(entity, func(l *layer) {
+		delete(l.values, key)
+	})
+	v.refreshValue(entity, key)
+}
+
+// Value returns the custom value of the entity identified by key and whether it was set.
+func (v *ViewLayer) Value(entity Entity, key any) (any, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	val, ok := v.entities[entity.H()].values[key]
+	return val, ok
+}
+
 // Remove removes all overrides for the entity from the ViewLayer.
 func (v *ViewLayer) Remove(entity Entity) {
-	if v.remove(entity) {
+	if l, ok := v.remove(entity); ok {
 		v.refresh(entity)
+		for key := range l.values {
+			v.refreshValue(entity, key)
+		}
+		if l.disguise != nil {
+			v.refreshDisguise(entity)
+		}
 	}
 }
 
 // remove removes all overrides for the entity from the ViewLayer without refreshing entity metadata. It returns
-// whether any overrides were removed.
-func (v *ViewLayer) remove(entity Entity) bool {
+// the overrides removed and whether any overrides were removed.
+func (v *ViewLayer) remove(entity Entity) (layer, bool) {
 	handle := entity.H()
 
 	v.mu.Lock()
-	_, ok := v.entities[handle]
+	l, ok := v.entities[handle]
 	delete(v.entities, handle)
 	v.mu.Unlock()
-	return ok
+	return l, ok
 }
 
 // update applies a mutation to the entity's layer, removes the entry if no overrides remain, and refreshes
@@ -185,18 +323,90 @@ func (v *ViewLayer) update(entity Entity, update func(*layer)) {
 	v.refresh(entity)
 }
 
+// ViewBlock overwrites the block at a position so that this ViewLayer views the Block passed instead of the
+// block actually in the world. The override only applies to the first layer of the block and is kept until
+// ViewPublicBlock is called or until the chunk containing the position is unloaded for the viewer.
+func (v *ViewLayer) ViewBlock(pos cube.Pos, b Block) {
+	chunkPos := chunkPosFromBlockPos(pos)
+
+	v.mu.Lock()
+	m, ok := v.blocks[chunkPos]
+	if !ok {
+		m = map[cube.Pos]Block{}
+		v.blocks[chunkPos] = m
+	}
+	m[pos] = b
+	v.mu.Unlock()
+
+	v.refreshBlock(pos)
+}
+
+// ViewPublicBlock removes the block override at a position, causing the block in the world to be viewed
+// again.
+func (v *ViewLayer) ViewPublicBlock(pos cube.Pos) {
+	chunkPos := chunkPosFromBlockPos(pos)
+
+	v.mu.Lock()
+	m := v.blocks[chunkPos]
+	_, ok := m[pos]
+	delete(m, pos)
+	if len(m) == 0 {
+		delete(v.blocks, chunkPos)
+	}
+	v.mu.Unlock()
+
+	if ok {
+		v.refreshBlock(pos)
+	}
+}
+
+// Block returns the overwritten block at a position and whether an override was set.
+func (v *ViewLayer) Block(pos cube.Pos) (Block, bool) {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	b, ok := v.blocks[chunkPosFromBlockPos(pos)][pos]
+	return b, ok
+}
+
+// ChunkBlocks returns all block overrides within the chunk at the position passed.
+func (v *ViewLayer) ChunkBlocks(pos ChunkPos) map[cube.Pos]Block {
+	v.mu.RLock()
+	defer v.mu.RUnlock()
+
+	return maps.Clone(v.blocks[pos])
+}
+
+// removeChunkBlocks removes all block overrides within the chunk at the position passed without refreshing
+// them. It is called when the chunk is unloaded for the viewer.
+func (v *ViewLayer) removeChunkBlocks(pos ChunkPos) {
+	v.mu.Lock()
+	delete(v.blocks, pos)
+	v.mu.Unlock()
+}
+
+// removeBlocks removes all block overrides without refreshing them. It is called when all chunks are
+// unloaded for the viewer, such as when it changes worlds.
+func (v *ViewLayer) removeBlocks() {
+	v.mu.Lock()
+	clear(v.blocks)
+	v.mu.Unlock()
+}
+
 // Close closes the view layer.
 func (v *ViewLayer) Close() error {
 	v.mu.Lock()
 	defer v.mu.Unlock()
 
 	clear(v.entities)
+	clear(v.blocks)
 	return nil
 }
 
 // empty checks if the layer does not override any public entity metadata.
 func (l layer) empty() bool {
-	return l.nameTag == nil && l.alwaysShowNameTag == nil && l.scoreTag == nil && l.visibility == PublicVisibility()
+	return l.nameTag == nil && l.alwaysShowNameTag == nil && l.scoreTag == nil && l.visibility == PublicVisibility() &&
+		l.scale == nil && l.actions == nil && l.disguise == nil && len(l.values) == 0
 }
 
 func (v *ViewLayer) refresh(entity Entity) {
@@ -204,3 +414,21 @@ func (v *ViewLayer) refresh(entity Entity) {
 		v.updater.ViewLayerEntityChanged(entity)
 	}
 }
+
+func (v *ViewLayer) refreshValue(entity Entity, key any) {
+	if u, ok := v.updater.(ViewLayerValueUpdater); ok {
+		u.ViewLayerValueChanged(entity, key)
+	}
+}
+
+func (v *ViewLayer) refreshDisguise(entity Entity) {
+	if u, ok := v.updater.(ViewLayerDisguiseUpdater); ok {
+		u.ViewLayerDisguiseChanged(entity)
+	}
+}
+
+func (v *ViewLayer) refreshBlock(pos cube.Pos) {
+	if u, ok := v.updater.(ViewLayerBlockUpdater); ok {
+		u.ViewLayerBlockChanged(pos)
+	}
+}
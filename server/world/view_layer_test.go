@@ -0,0 +1,116 @@
+package world
+
+import (
+	"testing"
+
+	"github.com/df-mc/dragonfly/server/block/cube"
+)
+
+// viewLayerRecorder is a ViewLayerUpdater that records the refreshes of a ViewLayer.
+type viewLayerRecorder struct {
+	entities, values, disguises int
+	blocks                      []cube.Pos
+}
+
+func (r *viewLayerRecorder) ViewLayerEntityChanged(Entity)     { r.entities++ }
+func (r *viewLayerRecorder) ViewLayerValueChanged(Entity, any) { r.values++ }
+func (r *viewLayerRecorder) ViewLayerDisguiseChanged(Entity)   { r.disguises++ }
+func (r *viewLayerRecorder) ViewLayerBlockChanged(pos cube.Pos) {
+	r.blocks = append(r.blocks, pos)
+}
+
+func TestViewLayerEntityOverrides(t *testing.T) {
+	r := &viewLayerRecorder{}
+	v := NewViewLayer(r)
+	e := taskTestEntity{h: NewEntity(taskTestEntityType{}, taskTestEntityConfig{})}
+
+	type (
+		key      struct{}
+		otherKey struct{}
+	)
+	v.ViewScale(e, 2)
+	v.ViewValue(e, otherKey{}, 1)
+	v.ViewValue(e, key{}, "value")
+	v.ViewActions(e, func(a EntityAction) bool { return false })
+	if scale, ok := v.Scale(e); !ok || scale != 2 {
+		t.Errorf("expected scale override 2, got %v (%v)", scale, ok)
+	}
+	if val, ok := v.Value(e, key{}); !ok || val != "value" {
+		t.Errorf("expected value override, got %v (%v)", val, ok)
+	}
+	if v.ViewsAction(e, nil) {
+		t.Errorf("expected action to be hidden")
+	}
+	if r.entities != 4 || r.values != 2 {
+		t.Errorf("expected 4 entity and 2 value refreshes, got %v and %v", r.entities, r.values)
+	}
+
+	v.ViewPublicScale(e)
+	v.ViewPublicValue(e, key{})
+	v.ViewActions(e, nil)
+	if len(v.Entities()) != 1 || r.values != 3 {
+		t.Errorf("expected value override to remain and removed value to be refreshed")
+	}
+	v.Remove(e)
+	if _, ok := v.Value(e, otherKey{}); ok || len(v.Entities()) != 0 || r.values != 4 {
+		t.Errorf("expected all overrides to be removed and the remaining value to be refreshed")
+	}
+
+	v.ViewDisguise(e, taskTestEntityType{})
+	if d, ok := v.Disguise(e); !ok || d != (taskTestEntityType{}) {
+		t.Errorf("expected disguise override, got %v (%v)", d, ok)
+	}
+	v.Remove(e)
+	if _, ok := v.Disguise(e); ok || r.disguises != 2 {
+		t.Errorf("expected disguise to be removed and refreshed, got %v refreshes", r.disguises)
+	}
+}
+
+func TestViewLayerOptionalUpdaters(t *testing.T) {
+	// A ViewLayerUpdater that only handles entity changes is not required to handle other changes.
+	r := &viewLayerEntityRecorder{}
+	v := NewViewLayer(r)
+	e := taskTestEntity{h: NewEntity(taskTestEntityType{}, taskTestEntityConfig{})}
+
+	v.ViewValue(e, struct{}{}, 1)
+	v.ViewDisguise(e, taskTestEntityType{})
+	v.ViewBlock(cube.Pos{}, scheduledTickTestBlock{})
+	v.Remove(e)
+	if r.entities != 3 {
+		t.Errorf("expected 3 entity refreshes, got %v", r.entities)
+	}
+}
+
+// viewLayerEntityRecorder is a ViewLayerUpdater that only implements the required methods.
+type viewLayerEntityRecorder struct{ entities int }
+
+func (r *viewLayerEntityRecorder) ViewLayerEntityChanged(Entity) { r.entities++ }
+
+func TestViewLayerBlocks(t *testing.T) {
+	r := &viewLayerRecorder{}
+	v := NewViewLayer(r)
+
+	a, b := cube.Pos{1, 64, -1}, cube.Pos{40, 10, 3}
+	v.ViewBlock(a, scheduledTickTestBlock{})
+	v.ViewBlock(b, scheduledTickTestBlock{})
+	if _, ok := v.Block(a); !ok {
+		t.Fatalf("expected override at %v", a)
+	}
+	if blocks := v.ChunkBlocks(ChunkPos{0, -1}); len(blocks) != 1 {
+		t.Errorf("expected 1 override in chunk, got %v", len(blocks))
+	}
+
+	v.ViewPublicBlock(a)
+	v.ViewPublicBlock(a)
+	if _, ok := v.Block(a); ok {
+		t.Errorf("expected override at %v to be removed", a)
+	}
+	if len(r.blocks) != 3 {
+		t.Errorf("expected 3 refreshed blocks, got %v", r.blocks)
+	}
+
+	v.removeChunkBlocks(ChunkPos{2, 0})
+	if _, ok := v.Block(b); ok {
+		t.Errorf("expected override at %v to be removed with its chunk", b)
+	}
+}
@@ -52,6 +52,10 @@ type World struct {
 
 	set     *Settings
 	handler atomic.Pointer[Handler]
+	metrics worldMetrics
+	// profiler records the time spent on ticks of the World. It is nil unless
+	// profiling was started using World.StartProfiling.
+	profiler atomic.Pointer[profiler]
 
 	weather
 
@@ -825,7 +829,7 @@ func (w *World) addParticle(pos mgl64.Vec3, p Particle) {
 // position will be able to hear the sound if they are close enough.
 func (w *World) playSound(tx *Tx, pos mgl64.Vec3, s Sound) {
 	ctx := tx.Event()
-	if w.Handler().HandleSound(ctx, s, pos); ctx.Cancelled() {
+	if tx.Handler().HandleSound(ctx, s, pos); ctx.Cancelled() {
 		return
 	}
 	s.Play(w, pos)
@@ -857,7 +861,7 @@ func (w *World) addEntityAt(tx *Tx, handle *EntityHandle, pos mgl64.Vec3) Entity
 		// Show the entity to all viewers in the chunk of the entity.
 		showEntity(e, v)
 	}
-	w.Handler().HandleEntitySpawn(tx, e)
+	tx.Handler().HandleEntitySpawn(tx, e)
 	handle.markWorldReady(w)
 	return e
 }
@@ -873,7 +877,7 @@ func (w *World) removeEntity(e Entity, tx *Tx) *EntityHandle {
 		// The entity currently isn't in this world.
 		return nil
 	}
-	w.Handler().HandleEntityDespawn(tx, e)
+	tx.Handler().HandleEntityDespawn(tx, e)
 
 	c := tx.chunk(pos)
 	c.Entities, c.modified = sliceutil.DeleteVal(c.Entities, handle), true
@@ -1187,7 +1191,9 @@ func (w *World) save(f func(*Tx, ChunkPos, *Column)) execFunc {
 			f(tx, pos, c)
 		}
 		w.conf.Log.Debug("Updating level.dat values...")
+		start := time.Now()
 		w.conf.Provider.SaveSettings(w.set)
+		w.metrics.saveSettings.ObserveSince(start)
 	}
 }
 
@@ -1195,9 +1201,11 @@ func (w *World) save(f func(*Tx, ChunkPos, *Column)) execFunc {
 func (w *World) saveChunk(_ *Tx, pos ChunkPos, c *Column) {
 	if !w.conf.ReadOnly && c.modified {
 		c.Compact()
+		start := time.Now()
 		if err := w.conf.Provider.StoreColumn(pos, w.conf.Dim, w.columnTo(c, pos)); err != nil {
 			w.conf.Log.Error("save chunk: "+err.Error(), "X", pos[0], "Z", pos[1])
 		}
+		w.metrics.saveColumn.ObserveSince(start)
 	}
 }
 
@@ -1238,7 +1246,7 @@ func (w *World) close() {
 	w.scheduleMu.Unlock()
 	<-w.exec(func(tx *Tx) {
 		// Let user code run anything that needs to be finished before closing.
-		w.Handler().HandleClose(tx)
+		tx.Handler().HandleClose(tx)
 		tx.runDeferred()
 		w.Handle(NopHandler{})
 
@@ -1255,6 +1263,7 @@ func (w *World) close() {
 
 	close(w.queueClosing)
 	w.queueing.Wait()
+	w.metrics.remove(w.conf.Metrics)
 
 	if w.set.ref.Add(-1); !w.advance {
 		return
@@ -1337,6 +1346,19 @@ func (w *World) Handler() Handler {
 	return *w.handler.Load()
 }
 
+// eventHandler returns the Handler that events of the World are dispatched
+// to. While the World is being profiled, the Handler of the World is wrapped
+// so that the time spent handling events is recorded.
+func (w *World) eventHandler() Handler {
+	if w == nil {
+		return NopHandler{}
+	}
+	if p := w.profiler.Load(); p != nil {
+		return profiledHandler{h: *w.handler.Load(), p: p}
+	}
+	return *w.handler.Load()
+}
+
 // showEntity shows an Entity to a viewer of the world. It makes sure
 // everything of the Entity, including the items held, is shown.
 func showEntity(e Entity, viewer Viewer) {
@@ -1378,19 +1400,29 @@ func (tx *Tx) chunk(pos ChunkPos) *Column {
 // loadChunk loads a chunk from the provider, or generates a chunk if one
 // doesn't currently exist, and calculates the light within it.
 func (w *World) loadChunk(pos ChunkPos) (*chunk.Column, error) {
+	start := time.Now()
 	column, err := w.conf.Provider.LoadColumn(pos, w.conf.Dim)
+	w.metrics.chunkLoad.ObserveSince(start)
 	if err != nil {
 		if !errors.Is(err, leveldb.ErrNotFound) {
 			return nil, err
 		}
-		ch := chunk.New(w.conf.Blocks, w.Range())
-		w.conf.Generator.GenerateChunk(pos, ch)
-		column = &chunk.Column{Chunk: ch}
+		column = &chunk.Column{Chunk: w.generateChunk(pos)}
 	}
 	chunk.LightArea([]*chunk.Chunk{column.Chunk}, int(pos[0]), int(pos[1])).Fill()
 	return column, nil
 }
 
+// generateChunk generates a new chunk at the position passed using the
+// Generator of the World.
+func (w *World) generateChunk(pos ChunkPos) *chunk.Chunk {
+	start := time.Now()
+	c := chunk.New(w.conf.Blocks, w.Range())
+	w.conf.Generator.GenerateChunk(pos, c)
+	w.metrics.chunkGenerate.ObserveSince(start)
+	return c
+}
+
 // emptyColumn returns an empty column, used as a stand-in when a chunk could
 // not be loaded.
 func (w *World) emptyColumn() *Column {